@@ -10,6 +10,7 @@ It is generated from these files:
 
 It has these top-level messages:
 	ArtefactList
+	DegradedSource
 	DownloadRequest
 	FileStream
 	Reference
@@ -18,10 +19,16 @@ It has these top-level messages:
 	Contents
 	SetAccessRequest
 	FindRequest
+	ArtefactFilter
+	ListRequest
 	GetVersionRequest
 	BuildList
+	BuildInfo
+	BuildForCommitRequest
+	BuildListRequest
 	DirListRequest
 	FileRequest
+	ArchiveRequest
 	FileInfo
 	DirInfo
 	ArtefactInfo
@@ -30,10 +37,37 @@ It has these top-level messages:
 	FileExistsInfo
 	ID
 	ArtefactID
+	FileChecksum
+	RepoArtefact
+	Webhook
+	WebhookList
 	ArtefactMeta
 	CreateArtefactRequest
 	CreateArtefactResponse
+	BranchList
+	WatchRequest
+	BuildEvent
 	LatestBuild
+	BuildRepoServer
+	BuildRepoServerList
+	BuildRepoAddress
+	FindFilesRequest
+	FileMatch
+	FileMatchList
+	Channel
+	ChannelChange
+	PromoteRequest
+	ChannelList
+	ChannelChangeList
+	BuildMark
+	BuildRecord
+	RetentionRule
+	PlannedDeletion
+	RetentionPlan
+	DiffRequest
+	FileDiff
+	BuildDiff
+	MarkBuildRequest
 */
 package artefact
 
@@ -83,8 +117,101 @@ func (x ContentType) String() string {
 }
 func (ContentType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+type ArtefactOrder int32
+
+const (
+	ArtefactOrder_ByName        ArtefactOrder = 0
+	ArtefactOrder_ByLatestBuild ArtefactOrder = 1
+)
+
+var ArtefactOrder_name = map[int32]string{
+	0: "ByName",
+	1: "ByLatestBuild",
+}
+var ArtefactOrder_value = map[string]int32{
+	"ByName":        0,
+	"ByLatestBuild": 1,
+}
+
+func (x ArtefactOrder) String() string {
+	return proto.EnumName(ArtefactOrder_name, int32(x))
+}
+func (ArtefactOrder) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type BuildState int32
+
+const (
+	BuildState_Available  BuildState = 0
+	BuildState_Deprecated BuildState = 1
+	BuildState_Yanked     BuildState = 2
+)
+
+var BuildState_name = map[int32]string{
+	0: "Available",
+	1: "Deprecated",
+	2: "Yanked",
+}
+var BuildState_value = map[string]int32{
+	"Available":  0,
+	"Deprecated": 1,
+	"Yanked":     2,
+}
+
+func (x BuildState) String() string {
+	return proto.EnumName(BuildState_name, int32(x))
+}
+func (BuildState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type ArchiveFormat int32
+
+const (
+	ArchiveFormat_ZIP   ArchiveFormat = 0
+	ArchiveFormat_TARGZ ArchiveFormat = 1
+)
+
+var ArchiveFormat_name = map[int32]string{
+	0: "ZIP",
+	1: "TARGZ",
+}
+var ArchiveFormat_value = map[string]int32{
+	"ZIP":   0,
+	"TARGZ": 1,
+}
+
+func (x ArchiveFormat) String() string {
+	return proto.EnumName(ArchiveFormat_name, int32(x))
+}
+func (ArchiveFormat) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type FileChange int32
+
+const (
+	FileChange_FileAdded    FileChange = 0
+	FileChange_FileRemoved  FileChange = 1
+	FileChange_FileModified FileChange = 2
+)
+
+var FileChange_name = map[int32]string{
+	0: "FileAdded",
+	1: "FileRemoved",
+	2: "FileModified",
+}
+var FileChange_value = map[string]int32{
+	"FileAdded":    0,
+	"FileRemoved":  1,
+	"FileModified": 2,
+}
+
+func (x FileChange) String() string {
+	return proto.EnumName(FileChange_name, int32(x))
+}
+func (FileChange) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
 type ArtefactList struct {
-	Artefacts []*Contents `protobuf:"bytes,1,rep,name=Artefacts" json:"Artefacts,omitempty"`
+	Artefacts        []*Contents       `protobuf:"bytes,1,rep,name=Artefacts" json:"Artefacts,omitempty"`
+	NextPageToken    string            `protobuf:"bytes,4,opt,name=NextPageToken" json:"NextPageToken,omitempty"`
+	Degraded         []*DegradedSource `protobuf:"bytes,2,rep,name=Degraded" json:"Degraded,omitempty"`
+	CatalogueUpdated uint32            `protobuf:"varint,3,opt,name=CatalogueUpdated" json:"CatalogueUpdated,omitempty"`
 }
 
 func (m *ArtefactList) Reset()                    { *m = ArtefactList{} }
@@ -99,6 +226,60 @@ func (m *ArtefactList) GetArtefacts() []*Contents {
 	return nil
 }
 
+func (m *ArtefactList) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *ArtefactList) GetDegraded() []*DegradedSource {
+	if m != nil {
+		return m.Degraded
+	}
+	return nil
+}
+
+func (m *ArtefactList) GetCatalogueUpdated() uint32 {
+	if m != nil {
+		return m.CatalogueUpdated
+	}
+	return 0
+}
+
+// a buildrepo which failed to answer a request
+type DegradedSource struct {
+	Domain    string `protobuf:"bytes,1,opt,name=Domain" json:"Domain,omitempty"`
+	BuildRepo string `protobuf:"bytes,2,opt,name=BuildRepo" json:"BuildRepo,omitempty"`
+	Error     string `protobuf:"bytes,3,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *DegradedSource) Reset()                    { *m = DegradedSource{} }
+func (m *DegradedSource) String() string            { return proto.CompactTextString(m) }
+func (*DegradedSource) ProtoMessage()               {}
+func (*DegradedSource) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *DegradedSource) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *DegradedSource) GetBuildRepo() string {
+	if m != nil {
+		return m.BuildRepo
+	}
+	return ""
+}
+
+func (m *DegradedSource) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type DownloadRequest struct {
 	Reference string `protobuf:"bytes,1,opt,name=Reference" json:"Reference,omitempty"`
 }
@@ -106,7 +287,7 @@ type DownloadRequest struct {
 func (m *DownloadRequest) Reset()                    { *m = DownloadRequest{} }
 func (m *DownloadRequest) String() string            { return proto.CompactTextString(m) }
 func (*DownloadRequest) ProtoMessage()               {}
-func (*DownloadRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*DownloadRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 func (m *DownloadRequest) GetReference() string {
 	if m != nil {
@@ -122,7 +303,7 @@ type FileStream struct {
 func (m *FileStream) Reset()                    { *m = FileStream{} }
 func (m *FileStream) String() string            { return proto.CompactTextString(m) }
 func (*FileStream) ProtoMessage()               {}
-func (*FileStream) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*FileStream) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 func (m *FileStream) GetData() []byte {
 	if m != nil {
@@ -138,7 +319,7 @@ type Reference struct {
 func (m *Reference) Reset()                    { *m = Reference{} }
 func (m *Reference) String() string            { return proto.CompactTextString(m) }
 func (*Reference) ProtoMessage()               {}
-func (*Reference) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*Reference) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *Reference) GetReference() string {
 	if m != nil {
@@ -155,7 +336,7 @@ type ArtefactRef struct {
 func (m *ArtefactRef) Reset()                    { *m = ArtefactRef{} }
 func (m *ArtefactRef) String() string            { return proto.CompactTextString(m) }
 func (*ArtefactRef) ProtoMessage()               {}
-func (*ArtefactRef) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*ArtefactRef) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *ArtefactRef) GetName() string {
 	if m != nil {
@@ -177,12 +358,13 @@ type SerialReference struct {
 	Texts     []string `protobuf:"bytes,3,rep,name=Texts" json:"Texts,omitempty"`
 	Domain    string   `protobuf:"bytes,4,opt,name=Domain" json:"Domain,omitempty"`
 	BuildRepo string   `protobuf:"bytes,5,opt,name=BuildRepo" json:"BuildRepo,omitempty"`
+	Branch    string   `protobuf:"bytes,6,opt,name=Branch" json:"Branch,omitempty"`
 }
 
 func (m *SerialReference) Reset()                    { *m = SerialReference{} }
 func (m *SerialReference) String() string            { return proto.CompactTextString(m) }
 func (*SerialReference) ProtoMessage()               {}
-func (*SerialReference) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*SerialReference) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *SerialReference) GetRefType() uint32 {
 	if m != nil {
@@ -219,6 +401,13 @@ func (m *SerialReference) GetBuildRepo() string {
 	return ""
 }
 
+func (m *SerialReference) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
 type Contents struct {
 	ReferenceVersion string       `protobuf:"bytes,1,opt,name=ReferenceVersion" json:"ReferenceVersion,omitempty"`
 	ReferenceLatest  string       `protobuf:"bytes,2,opt,name=ReferenceLatest" json:"ReferenceLatest,omitempty"`
@@ -231,18 +420,25 @@ type Contents struct {
 	Path             string       `protobuf:"bytes,9,opt,name=Path" json:"Path,omitempty"`
 	Downloadable     bool         `protobuf:"varint,10,opt,name=Downloadable" json:"Downloadable,omitempty"`
 	// the name of an artefact is not entirely sufficient. we may have multiple buildrepo servers too
-	Domain        string      `protobuf:"bytes,11,opt,name=Domain" json:"Domain,omitempty"`
-	ArtefactID    *ArtefactID `protobuf:"bytes,12,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
-	BuildRepo     string      `protobuf:"bytes,13,opt,name=BuildRepo" json:"BuildRepo,omitempty"`
-	RepositoryID  uint64      `protobuf:"varint,14,opt,name=RepositoryID" json:"RepositoryID,omitempty"`
-	LinkToVersion string      `protobuf:"bytes,15,opt,name=LinkToVersion" json:"LinkToVersion,omitempty"`
-	LinkToLatest  string      `protobuf:"bytes,16,opt,name=LinkToLatest" json:"LinkToLatest,omitempty"`
+	Domain         string      `protobuf:"bytes,11,opt,name=Domain" json:"Domain,omitempty"`
+	ArtefactID     *ArtefactID `protobuf:"bytes,12,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	BuildRepo      string      `protobuf:"bytes,13,opt,name=BuildRepo" json:"BuildRepo,omitempty"`
+	RepositoryID   uint64      `protobuf:"varint,14,opt,name=RepositoryID" json:"RepositoryID,omitempty"`
+	LinkToVersion  string      `protobuf:"bytes,15,opt,name=LinkToVersion" json:"LinkToVersion,omitempty"`
+	LinkToLatest   string      `protobuf:"bytes,16,opt,name=LinkToLatest" json:"LinkToLatest,omitempty"`
+	Branch         string      `protobuf:"bytes,17,opt,name=Branch" json:"Branch,omitempty"`
+	LinkToArchive  string      `protobuf:"bytes,18,opt,name=LinkToArchive" json:"LinkToArchive,omitempty"`
+	SHA256         string      `protobuf:"bytes,19,opt,name=SHA256" json:"SHA256,omitempty"`
+	BuildTimestamp uint32      `protobuf:"varint,20,opt,name=BuildTimestamp" json:"BuildTimestamp,omitempty"`
+	Score          float64     `protobuf:"fixed64,21,opt,name=Score" json:"Score,omitempty"`
+	Channels       []string    `protobuf:"bytes,22,rep,name=Channels" json:"Channels,omitempty"`
+	Build          *BuildInfo  `protobuf:"bytes,23,opt,name=Build" json:"Build,omitempty"`
 }
 
 func (m *Contents) Reset()                    { *m = Contents{} }
 func (m *Contents) String() string            { return proto.CompactTextString(m) }
 func (*Contents) ProtoMessage()               {}
-func (*Contents) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*Contents) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *Contents) GetReferenceVersion() string {
 	if m != nil {
@@ -356,6 +552,55 @@ func (m *Contents) GetLinkToLatest() string {
 	return ""
 }
 
+func (m *Contents) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *Contents) GetLinkToArchive() string {
+	if m != nil {
+		return m.LinkToArchive
+	}
+	return ""
+}
+
+func (m *Contents) GetSHA256() string {
+	if m != nil {
+		return m.SHA256
+	}
+	return ""
+}
+
+func (m *Contents) GetBuildTimestamp() uint32 {
+	if m != nil {
+		return m.BuildTimestamp
+	}
+	return 0
+}
+
+func (m *Contents) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Contents) GetChannels() []string {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *Contents) GetBuild() *BuildInfo {
+	if m != nil {
+		return m.Build
+	}
+	return nil
+}
+
 type SetAccessRequest struct {
 	Target *Reference `protobuf:"bytes,1,opt,name=Target" json:"Target,omitempty"`
 	UserID string     `protobuf:"bytes,2,opt,name=UserID" json:"UserID,omitempty"`
@@ -365,7 +610,7 @@ type SetAccessRequest struct {
 func (m *SetAccessRequest) Reset()                    { *m = SetAccessRequest{} }
 func (m *SetAccessRequest) String() string            { return proto.CompactTextString(m) }
 func (*SetAccessRequest) ProtoMessage()               {}
-func (*SetAccessRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*SetAccessRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *SetAccessRequest) GetTarget() *Reference {
 	if m != nil {
@@ -389,14 +634,20 @@ func (m *SetAccessRequest) GetGrant() bool {
 }
 
 type FindRequest struct {
-	// only return results containing this
-	NameMatch string `protobuf:"bytes,1,opt,name=NameMatch" json:"NameMatch,omitempty"`
+	// search term, matched against name, url and domain. results are ordered by relevance (see Contents.Score).
+	// if empty, all artefacts are returned (in Order)
+	NameMatch  string          `protobuf:"bytes,1,opt,name=NameMatch" json:"NameMatch,omitempty"`
+	Filter     *ArtefactFilter `protobuf:"bytes,2,opt,name=Filter" json:"Filter,omitempty"`
+	Order      ArtefactOrder   `protobuf:"varint,3,opt,name=Order,enum=artefact.ArtefactOrder" json:"Order,omitempty"`
+	PageSize   uint32          `protobuf:"varint,4,opt,name=PageSize" json:"PageSize,omitempty"`
+	PageToken  string          `protobuf:"bytes,5,opt,name=PageToken" json:"PageToken,omitempty"`
+	MaxResults uint32          `protobuf:"varint,6,opt,name=MaxResults" json:"MaxResults,omitempty"`
 }
 
 func (m *FindRequest) Reset()                    { *m = FindRequest{} }
 func (m *FindRequest) String() string            { return proto.CompactTextString(m) }
 func (*FindRequest) ProtoMessage()               {}
-func (*FindRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*FindRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *FindRequest) GetNameMatch() string {
 	if m != nil {
@@ -405,698 +656,2524 @@ func (m *FindRequest) GetNameMatch() string {
 	return ""
 }
 
-type GetVersionRequest struct {
-	Name    string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
-	Domain  string `protobuf:"bytes,2,opt,name=Domain" json:"Domain,omitempty"`
-	Version uint64 `protobuf:"varint,3,opt,name=Version" json:"Version,omitempty"`
+func (m *FindRequest) GetFilter() *ArtefactFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
 }
 
-func (m *GetVersionRequest) Reset()                    { *m = GetVersionRequest{} }
-func (m *GetVersionRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetVersionRequest) ProtoMessage()               {}
-func (*GetVersionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (m *FindRequest) GetOrder() ArtefactOrder {
+	if m != nil {
+		return m.Order
+	}
+	return ArtefactOrder_ByName
+}
 
-func (m *GetVersionRequest) GetName() string {
+func (m *FindRequest) GetPageSize() uint32 {
 	if m != nil {
-		return m.Name
+		return m.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (m *GetVersionRequest) GetDomain() string {
+func (m *FindRequest) GetPageToken() string {
 	if m != nil {
-		return m.Domain
+		return m.PageToken
 	}
 	return ""
 }
 
-func (m *GetVersionRequest) GetVersion() uint64 {
+func (m *FindRequest) GetMaxResults() uint32 {
 	if m != nil {
-		return m.Version
+		return m.MaxResults
 	}
 	return 0
 }
 
-type BuildList struct {
-	Builds []uint64 `protobuf:"varint,1,rep,packed,name=Builds" json:"Builds,omitempty"`
+// all fields are optional
+type ArtefactFilter struct {
+	Domain         string `protobuf:"bytes,1,opt,name=Domain" json:"Domain,omitempty"`
+	OrganisationID string `protobuf:"bytes,2,opt,name=OrganisationID" json:"OrganisationID,omitempty"`
+	NamePrefix     string `protobuf:"bytes,3,opt,name=NamePrefix" json:"NamePrefix,omitempty"`
+	BuiltSince     uint32 `protobuf:"varint,4,opt,name=BuiltSince" json:"BuiltSince,omitempty"`
 }
 
-func (m *BuildList) Reset()                    { *m = BuildList{} }
-func (m *BuildList) String() string            { return proto.CompactTextString(m) }
-func (*BuildList) ProtoMessage()               {}
-func (*BuildList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (m *ArtefactFilter) Reset()                    { *m = ArtefactFilter{} }
+func (m *ArtefactFilter) String() string            { return proto.CompactTextString(m) }
+func (*ArtefactFilter) ProtoMessage()               {}
+func (*ArtefactFilter) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
-func (m *BuildList) GetBuilds() []uint64 {
+func (m *ArtefactFilter) GetDomain() string {
 	if m != nil {
-		return m.Builds
+		return m.Domain
 	}
-	return nil
-}
-
-type DirListRequest struct {
-	Build      uint64 `protobuf:"varint,1,opt,name=Build" json:"Build,omitempty"`
-	Dir        string `protobuf:"bytes,2,opt,name=Dir" json:"Dir,omitempty"`
-	ArtefactID uint64 `protobuf:"varint,3,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	return ""
 }
 
-func (m *DirListRequest) Reset()                    { *m = DirListRequest{} }
-func (m *DirListRequest) String() string            { return proto.CompactTextString(m) }
-func (*DirListRequest) ProtoMessage()               {}
-func (*DirListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
-
-func (m *DirListRequest) GetBuild() uint64 {
+func (m *ArtefactFilter) GetOrganisationID() string {
 	if m != nil {
-		return m.Build
+		return m.OrganisationID
 	}
-	return 0
+	return ""
 }
 
-func (m *DirListRequest) GetDir() string {
+func (m *ArtefactFilter) GetNamePrefix() string {
 	if m != nil {
-		return m.Dir
+		return m.NamePrefix
 	}
 	return ""
 }
 
-func (m *DirListRequest) GetArtefactID() uint64 {
+func (m *ArtefactFilter) GetBuiltSince() uint32 {
 	if m != nil {
-		return m.ArtefactID
+		return m.BuiltSince
 	}
 	return 0
 }
 
-type FileRequest struct {
-	ArtefactID uint64 `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
-	Build      uint64 `protobuf:"varint,2,opt,name=Build" json:"Build,omitempty"`
-	Filename   string `protobuf:"bytes,3,opt,name=Filename" json:"Filename,omitempty"`
+type ListRequest struct {
+	Filter    *ArtefactFilter `protobuf:"bytes,1,opt,name=Filter" json:"Filter,omitempty"`
+	Order     ArtefactOrder   `protobuf:"varint,2,opt,name=Order,enum=artefact.ArtefactOrder" json:"Order,omitempty"`
+	PageSize  uint32          `protobuf:"varint,3,opt,name=PageSize" json:"PageSize,omitempty"`
+	PageToken string          `protobuf:"bytes,4,opt,name=PageToken" json:"PageToken,omitempty"`
 }
 
-func (m *FileRequest) Reset()                    { *m = FileRequest{} }
-func (m *FileRequest) String() string            { return proto.CompactTextString(m) }
-func (*FileRequest) ProtoMessage()               {}
-func (*FileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (m *ListRequest) Reset()                    { *m = ListRequest{} }
+func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()               {}
+func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
-func (m *FileRequest) GetArtefactID() uint64 {
+func (m *ListRequest) GetFilter() *ArtefactFilter {
 	if m != nil {
-		return m.ArtefactID
+		return m.Filter
 	}
-	return 0
+	return nil
 }
 
-func (m *FileRequest) GetBuild() uint64 {
+func (m *ListRequest) GetOrder() ArtefactOrder {
 	if m != nil {
-		return m.Build
+		return m.Order
+	}
+	return ArtefactOrder_ByName
+}
+
+func (m *ListRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
 	}
 	return 0
 }
 
-func (m *FileRequest) GetFilename() string {
+func (m *ListRequest) GetPageToken() string {
 	if m != nil {
-		return m.Filename
+		return m.PageToken
 	}
 	return ""
 }
 
-type FileInfo struct {
-	Name        string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
-	RelativeDir string `protobuf:"bytes,2,opt,name=RelativeDir" json:"RelativeDir,omitempty"`
+type GetVersionRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	Domain  string `protobuf:"bytes,2,opt,name=Domain" json:"Domain,omitempty"`
+	Version uint64 `protobuf:"varint,3,opt,name=Version" json:"Version,omitempty"`
+	Branch  string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
 }
 
-func (m *FileInfo) Reset()                    { *m = FileInfo{} }
-func (m *FileInfo) String() string            { return proto.CompactTextString(m) }
-func (*FileInfo) ProtoMessage()               {}
-func (*FileInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *GetVersionRequest) Reset()                    { *m = GetVersionRequest{} }
+func (m *GetVersionRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetVersionRequest) ProtoMessage()               {}
+func (*GetVersionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
-func (m *FileInfo) GetName() string {
+func (m *GetVersionRequest) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *FileInfo) GetRelativeDir() string {
+func (m *GetVersionRequest) GetDomain() string {
 	if m != nil {
-		return m.RelativeDir
+		return m.Domain
 	}
 	return ""
 }
 
-type DirInfo struct {
-	Name        string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
-	RelativeDir string `protobuf:"bytes,2,opt,name=RelativeDir" json:"RelativeDir,omitempty"`
-}
-
-func (m *DirInfo) Reset()                    { *m = DirInfo{} }
-func (m *DirInfo) String() string            { return proto.CompactTextString(m) }
-func (*DirInfo) ProtoMessage()               {}
-func (*DirInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
-
-func (m *DirInfo) GetName() string {
+func (m *GetVersionRequest) GetVersion() uint64 {
 	if m != nil {
-		return m.Name
+		return m.Version
 	}
-	return ""
+	return 0
 }
 
-func (m *DirInfo) GetRelativeDir() string {
+func (m *GetVersionRequest) GetBranch() string {
 	if m != nil {
-		return m.RelativeDir
+		return m.Branch
 	}
 	return ""
 }
 
-type ArtefactInfo struct {
-	ID   uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=Name" json:"Name,omitempty"`
+type BuildList struct {
+	Builds        []uint64     `protobuf:"varint,1,rep,packed,name=Builds" json:"Builds,omitempty"`
+	NextPageToken string       `protobuf:"bytes,2,opt,name=NextPageToken" json:"NextPageToken,omitempty"`
+	Infos         []*BuildInfo `protobuf:"bytes,3,rep,name=Infos" json:"Infos,omitempty"`
 }
 
-func (m *ArtefactInfo) Reset()                    { *m = ArtefactInfo{} }
-func (m *ArtefactInfo) String() string            { return proto.CompactTextString(m) }
-func (*ArtefactInfo) ProtoMessage()               {}
-func (*ArtefactInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (m *BuildList) Reset()                    { *m = BuildList{} }
+func (m *BuildList) String() string            { return proto.CompactTextString(m) }
+func (*BuildList) ProtoMessage()               {}
+func (*BuildList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
-func (m *ArtefactInfo) GetID() uint64 {
+func (m *BuildList) GetBuilds() []uint64 {
 	if m != nil {
-		return m.ID
+		return m.Builds
 	}
-	return 0
+	return nil
 }
 
-func (m *ArtefactInfo) GetName() string {
+func (m *BuildList) GetNextPageToken() string {
 	if m != nil {
-		return m.Name
+		return m.NextPageToken
 	}
 	return ""
 }
 
-type DirListing struct {
-	Files        []*FileInfo   `protobuf:"bytes,1,rep,name=Files" json:"Files,omitempty"`
-	Dirs         []*DirInfo    `protobuf:"bytes,2,rep,name=Dirs" json:"Dirs,omitempty"`
-	ArtefactInfo *ArtefactInfo `protobuf:"bytes,3,opt,name=ArtefactInfo" json:"ArtefactInfo,omitempty"`
-	Path         string        `protobuf:"bytes,4,opt,name=Path" json:"Path,omitempty"`
-}
-
-func (m *DirListing) Reset()                    { *m = DirListing{} }
-func (m *DirListing) String() string            { return proto.CompactTextString(m) }
-func (*DirListing) ProtoMessage()               {}
-func (*DirListing) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
-
-func (m *DirListing) GetFiles() []*FileInfo {
+func (m *BuildList) GetInfos() []*BuildInfo {
 	if m != nil {
-		return m.Files
+		return m.Infos
 	}
 	return nil
 }
 
-func (m *DirListing) GetDirs() []*DirInfo {
+type BuildInfo struct {
+	BuildID     uint64     `protobuf:"varint,1,opt,name=BuildID" json:"BuildID,omitempty"`
+	State       BuildState `protobuf:"varint,2,opt,name=State,enum=artefact.BuildState" json:"State,omitempty"`
+	StateReason string     `protobuf:"bytes,3,opt,name=StateReason" json:"StateReason,omitempty"`
+	// the following are "" (empty) or 0 if not known. the buildrepo only reports them for the latest build, so
+	// they are only known for builds which the artefact server saw as latest build of their branch
+	Branch        string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	CommitID      string `protobuf:"bytes,5,opt,name=CommitID" json:"CommitID,omitempty"`
+	CommitMessage string `protobuf:"bytes,6,opt,name=CommitMessage" json:"CommitMessage,omitempty"`
+	Timestamp     uint32 `protobuf:"varint,7,opt,name=Timestamp" json:"Timestamp,omitempty"`
+	BuildHost     string `protobuf:"bytes,8,opt,name=BuildHost" json:"BuildHost,omitempty"`
+	UserID        string `protobuf:"bytes,9,opt,name=UserID" json:"UserID,omitempty"`
+}
+
+func (m *BuildInfo) Reset()                    { *m = BuildInfo{} }
+func (m *BuildInfo) String() string            { return proto.CompactTextString(m) }
+func (*BuildInfo) ProtoMessage()               {}
+func (*BuildInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *BuildInfo) GetBuildID() uint64 {
 	if m != nil {
-		return m.Dirs
+		return m.BuildID
 	}
-	return nil
+	return 0
 }
 
-func (m *DirListing) GetArtefactInfo() *ArtefactInfo {
+func (m *BuildInfo) GetState() BuildState {
 	if m != nil {
-		return m.ArtefactInfo
+		return m.State
 	}
-	return nil
+	return BuildState_Available
 }
 
-func (m *DirListing) GetPath() string {
+func (m *BuildInfo) GetStateReason() string {
 	if m != nil {
-		return m.Path
+		return m.StateReason
 	}
 	return ""
 }
 
-type FileStreamResponse struct {
-	Filesize uint64 `protobuf:"varint,1,opt,name=Filesize" json:"Filesize,omitempty"`
-	Payload  []byte `protobuf:"bytes,2,opt,name=Payload,proto3" json:"Payload,omitempty"`
+func (m *BuildInfo) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
 }
 
-func (m *FileStreamResponse) Reset()                    { *m = FileStreamResponse{} }
-func (m *FileStreamResponse) String() string            { return proto.CompactTextString(m) }
-func (*FileStreamResponse) ProtoMessage()               {}
-func (*FileStreamResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (m *BuildInfo) GetCommitID() string {
+	if m != nil {
+		return m.CommitID
+	}
+	return ""
+}
 
-func (m *FileStreamResponse) GetFilesize() uint64 {
+func (m *BuildInfo) GetCommitMessage() string {
 	if m != nil {
-		return m.Filesize
+		return m.CommitMessage
+	}
+	return ""
+}
+
+func (m *BuildInfo) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
 	}
 	return 0
 }
 
-func (m *FileStreamResponse) GetPayload() []byte {
+func (m *BuildInfo) GetBuildHost() string {
 	if m != nil {
-		return m.Payload
+		return m.BuildHost
 	}
-	return nil
+	return ""
 }
 
-type FileExistsInfo struct {
-	Exists bool   `protobuf:"varint,1,opt,name=Exists" json:"Exists,omitempty"`
-	Size   uint64 `protobuf:"varint,2,opt,name=Size" json:"Size,omitempty"`
+func (m *BuildInfo) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
 }
 
-func (m *FileExistsInfo) Reset()                    { *m = FileExistsInfo{} }
-func (m *FileExistsInfo) String() string            { return proto.CompactTextString(m) }
-func (*FileExistsInfo) ProtoMessage()               {}
-func (*FileExistsInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+type BuildForCommitRequest struct {
+	ArtefactID uint64 `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	CommitID   string `protobuf:"bytes,2,opt,name=CommitID" json:"CommitID,omitempty"`
+}
 
-func (m *FileExistsInfo) GetExists() bool {
+func (m *BuildForCommitRequest) Reset()                    { *m = BuildForCommitRequest{} }
+func (m *BuildForCommitRequest) String() string            { return proto.CompactTextString(m) }
+func (*BuildForCommitRequest) ProtoMessage()               {}
+func (*BuildForCommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *BuildForCommitRequest) GetArtefactID() uint64 {
 	if m != nil {
-		return m.Exists
+		return m.ArtefactID
 	}
-	return false
+	return 0
 }
 
-func (m *FileExistsInfo) GetSize() uint64 {
+func (m *BuildForCommitRequest) GetCommitID() string {
 	if m != nil {
-		return m.Size
+		return m.CommitID
 	}
-	return 0
+	return ""
 }
 
-type ID struct {
-	ID uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+type BuildListRequest struct {
+	ArtefactID uint64 `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch     string `protobuf:"bytes,2,opt,name=Branch" json:"Branch,omitempty"`
+	PageSize   uint32 `protobuf:"varint,3,opt,name=PageSize" json:"PageSize,omitempty"`
+	PageToken  string `protobuf:"bytes,4,opt,name=PageToken" json:"PageToken,omitempty"`
 }
 
-func (m *ID) Reset()                    { *m = ID{} }
-func (m *ID) String() string            { return proto.CompactTextString(m) }
-func (*ID) ProtoMessage()               {}
-func (*ID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (m *BuildListRequest) Reset()                    { *m = BuildListRequest{} }
+func (m *BuildListRequest) String() string            { return proto.CompactTextString(m) }
+func (*BuildListRequest) ProtoMessage()               {}
+func (*BuildListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
-func (m *ID) GetID() uint64 {
+func (m *BuildListRequest) GetArtefactID() uint64 {
 	if m != nil {
-		return m.ID
+		return m.ArtefactID
 	}
 	return 0
 }
 
-// for database, stores meta information about artefacts
-type ArtefactID struct {
-	ID      uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
-	Domain  string `protobuf:"bytes,2,opt,name=Domain" json:"Domain,omitempty"`
-	Name    string `protobuf:"bytes,3,opt,name=Name" json:"Name,omitempty"`
-	URL     string `protobuf:"bytes,4,opt,name=URL" json:"URL,omitempty"`
-	Created uint32 `protobuf:"varint,5,opt,name=Created" json:"Created,omitempty"`
+func (m *BuildListRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
 }
 
-func (m *ArtefactID) Reset()                    { *m = ArtefactID{} }
-func (m *ArtefactID) String() string            { return proto.CompactTextString(m) }
-func (*ArtefactID) ProtoMessage()               {}
-func (*ArtefactID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
-
-func (m *ArtefactID) GetID() uint64 {
+func (m *BuildListRequest) GetPageSize() uint32 {
 	if m != nil {
-		return m.ID
+		return m.PageSize
 	}
 	return 0
 }
 
-func (m *ArtefactID) GetDomain() string {
+func (m *BuildListRequest) GetPageToken() string {
 	if m != nil {
-		return m.Domain
+		return m.PageToken
 	}
 	return ""
 }
 
-func (m *ArtefactID) GetName() string {
+type DirListRequest struct {
+	Build      uint64 `protobuf:"varint,1,opt,name=Build" json:"Build,omitempty"`
+	Dir        string `protobuf:"bytes,2,opt,name=Dir" json:"Dir,omitempty"`
+	ArtefactID uint64 `protobuf:"varint,3,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch     string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+}
+
+func (m *DirListRequest) Reset()                    { *m = DirListRequest{} }
+func (m *DirListRequest) String() string            { return proto.CompactTextString(m) }
+func (*DirListRequest) ProtoMessage()               {}
+func (*DirListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *DirListRequest) GetBuild() uint64 {
 	if m != nil {
-		return m.Name
+		return m.Build
 	}
-	return ""
+	return 0
 }
 
-func (m *ArtefactID) GetURL() string {
+func (m *DirListRequest) GetDir() string {
 	if m != nil {
-		return m.URL
+		return m.Dir
 	}
 	return ""
 }
 
-func (m *ArtefactID) GetCreated() uint32 {
+func (m *DirListRequest) GetArtefactID() uint64 {
 	if m != nil {
-		return m.Created
+		return m.ArtefactID
 	}
 	return 0
 }
 
-// metadata about an artefact
-type ArtefactMeta struct {
-	ID           uint64       `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
-	RepositoryID uint64       `protobuf:"varint,2,opt,name=RepositoryID" json:"RepositoryID,omitempty"`
-	LatestBuild  *LatestBuild `protobuf:"bytes,3,opt,name=LatestBuild" json:"LatestBuild,omitempty"`
+func (m *DirListRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
 }
 
-func (m *ArtefactMeta) Reset()                    { *m = ArtefactMeta{} }
-func (m *ArtefactMeta) String() string            { return proto.CompactTextString(m) }
-func (*ArtefactMeta) ProtoMessage()               {}
-func (*ArtefactMeta) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+type FileRequest struct {
+	ArtefactID     uint64 `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Build          uint64 `protobuf:"varint,2,opt,name=Build" json:"Build,omitempty"`
+	Filename       string `protobuf:"bytes,3,opt,name=Filename" json:"Filename,omitempty"`
+	Branch         string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	VerifyChecksum bool   `protobuf:"varint,5,opt,name=VerifyChecksum" json:"VerifyChecksum,omitempty"`
+}
 
-func (m *ArtefactMeta) GetID() uint64 {
+func (m *FileRequest) Reset()                    { *m = FileRequest{} }
+func (m *FileRequest) String() string            { return proto.CompactTextString(m) }
+func (*FileRequest) ProtoMessage()               {}
+func (*FileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *FileRequest) GetArtefactID() uint64 {
 	if m != nil {
-		return m.ID
+		return m.ArtefactID
 	}
 	return 0
 }
 
-func (m *ArtefactMeta) GetRepositoryID() uint64 {
+func (m *FileRequest) GetBuild() uint64 {
 	if m != nil {
-		return m.RepositoryID
+		return m.Build
 	}
 	return 0
 }
 
-func (m *ArtefactMeta) GetLatestBuild() *LatestBuild {
+func (m *FileRequest) GetFilename() string {
 	if m != nil {
-		return m.LatestBuild
+		return m.Filename
 	}
-	return nil
+	return ""
 }
 
-type CreateArtefactRequest struct {
-	OrganisationID  string `protobuf:"bytes,1,opt,name=OrganisationID" json:"OrganisationID,omitempty"`
-	ArtefactName    string `protobuf:"bytes,2,opt,name=ArtefactName" json:"ArtefactName,omitempty"`
-	BuildRepoDomain string `protobuf:"bytes,3,opt,name=BuildRepoDomain" json:"BuildRepoDomain,omitempty"`
-	GitURL          string `protobuf:"bytes,4,opt,name=GitURL" json:"GitURL,omitempty"`
+func (m *FileRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
 }
 
-func (m *CreateArtefactRequest) Reset()                    { *m = CreateArtefactRequest{} }
-func (m *CreateArtefactRequest) String() string            { return proto.CompactTextString(m) }
-func (*CreateArtefactRequest) ProtoMessage()               {}
-func (*CreateArtefactRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (m *FileRequest) GetVerifyChecksum() bool {
+	if m != nil {
+		return m.VerifyChecksum
+	}
+	return false
+}
 
-func (m *CreateArtefactRequest) GetOrganisationID() string {
+type ArchiveRequest struct {
+	ArtefactID uint64        `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Build      uint64        `protobuf:"varint,2,opt,name=Build" json:"Build,omitempty"`
+	Dir        string        `protobuf:"bytes,3,opt,name=Dir" json:"Dir,omitempty"`
+	Branch     string        `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	Format     ArchiveFormat `protobuf:"varint,5,opt,name=Format,enum=artefact.ArchiveFormat" json:"Format,omitempty"`
+}
+
+func (m *ArchiveRequest) Reset()                    { *m = ArchiveRequest{} }
+func (m *ArchiveRequest) String() string            { return proto.CompactTextString(m) }
+func (*ArchiveRequest) ProtoMessage()               {}
+func (*ArchiveRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ArchiveRequest) GetArtefactID() uint64 {
 	if m != nil {
-		return m.OrganisationID
+		return m.ArtefactID
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateArtefactRequest) GetArtefactName() string {
+func (m *ArchiveRequest) GetBuild() uint64 {
 	if m != nil {
-		return m.ArtefactName
+		return m.Build
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateArtefactRequest) GetBuildRepoDomain() string {
+func (m *ArchiveRequest) GetDir() string {
 	if m != nil {
-		return m.BuildRepoDomain
+		return m.Dir
 	}
 	return ""
 }
 
-func (m *CreateArtefactRequest) GetGitURL() string {
+func (m *ArchiveRequest) GetBranch() string {
 	if m != nil {
-		return m.GitURL
+		return m.Branch
 	}
 	return ""
 }
 
-type CreateArtefactResponse struct {
-	Created bool          `protobuf:"varint,1,opt,name=Created" json:"Created,omitempty"`
-	Meta    *ArtefactMeta `protobuf:"bytes,2,opt,name=Meta" json:"Meta,omitempty"`
+func (m *ArchiveRequest) GetFormat() ArchiveFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ArchiveFormat_ZIP
 }
 
-func (m *CreateArtefactResponse) Reset()                    { *m = CreateArtefactResponse{} }
-func (m *CreateArtefactResponse) String() string            { return proto.CompactTextString(m) }
-func (*CreateArtefactResponse) ProtoMessage()               {}
-func (*CreateArtefactResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+type FileInfo struct {
+	Name        string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	RelativeDir string `protobuf:"bytes,2,opt,name=RelativeDir" json:"RelativeDir,omitempty"`
+	SHA256      string `protobuf:"bytes,3,opt,name=SHA256" json:"SHA256,omitempty"`
+}
 
-func (m *CreateArtefactResponse) GetCreated() bool {
+func (m *FileInfo) Reset()                    { *m = FileInfo{} }
+func (m *FileInfo) String() string            { return proto.CompactTextString(m) }
+func (*FileInfo) ProtoMessage()               {}
+func (*FileInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *FileInfo) GetName() string {
 	if m != nil {
-		return m.Created
+		return m.Name
 	}
-	return false
+	return ""
 }
 
-func (m *CreateArtefactResponse) GetMeta() *ArtefactMeta {
+func (m *FileInfo) GetRelativeDir() string {
 	if m != nil {
-		return m.Meta
+		return m.RelativeDir
 	}
-	return nil
+	return ""
 }
 
-type LatestBuild struct {
-	BuildID       uint64 `protobuf:"varint,1,opt,name=BuildID" json:"BuildID,omitempty"`
-	UnixTimestamp uint32 `protobuf:"varint,2,opt,name=UnixTimestamp" json:"UnixTimestamp,omitempty"`
+func (m *FileInfo) GetSHA256() string {
+	if m != nil {
+		return m.SHA256
+	}
+	return ""
 }
 
-func (m *LatestBuild) Reset()                    { *m = LatestBuild{} }
-func (m *LatestBuild) String() string            { return proto.CompactTextString(m) }
-func (*LatestBuild) ProtoMessage()               {}
-func (*LatestBuild) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+type DirInfo struct {
+	Name        string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	RelativeDir string `protobuf:"bytes,2,opt,name=RelativeDir" json:"RelativeDir,omitempty"`
+}
 
-func (m *LatestBuild) GetBuildID() uint64 {
+func (m *DirInfo) Reset()                    { *m = DirInfo{} }
+func (m *DirInfo) String() string            { return proto.CompactTextString(m) }
+func (*DirInfo) ProtoMessage()               {}
+func (*DirInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *DirInfo) GetName() string {
 	if m != nil {
-		return m.BuildID
+		return m.Name
 	}
-	return 0
+	return ""
 }
 
-func (m *LatestBuild) GetUnixTimestamp() uint32 {
+func (m *DirInfo) GetRelativeDir() string {
 	if m != nil {
-		return m.UnixTimestamp
+		return m.RelativeDir
 	}
-	return 0
+	return ""
 }
 
-func init() {
-	proto.RegisterType((*ArtefactList)(nil), "artefact.ArtefactList")
-	proto.RegisterType((*DownloadRequest)(nil), "artefact.DownloadRequest")
-	proto.RegisterType((*FileStream)(nil), "artefact.FileStream")
-	proto.RegisterType((*Reference)(nil), "artefact.Reference")
-	proto.RegisterType((*ArtefactRef)(nil), "artefact.ArtefactRef")
-	proto.RegisterType((*SerialReference)(nil), "artefact.SerialReference")
-	proto.RegisterType((*Contents)(nil), "artefact.Contents")
-	proto.RegisterType((*SetAccessRequest)(nil), "artefact.SetAccessRequest")
-	proto.RegisterType((*FindRequest)(nil), "artefact.FindRequest")
-	proto.RegisterType((*GetVersionRequest)(nil), "artefact.GetVersionRequest")
-	proto.RegisterType((*BuildList)(nil), "artefact.BuildList")
-	proto.RegisterType((*DirListRequest)(nil), "artefact.DirListRequest")
-	proto.RegisterType((*FileRequest)(nil), "artefact.FileRequest")
-	proto.RegisterType((*FileInfo)(nil), "artefact.FileInfo")
-	proto.RegisterType((*DirInfo)(nil), "artefact.DirInfo")
-	proto.RegisterType((*ArtefactInfo)(nil), "artefact.ArtefactInfo")
-	proto.RegisterType((*DirListing)(nil), "artefact.DirListing")
-	proto.RegisterType((*FileStreamResponse)(nil), "artefact.FileStreamResponse")
-	proto.RegisterType((*FileExistsInfo)(nil), "artefact.FileExistsInfo")
-	proto.RegisterType((*ID)(nil), "artefact.ID")
-	proto.RegisterType((*ArtefactID)(nil), "artefact.ArtefactID")
-	proto.RegisterType((*ArtefactMeta)(nil), "artefact.ArtefactMeta")
-	proto.RegisterType((*CreateArtefactRequest)(nil), "artefact.CreateArtefactRequest")
-	proto.RegisterType((*CreateArtefactResponse)(nil), "artefact.CreateArtefactResponse")
-	proto.RegisterType((*LatestBuild)(nil), "artefact.LatestBuild")
-	proto.RegisterEnum("artefact.ContentType", ContentType_name, ContentType_value)
+type ArtefactInfo struct {
+	ID   uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=Name" json:"Name,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ArtefactInfo) Reset()                    { *m = ArtefactInfo{} }
+func (m *ArtefactInfo) String() string            { return proto.CompactTextString(m) }
+func (*ArtefactInfo) ProtoMessage()               {}
+func (*ArtefactInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
-// Client API for ArtefactService service
-
-type ArtefactServiceClient interface {
-	// list *latest* version of all artefacts (for this user)
-	List(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*ArtefactList, error)
-	// get contents of a directory or artefact.
-	GetContents(ctx context.Context, in *Reference, opts ...grpc.CallOption) (*Contents, error)
-	// download a file via http
-	StreamHTTP(ctx context.Context, in *h2gproxy.StreamRequest, opts ...grpc.CallOption) (ArtefactService_StreamHTTPClient, error)
-	// download a file via gRPC stream
-	GetFile(ctx context.Context, in *Reference, opts ...grpc.CallOption) (ArtefactService_GetFileClient, error)
-	// set access to a repo/artefact
-	SetAccess(ctx context.Context, in *SetAccessRequest, opts ...grpc.CallOption) (*common.Void, error)
-	// finds artefacts based on fuzzy string matches
-	Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*ArtefactList, error)
-	// get a specific version of a repository
-	GetRepoVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*Contents, error)
-	// get builds for a given artefact
-	GetArtefactBuilds(ctx context.Context, in *ArtefactID, opts ...grpc.CallOption) (*BuildList, error)
-	// get list of files for a given artefact, build and directory
-	GetDirListing(ctx context.Context, in *DirListRequest, opts ...grpc.CallOption) (*DirListing, error)
-	// get a file as stream
-	GetFileStream(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (ArtefactService_GetFileStreamClient, error)
-	// does file exist
-	DoesFileExist(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (*FileExistsInfo, error)
-	// get which repo fed this artefact
-	GetRepoForArtefact(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ID, error)
-	// get an artefact for a given repo
-	GetArtefactForRepo(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ID, error)
-	// get an artefact for a given repo
-	GetArtefactByID(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ArtefactID, error)
-	// get an artefactid for a given repo
-	GetArtefactIDForRepo(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ArtefactID, error)
-	// get artefact metadata by artefactid
-	MetaByID(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ArtefactMeta, error)
-	// create artefact if required. if it exists already it will not be recreated. URL may be added or updated
-	CreateArtefactIfRequired(ctx context.Context, in *CreateArtefactRequest, opts ...grpc.CallOption) (*CreateArtefactResponse, error)
-	LatestBuildForGoEasyops(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*LatestBuild, error)
+func (m *ArtefactInfo) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
 }
 
-type artefactServiceClient struct {
-	cc *grpc.ClientConn
+func (m *ArtefactInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func NewArtefactServiceClient(cc *grpc.ClientConn) ArtefactServiceClient {
-	return &artefactServiceClient{cc}
+type DirListing struct {
+	Files        []*FileInfo   `protobuf:"bytes,1,rep,name=Files" json:"Files,omitempty"`
+	Dirs         []*DirInfo    `protobuf:"bytes,2,rep,name=Dirs" json:"Dirs,omitempty"`
+	ArtefactInfo *ArtefactInfo `protobuf:"bytes,3,opt,name=ArtefactInfo" json:"ArtefactInfo,omitempty"`
+	Path         string        `protobuf:"bytes,4,opt,name=Path" json:"Path,omitempty"`
 }
 
-func (c *artefactServiceClient) List(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*ArtefactList, error) {
-	out := new(ArtefactList)
-	err := grpc.Invoke(ctx, "/artefact.ArtefactService/List", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DirListing) Reset()                    { *m = DirListing{} }
+func (m *DirListing) String() string            { return proto.CompactTextString(m) }
+func (*DirListing) ProtoMessage()               {}
+func (*DirListing) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *DirListing) GetFiles() []*FileInfo {
+	if m != nil {
+		return m.Files
 	}
-	return out, nil
+	return nil
 }
 
-func (c *artefactServiceClient) GetContents(ctx context.Context, in *Reference, opts ...grpc.CallOption) (*Contents, error) {
-	out := new(Contents)
-	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetContents", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DirListing) GetDirs() []*DirInfo {
+	if m != nil {
+		return m.Dirs
 	}
-	return out, nil
+	return nil
 }
 
-func (c *artefactServiceClient) StreamHTTP(ctx context.Context, in *h2gproxy.StreamRequest, opts ...grpc.CallOption) (ArtefactService_StreamHTTPClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_ArtefactService_serviceDesc.Streams[0], c.cc, "/artefact.ArtefactService/StreamHTTP", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &artefactServiceStreamHTTPClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *DirListing) GetArtefactInfo() *ArtefactInfo {
+	if m != nil {
+		return m.ArtefactInfo
 	}
-	return x, nil
+	return nil
 }
 
-type ArtefactService_StreamHTTPClient interface {
-	Recv() (*h2gproxy.StreamDataResponse, error)
-	grpc.ClientStream
+func (m *DirListing) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
 }
 
-type artefactServiceStreamHTTPClient struct {
-	grpc.ClientStream
+type FileStreamResponse struct {
+	Filesize uint64 `protobuf:"varint,1,opt,name=Filesize" json:"Filesize,omitempty"`
+	Payload  []byte `protobuf:"bytes,2,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	SHA256   string `protobuf:"bytes,3,opt,name=SHA256" json:"SHA256,omitempty"`
+	Warning  string `protobuf:"bytes,4,opt,name=Warning" json:"Warning,omitempty"`
 }
 
-func (x *artefactServiceStreamHTTPClient) Recv() (*h2gproxy.StreamDataResponse, error) {
-	m := new(h2gproxy.StreamDataResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *FileStreamResponse) Reset()                    { *m = FileStreamResponse{} }
+func (m *FileStreamResponse) String() string            { return proto.CompactTextString(m) }
+func (*FileStreamResponse) ProtoMessage()               {}
+func (*FileStreamResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *FileStreamResponse) GetFilesize() uint64 {
+	if m != nil {
+		return m.Filesize
 	}
-	return m, nil
+	return 0
 }
 
-func (c *artefactServiceClient) GetFile(ctx context.Context, in *Reference, opts ...grpc.CallOption) (ArtefactService_GetFileClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_ArtefactService_serviceDesc.Streams[1], c.cc, "/artefact.ArtefactService/GetFile", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &artefactServiceGetFileClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+func (m *FileStreamResponse) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *FileStreamResponse) GetSHA256() string {
+	if m != nil {
+		return m.SHA256
 	}
-	return x, nil
+	return ""
 }
 
-type ArtefactService_GetFileClient interface {
-	Recv() (*h2gproxy.StreamDataResponse, error)
-	grpc.ClientStream
+func (m *FileStreamResponse) GetWarning() string {
+	if m != nil {
+		return m.Warning
+	}
+	return ""
 }
 
-type artefactServiceGetFileClient struct {
-	grpc.ClientStream
+type FileExistsInfo struct {
+	Exists bool   `protobuf:"varint,1,opt,name=Exists" json:"Exists,omitempty"`
+	Size   uint64 `protobuf:"varint,2,opt,name=Size" json:"Size,omitempty"`
+	SHA256 string `protobuf:"bytes,3,opt,name=SHA256" json:"SHA256,omitempty"`
 }
 
-func (x *artefactServiceGetFileClient) Recv() (*h2gproxy.StreamDataResponse, error) {
-	m := new(h2gproxy.StreamDataResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *FileExistsInfo) Reset()                    { *m = FileExistsInfo{} }
+func (m *FileExistsInfo) String() string            { return proto.CompactTextString(m) }
+func (*FileExistsInfo) ProtoMessage()               {}
+func (*FileExistsInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *FileExistsInfo) GetExists() bool {
+	if m != nil {
+		return m.Exists
 	}
-	return m, nil
+	return false
 }
 
-func (c *artefactServiceClient) SetAccess(ctx context.Context, in *SetAccessRequest, opts ...grpc.CallOption) (*common.Void, error) {
-	out := new(common.Void)
-	err := grpc.Invoke(ctx, "/artefact.ArtefactService/SetAccess", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FileExistsInfo) GetSize() uint64 {
+	if m != nil {
+		return m.Size
 	}
-	return out, nil
+	return 0
 }
 
-func (c *artefactServiceClient) Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*ArtefactList, error) {
-	out := new(ArtefactList)
-	err := grpc.Invoke(ctx, "/artefact.ArtefactService/Find", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *FileExistsInfo) GetSHA256() string {
+	if m != nil {
+		return m.SHA256
 	}
-	return out, nil
+	return ""
 }
 
-func (c *artefactServiceClient) GetRepoVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*Contents, error) {
-	out := new(Contents)
-	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetRepoVersion", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ID struct {
+	ID uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
 }
 
-func (c *artefactServiceClient) GetArtefactBuilds(ctx context.Context, in *ArtefactID, opts ...grpc.CallOption) (*BuildList, error) {
-	out := new(BuildList)
-	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetArtefactBuilds", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ID) Reset()                    { *m = ID{} }
+func (m *ID) String() string            { return proto.CompactTextString(m) }
+func (*ID) ProtoMessage()               {}
+func (*ID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *ID) GetID() uint64 {
+	if m != nil {
+		return m.ID
 	}
-	return out, nil
+	return 0
 }
 
-func (c *artefactServiceClient) GetDirListing(ctx context.Context, in *DirListRequest, opts ...grpc.CallOption) (*DirListing, error) {
-	out := new(DirListing)
-	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetDirListing", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// for database, stores meta information about artefacts
+type ArtefactID struct {
+	ID             uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	Domain         string `protobuf:"bytes,2,opt,name=Domain" json:"Domain,omitempty"`
+	Name           string `protobuf:"bytes,3,opt,name=Name" json:"Name,omitempty"`
+	URL            string `protobuf:"bytes,4,opt,name=URL" json:"URL,omitempty"`
+	Created        uint32 `protobuf:"varint,5,opt,name=Created" json:"Created,omitempty"`
+	OrganisationID string `protobuf:"bytes,6,opt,name=OrganisationID" json:"OrganisationID,omitempty"`
 }
 
-func (c *artefactServiceClient) GetFileStream(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (ArtefactService_GetFileStreamClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_ArtefactService_serviceDesc.Streams[2], c.cc, "/artefact.ArtefactService/GetFileStream", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &artefactServiceGetFileStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+func (m *ArtefactID) Reset()                    { *m = ArtefactID{} }
+func (m *ArtefactID) String() string            { return proto.CompactTextString(m) }
+func (*ArtefactID) ProtoMessage()               {}
+func (*ArtefactID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *ArtefactID) GetID() uint64 {
+	if m != nil {
+		return m.ID
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *ArtefactID) GetDomain() string {
+	if m != nil {
+		return m.Domain
 	}
-	return x, nil
+	return ""
 }
 
-type ArtefactService_GetFileStreamClient interface {
-	Recv() (*FileStreamResponse, error)
-	grpc.ClientStream
+func (m *ArtefactID) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-type artefactServiceGetFileStreamClient struct {
-	grpc.ClientStream
+func (m *ArtefactID) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *ArtefactID) GetCreated() uint32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ArtefactID) GetOrganisationID() string {
+	if m != nil {
+		return m.OrganisationID
+	}
+	return ""
+}
+
+// for database, sha256 of a file in a build
+type FileChecksum struct {
+	ID         uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	ArtefactID uint64 `protobuf:"varint,2,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch     string `protobuf:"bytes,3,opt,name=Branch" json:"Branch,omitempty"`
+	BuildID    uint64 `protobuf:"varint,4,opt,name=BuildID" json:"BuildID,omitempty"`
+	Path       string `protobuf:"bytes,5,opt,name=Path" json:"Path,omitempty"`
+	SHA256     string `protobuf:"bytes,6,opt,name=SHA256" json:"SHA256,omitempty"`
+	Size       uint64 `protobuf:"varint,7,opt,name=Size" json:"Size,omitempty"`
+	Created    uint32 `protobuf:"varint,8,opt,name=Created" json:"Created,omitempty"`
+}
+
+func (m *FileChecksum) Reset()                    { *m = FileChecksum{} }
+func (m *FileChecksum) String() string            { return proto.CompactTextString(m) }
+func (*FileChecksum) ProtoMessage()               {}
+func (*FileChecksum) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *FileChecksum) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *FileChecksum) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *FileChecksum) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *FileChecksum) GetBuildID() uint64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *FileChecksum) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileChecksum) GetSHA256() string {
+	if m != nil {
+		return m.SHA256
+	}
+	return ""
+}
+
+func (m *FileChecksum) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *FileChecksum) GetCreated() uint32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+// for database, maps repositories to artefacts
+type RepoArtefact struct {
+	ID           uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	RepositoryID uint64 `protobuf:"varint,2,opt,name=RepositoryID" json:"RepositoryID,omitempty"`
+	ArtefactID   uint64 `protobuf:"varint,3,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Updated      uint32 `protobuf:"varint,4,opt,name=Updated" json:"Updated,omitempty"`
+}
+
+func (m *RepoArtefact) Reset()                    { *m = RepoArtefact{} }
+func (m *RepoArtefact) String() string            { return proto.CompactTextString(m) }
+func (*RepoArtefact) ProtoMessage()               {}
+func (*RepoArtefact) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *RepoArtefact) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *RepoArtefact) GetRepositoryID() uint64 {
+	if m != nil {
+		return m.RepositoryID
+	}
+	return 0
+}
+
+func (m *RepoArtefact) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *RepoArtefact) GetUpdated() uint32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+// for database, outbound http notifications about new builds
+type Webhook struct {
+	ID         uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	ArtefactID uint64 `protobuf:"varint,2,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Domain     string `protobuf:"bytes,3,opt,name=Domain" json:"Domain,omitempty"`
+	URL        string `protobuf:"bytes,4,opt,name=URL" json:"URL,omitempty"`
+	Secret     string `protobuf:"bytes,5,opt,name=Secret" json:"Secret,omitempty"`
+	CreatedBy  string `protobuf:"bytes,6,opt,name=CreatedBy" json:"CreatedBy,omitempty"`
+	Created    uint32 `protobuf:"varint,7,opt,name=Created" json:"Created,omitempty"`
+}
+
+func (m *Webhook) Reset()                    { *m = Webhook{} }
+func (m *Webhook) String() string            { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()               {}
+func (*Webhook) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Webhook) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *Webhook) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *Webhook) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *Webhook) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *Webhook) GetCreatedBy() string {
+	if m != nil {
+		return m.CreatedBy
+	}
+	return ""
+}
+
+func (m *Webhook) GetCreated() uint32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type WebhookList struct {
+	Webhooks []*Webhook `protobuf:"bytes,1,rep,name=Webhooks" json:"Webhooks,omitempty"`
+}
+
+func (m *WebhookList) Reset()                    { *m = WebhookList{} }
+func (m *WebhookList) String() string            { return proto.CompactTextString(m) }
+func (*WebhookList) ProtoMessage()               {}
+func (*WebhookList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *WebhookList) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+// metadata about an artefact
+type ArtefactMeta struct {
+	ID           uint64       `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	RepositoryID uint64       `protobuf:"varint,2,opt,name=RepositoryID" json:"RepositoryID,omitempty"`
+	LatestBuild  *LatestBuild `protobuf:"bytes,3,opt,name=LatestBuild" json:"LatestBuild,omitempty"`
+}
+
+func (m *ArtefactMeta) Reset()                    { *m = ArtefactMeta{} }
+func (m *ArtefactMeta) String() string            { return proto.CompactTextString(m) }
+func (*ArtefactMeta) ProtoMessage()               {}
+func (*ArtefactMeta) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *ArtefactMeta) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *ArtefactMeta) GetRepositoryID() uint64 {
+	if m != nil {
+		return m.RepositoryID
+	}
+	return 0
+}
+
+func (m *ArtefactMeta) GetLatestBuild() *LatestBuild {
+	if m != nil {
+		return m.LatestBuild
+	}
+	return nil
+}
+
+type CreateArtefactRequest struct {
+	OrganisationID  string `protobuf:"bytes,1,opt,name=OrganisationID" json:"OrganisationID,omitempty"`
+	ArtefactName    string `protobuf:"bytes,2,opt,name=ArtefactName" json:"ArtefactName,omitempty"`
+	BuildRepoDomain string `protobuf:"bytes,3,opt,name=BuildRepoDomain" json:"BuildRepoDomain,omitempty"`
+	GitURL          string `protobuf:"bytes,4,opt,name=GitURL" json:"GitURL,omitempty"`
+}
+
+func (m *CreateArtefactRequest) Reset()                    { *m = CreateArtefactRequest{} }
+func (m *CreateArtefactRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateArtefactRequest) ProtoMessage()               {}
+func (*CreateArtefactRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *CreateArtefactRequest) GetOrganisationID() string {
+	if m != nil {
+		return m.OrganisationID
+	}
+	return ""
+}
+
+func (m *CreateArtefactRequest) GetArtefactName() string {
+	if m != nil {
+		return m.ArtefactName
+	}
+	return ""
+}
+
+func (m *CreateArtefactRequest) GetBuildRepoDomain() string {
+	if m != nil {
+		return m.BuildRepoDomain
+	}
+	return ""
+}
+
+func (m *CreateArtefactRequest) GetGitURL() string {
+	if m != nil {
+		return m.GitURL
+	}
+	return ""
+}
+
+type CreateArtefactResponse struct {
+	Created bool          `protobuf:"varint,1,opt,name=Created" json:"Created,omitempty"`
+	Meta    *ArtefactMeta `protobuf:"bytes,2,opt,name=Meta" json:"Meta,omitempty"`
+}
+
+func (m *CreateArtefactResponse) Reset()                    { *m = CreateArtefactResponse{} }
+func (m *CreateArtefactResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateArtefactResponse) ProtoMessage()               {}
+func (*CreateArtefactResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *CreateArtefactResponse) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+func (m *CreateArtefactResponse) GetMeta() *ArtefactMeta {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+type BranchList struct {
+	Branches      []string `protobuf:"bytes,1,rep,name=Branches" json:"Branches,omitempty"`
+	DefaultBranch string   `protobuf:"bytes,2,opt,name=DefaultBranch" json:"DefaultBranch,omitempty"`
+}
+
+func (m *BranchList) Reset()                    { *m = BranchList{} }
+func (m *BranchList) String() string            { return proto.CompactTextString(m) }
+func (*BranchList) ProtoMessage()               {}
+func (*BranchList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *BranchList) GetBranches() []string {
+	if m != nil {
+		return m.Branches
+	}
+	return nil
+}
+
+func (m *BranchList) GetDefaultBranch() string {
+	if m != nil {
+		return m.DefaultBranch
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	ArtefactIDs []uint64 `protobuf:"varint,1,rep,packed,name=ArtefactIDs" json:"ArtefactIDs,omitempty"`
+	Domain      string   `protobuf:"bytes,2,opt,name=Domain" json:"Domain,omitempty"`
+}
+
+func (m *WatchRequest) Reset()                    { *m = WatchRequest{} }
+func (m *WatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()               {}
+func (*WatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *WatchRequest) GetArtefactIDs() []uint64 {
+	if m != nil {
+		return m.ArtefactIDs
+	}
+	return nil
+}
+
+func (m *WatchRequest) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+// sent when the latest build of an artefact changes
+type BuildEvent struct {
+	ArtefactID uint64 `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=Name" json:"Name,omitempty"`
+	Domain     string `protobuf:"bytes,3,opt,name=Domain" json:"Domain,omitempty"`
+	Branch     string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	OldBuildID uint64 `protobuf:"varint,5,opt,name=OldBuildID" json:"OldBuildID,omitempty"`
+	NewBuildID uint64 `protobuf:"varint,6,opt,name=NewBuildID" json:"NewBuildID,omitempty"`
+	Timestamp  uint32 `protobuf:"varint,7,opt,name=Timestamp" json:"Timestamp,omitempty"`
+}
+
+func (m *BuildEvent) Reset()                    { *m = BuildEvent{} }
+func (m *BuildEvent) String() string            { return proto.CompactTextString(m) }
+func (*BuildEvent) ProtoMessage()               {}
+func (*BuildEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *BuildEvent) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *BuildEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *BuildEvent) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *BuildEvent) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *BuildEvent) GetOldBuildID() uint64 {
+	if m != nil {
+		return m.OldBuildID
+	}
+	return 0
+}
+
+func (m *BuildEvent) GetNewBuildID() uint64 {
+	if m != nil {
+		return m.NewBuildID
+	}
+	return 0
+}
+
+func (m *BuildEvent) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type LatestBuild struct {
+	BuildID       uint64 `protobuf:"varint,1,opt,name=BuildID" json:"BuildID,omitempty"`
+	UnixTimestamp uint32 `protobuf:"varint,2,opt,name=UnixTimestamp" json:"UnixTimestamp,omitempty"`
+	CommitID      string `protobuf:"bytes,3,opt,name=CommitID" json:"CommitID,omitempty"`
+	Branch        string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	UserID        string `protobuf:"bytes,5,opt,name=UserID" json:"UserID,omitempty"`
+}
+
+func (m *LatestBuild) Reset()                    { *m = LatestBuild{} }
+func (m *LatestBuild) String() string            { return proto.CompactTextString(m) }
+func (*LatestBuild) ProtoMessage()               {}
+func (*LatestBuild) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *LatestBuild) GetBuildID() uint64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *LatestBuild) GetUnixTimestamp() uint32 {
+	if m != nil {
+		return m.UnixTimestamp
+	}
+	return 0
+}
+
+func (m *LatestBuild) GetCommitID() string {
+	if m != nil {
+		return m.CommitID
+	}
+	return ""
+}
+
+func (m *LatestBuild) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *LatestBuild) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+// a buildrepo the artefact server uses
+type BuildRepoServer struct {
+	Address   string `protobuf:"bytes,1,opt,name=Address" json:"Address,omitempty"`
+	Domain    string `protobuf:"bytes,2,opt,name=Domain" json:"Domain,omitempty"`
+	Connected bool   `protobuf:"varint,3,opt,name=Connected" json:"Connected,omitempty"`
+	LastError string `protobuf:"bytes,4,opt,name=LastError" json:"LastError,omitempty"`
+	Healthy   bool   `protobuf:"varint,5,opt,name=Healthy" json:"Healthy,omitempty"`
+	Failures  uint32 `protobuf:"varint,6,opt,name=Failures" json:"Failures,omitempty"`
+}
+
+func (m *BuildRepoServer) Reset()                    { *m = BuildRepoServer{} }
+func (m *BuildRepoServer) String() string            { return proto.CompactTextString(m) }
+func (*BuildRepoServer) ProtoMessage()               {}
+func (*BuildRepoServer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *BuildRepoServer) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *BuildRepoServer) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *BuildRepoServer) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *BuildRepoServer) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *BuildRepoServer) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *BuildRepoServer) GetFailures() uint32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+type BuildRepoServerList struct {
+	Servers []*BuildRepoServer `protobuf:"bytes,1,rep,name=Servers" json:"Servers,omitempty"`
+}
+
+func (m *BuildRepoServerList) Reset()                    { *m = BuildRepoServerList{} }
+func (m *BuildRepoServerList) String() string            { return proto.CompactTextString(m) }
+func (*BuildRepoServerList) ProtoMessage()               {}
+func (*BuildRepoServerList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *BuildRepoServerList) GetServers() []*BuildRepoServer {
+	if m != nil {
+		return m.Servers
+	}
+	return nil
+}
+
+type BuildRepoAddress struct {
+	Address string `protobuf:"bytes,1,opt,name=Address" json:"Address,omitempty"`
+}
+
+func (m *BuildRepoAddress) Reset()                    { *m = BuildRepoAddress{} }
+func (m *BuildRepoAddress) String() string            { return proto.CompactTextString(m) }
+func (*BuildRepoAddress) ProtoMessage()               {}
+func (*BuildRepoAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *BuildRepoAddress) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type FindFilesRequest struct {
+	Pattern    string `protobuf:"bytes,1,opt,name=Pattern" json:"Pattern,omitempty"`
+	Regex      bool   `protobuf:"varint,2,opt,name=Regex" json:"Regex,omitempty"`
+	ArtefactID uint64 `protobuf:"varint,3,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch     string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	MaxResults uint32 `protobuf:"varint,5,opt,name=MaxResults" json:"MaxResults,omitempty"`
+}
+
+func (m *FindFilesRequest) Reset()                    { *m = FindFilesRequest{} }
+func (m *FindFilesRequest) String() string            { return proto.CompactTextString(m) }
+func (*FindFilesRequest) ProtoMessage()               {}
+func (*FindFilesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *FindFilesRequest) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *FindFilesRequest) GetRegex() bool {
+	if m != nil {
+		return m.Regex
+	}
+	return false
+}
+
+func (m *FindFilesRequest) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *FindFilesRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *FindFilesRequest) GetMaxResults() uint32 {
+	if m != nil {
+		return m.MaxResults
+	}
+	return 0
+}
+
+// a file in a build of an artefact
+type FileMatch struct {
+	ArtefactID    uint64 `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=Name" json:"Name,omitempty"`
+	Domain        string `protobuf:"bytes,3,opt,name=Domain" json:"Domain,omitempty"`
+	Branch        string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	Build         uint64 `protobuf:"varint,5,opt,name=Build" json:"Build,omitempty"`
+	Path          string `protobuf:"bytes,6,opt,name=Path" json:"Path,omitempty"`
+	LinkToVersion string `protobuf:"bytes,7,opt,name=LinkToVersion" json:"LinkToVersion,omitempty"`
+}
+
+func (m *FileMatch) Reset()                    { *m = FileMatch{} }
+func (m *FileMatch) String() string            { return proto.CompactTextString(m) }
+func (*FileMatch) ProtoMessage()               {}
+func (*FileMatch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *FileMatch) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *FileMatch) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FileMatch) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *FileMatch) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *FileMatch) GetBuild() uint64 {
+	if m != nil {
+		return m.Build
+	}
+	return 0
+}
+
+func (m *FileMatch) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileMatch) GetLinkToVersion() string {
+	if m != nil {
+		return m.LinkToVersion
+	}
+	return ""
+}
+
+type FileMatchList struct {
+	Matches      []*FileMatch `protobuf:"bytes,1,rep,name=Matches" json:"Matches,omitempty"`
+	Truncated    bool         `protobuf:"varint,2,opt,name=Truncated" json:"Truncated,omitempty"`
+	Pending      uint32       `protobuf:"varint,3,opt,name=Pending" json:"Pending,omitempty"`
+	IndexUpdated uint32       `protobuf:"varint,4,opt,name=IndexUpdated" json:"IndexUpdated,omitempty"`
+}
+
+func (m *FileMatchList) Reset()                    { *m = FileMatchList{} }
+func (m *FileMatchList) String() string            { return proto.CompactTextString(m) }
+func (*FileMatchList) ProtoMessage()               {}
+func (*FileMatchList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *FileMatchList) GetMatches() []*FileMatch {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+func (m *FileMatchList) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *FileMatchList) GetPending() uint32 {
+	if m != nil {
+		return m.Pending
+	}
+	return 0
+}
+
+func (m *FileMatchList) GetIndexUpdated() uint32 {
+	if m != nil {
+		return m.IndexUpdated
+	}
+	return 0
+}
+
+// for database, a named release channel (e.g. "stable" or "beta") of an artefact, pinned to a build
+type Channel struct {
+	ID         uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	ArtefactID uint64 `protobuf:"varint,2,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Name       string `protobuf:"bytes,3,opt,name=Name" json:"Name,omitempty"`
+	Branch     string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	BuildID    uint64 `protobuf:"varint,5,opt,name=BuildID" json:"BuildID,omitempty"`
+	UpdatedBy  string `protobuf:"bytes,6,opt,name=UpdatedBy" json:"UpdatedBy,omitempty"`
+	Updated    uint32 `protobuf:"varint,7,opt,name=Updated" json:"Updated,omitempty"`
+}
+
+func (m *Channel) Reset()                    { *m = Channel{} }
+func (m *Channel) String() string            { return proto.CompactTextString(m) }
+func (*Channel) ProtoMessage()               {}
+func (*Channel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *Channel) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *Channel) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *Channel) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Channel) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *Channel) GetBuildID() uint64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *Channel) GetUpdatedBy() string {
+	if m != nil {
+		return m.UpdatedBy
+	}
+	return ""
+}
+
+func (m *Channel) GetUpdated() uint32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+// for database, audit trail of channel changes
+type ChannelChange struct {
+	ID         uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	ArtefactID uint64 `protobuf:"varint,2,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Channel    string `protobuf:"bytes,3,opt,name=Channel" json:"Channel,omitempty"`
+	Branch     string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+	OldBuildID uint64 `protobuf:"varint,5,opt,name=OldBuildID" json:"OldBuildID,omitempty"`
+	NewBuildID uint64 `protobuf:"varint,6,opt,name=NewBuildID" json:"NewBuildID,omitempty"`
+	UserID     string `protobuf:"bytes,7,opt,name=UserID" json:"UserID,omitempty"`
+	Timestamp  uint32 `protobuf:"varint,8,opt,name=Timestamp" json:"Timestamp,omitempty"`
+}
+
+func (m *ChannelChange) Reset()                    { *m = ChannelChange{} }
+func (m *ChannelChange) String() string            { return proto.CompactTextString(m) }
+func (*ChannelChange) ProtoMessage()               {}
+func (*ChannelChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *ChannelChange) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *ChannelChange) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *ChannelChange) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *ChannelChange) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *ChannelChange) GetOldBuildID() uint64 {
+	if m != nil {
+		return m.OldBuildID
+	}
+	return 0
+}
+
+func (m *ChannelChange) GetNewBuildID() uint64 {
+	if m != nil {
+		return m.NewBuildID
+	}
+	return 0
+}
+
+func (m *ChannelChange) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *ChannelChange) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type PromoteRequest struct {
+	ArtefactID uint64 `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	BuildID    uint64 `protobuf:"varint,2,opt,name=BuildID" json:"BuildID,omitempty"`
+	Channel    string `protobuf:"bytes,3,opt,name=Channel" json:"Channel,omitempty"`
+	Branch     string `protobuf:"bytes,4,opt,name=Branch" json:"Branch,omitempty"`
+}
+
+func (m *PromoteRequest) Reset()                    { *m = PromoteRequest{} }
+func (m *PromoteRequest) String() string            { return proto.CompactTextString(m) }
+func (*PromoteRequest) ProtoMessage()               {}
+func (*PromoteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *PromoteRequest) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *PromoteRequest) GetBuildID() uint64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *PromoteRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *PromoteRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+type ChannelList struct {
+	Channels []*Channel `protobuf:"bytes,1,rep,name=Channels" json:"Channels,omitempty"`
+}
+
+func (m *ChannelList) Reset()                    { *m = ChannelList{} }
+func (m *ChannelList) String() string            { return proto.CompactTextString(m) }
+func (*ChannelList) ProtoMessage()               {}
+func (*ChannelList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *ChannelList) GetChannels() []*Channel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type ChannelChangeList struct {
+	Changes []*ChannelChange `protobuf:"bytes,1,rep,name=Changes" json:"Changes,omitempty"`
+}
+
+func (m *ChannelChangeList) Reset()                    { *m = ChannelChangeList{} }
+func (m *ChannelChangeList) String() string            { return proto.CompactTextString(m) }
+func (*ChannelChangeList) ProtoMessage()               {}
+func (*ChannelChangeList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *ChannelChangeList) GetChanges() []*ChannelChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+// for database, a deprecated or yanked build. builds without a BuildMark are available
+type BuildMark struct {
+	ID         uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	ArtefactID uint64 `protobuf:"varint,2,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch     string `protobuf:"bytes,3,opt,name=Branch" json:"Branch,omitempty"`
+	BuildID    uint64 `protobuf:"varint,4,opt,name=BuildID" json:"BuildID,omitempty"`
+	Yanked     bool   `protobuf:"varint,5,opt,name=Yanked" json:"Yanked,omitempty"`
+	Reason     string `protobuf:"bytes,6,opt,name=Reason" json:"Reason,omitempty"`
+	UserID     string `protobuf:"bytes,7,opt,name=UserID" json:"UserID,omitempty"`
+	Timestamp  uint32 `protobuf:"varint,8,opt,name=Timestamp" json:"Timestamp,omitempty"`
+}
+
+func (m *BuildMark) Reset()                    { *m = BuildMark{} }
+func (m *BuildMark) String() string            { return proto.CompactTextString(m) }
+func (*BuildMark) ProtoMessage()               {}
+func (*BuildMark) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *BuildMark) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *BuildMark) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *BuildMark) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *BuildMark) GetBuildID() uint64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *BuildMark) GetYanked() bool {
+	if m != nil {
+		return m.Yanked
+	}
+	return false
+}
+
+func (m *BuildMark) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *BuildMark) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *BuildMark) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// for database, what we know about a build beyond what the buildrepo tells us
+type BuildRecord struct {
+	ID            uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	ArtefactID    uint64 `protobuf:"varint,2,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch        string `protobuf:"bytes,3,opt,name=Branch" json:"Branch,omitempty"`
+	BuildID       uint64 `protobuf:"varint,4,opt,name=BuildID" json:"BuildID,omitempty"`
+	Created       uint32 `protobuf:"varint,5,opt,name=Created" json:"Created,omitempty"`
+	LastDownload  uint32 `protobuf:"varint,6,opt,name=LastDownload" json:"LastDownload,omitempty"`
+	CommitID      string `protobuf:"bytes,7,opt,name=CommitID" json:"CommitID,omitempty"`
+	CommitMessage string `protobuf:"bytes,8,opt,name=CommitMessage" json:"CommitMessage,omitempty"`
+	BuildUserID   string `protobuf:"bytes,9,opt,name=BuildUserID" json:"BuildUserID,omitempty"`
+	BuildHost     string `protobuf:"bytes,10,opt,name=BuildHost" json:"BuildHost,omitempty"`
+}
+
+func (m *BuildRecord) Reset()                    { *m = BuildRecord{} }
+func (m *BuildRecord) String() string            { return proto.CompactTextString(m) }
+func (*BuildRecord) ProtoMessage()               {}
+func (*BuildRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *BuildRecord) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *BuildRecord) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *BuildRecord) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *BuildRecord) GetBuildID() uint64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *BuildRecord) GetCreated() uint32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *BuildRecord) GetLastDownload() uint32 {
+	if m != nil {
+		return m.LastDownload
+	}
+	return 0
+}
+
+func (m *BuildRecord) GetCommitID() string {
+	if m != nil {
+		return m.CommitID
+	}
+	return ""
+}
+
+func (m *BuildRecord) GetCommitMessage() string {
+	if m != nil {
+		return m.CommitMessage
+	}
+	return ""
+}
+
+func (m *BuildRecord) GetBuildUserID() string {
+	if m != nil {
+		return m.BuildUserID
+	}
+	return ""
+}
+
+func (m *BuildRecord) GetBuildHost() string {
+	if m != nil {
+		return m.BuildHost
+	}
+	return ""
+}
+
+// for database, which builds of an artefact to keep. a build is kept if any of the rules applies to it.
+// the latest build of each branch and builds pinned to a channel are always kept
+type RetentionRule struct {
+	ID                 uint64 `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	ArtefactID         uint64 `protobuf:"varint,2,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	KeepLast           uint32 `protobuf:"varint,3,opt,name=KeepLast" json:"KeepLast,omitempty"`
+	KeepDays           uint32 `protobuf:"varint,4,opt,name=KeepDays" json:"KeepDays,omitempty"`
+	KeepDownloadedDays uint32 `protobuf:"varint,5,opt,name=KeepDownloadedDays" json:"KeepDownloadedDays,omitempty"`
+	UpdatedBy          string `protobuf:"bytes,6,opt,name=UpdatedBy" json:"UpdatedBy,omitempty"`
+	Updated            uint32 `protobuf:"varint,7,opt,name=Updated" json:"Updated,omitempty"`
+}
+
+func (m *RetentionRule) Reset()                    { *m = RetentionRule{} }
+func (m *RetentionRule) String() string            { return proto.CompactTextString(m) }
+func (*RetentionRule) ProtoMessage()               {}
+func (*RetentionRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *RetentionRule) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *RetentionRule) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *RetentionRule) GetKeepLast() uint32 {
+	if m != nil {
+		return m.KeepLast
+	}
+	return 0
+}
+
+func (m *RetentionRule) GetKeepDays() uint32 {
+	if m != nil {
+		return m.KeepDays
+	}
+	return 0
+}
+
+func (m *RetentionRule) GetKeepDownloadedDays() uint32 {
+	if m != nil {
+		return m.KeepDownloadedDays
+	}
+	return 0
+}
+
+func (m *RetentionRule) GetUpdatedBy() string {
+	if m != nil {
+		return m.UpdatedBy
+	}
+	return ""
+}
+
+func (m *RetentionRule) GetUpdated() uint32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+// a build which the retention rule of an artefact would delete
+type PlannedDeletion struct {
+	Branch       string `protobuf:"bytes,1,opt,name=Branch" json:"Branch,omitempty"`
+	BuildID      uint64 `protobuf:"varint,2,opt,name=BuildID" json:"BuildID,omitempty"`
+	Bytes        uint64 `protobuf:"varint,3,opt,name=Bytes" json:"Bytes,omitempty"`
+	Created      uint32 `protobuf:"varint,4,opt,name=Created" json:"Created,omitempty"`
+	LastDownload uint32 `protobuf:"varint,5,opt,name=LastDownload" json:"LastDownload,omitempty"`
+}
+
+func (m *PlannedDeletion) Reset()                    { *m = PlannedDeletion{} }
+func (m *PlannedDeletion) String() string            { return proto.CompactTextString(m) }
+func (*PlannedDeletion) ProtoMessage()               {}
+func (*PlannedDeletion) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *PlannedDeletion) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *PlannedDeletion) GetBuildID() uint64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *PlannedDeletion) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *PlannedDeletion) GetCreated() uint32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *PlannedDeletion) GetLastDownload() uint32 {
+	if m != nil {
+		return m.LastDownload
+	}
+	return 0
+}
+
+type RetentionPlan struct {
+	ArtefactID   uint64             `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Rule         *RetentionRule     `protobuf:"bytes,2,opt,name=Rule" json:"Rule,omitempty"`
+	Deletions    []*PlannedDeletion `protobuf:"bytes,3,rep,name=Deletions" json:"Deletions,omitempty"`
+	KeptBuilds   uint32             `protobuf:"varint,4,opt,name=KeptBuilds" json:"KeptBuilds,omitempty"`
+	BytesSaved   uint64             `protobuf:"varint,5,opt,name=BytesSaved" json:"BytesSaved,omitempty"`
+	CannotDelete bool               `protobuf:"varint,6,opt,name=CannotDelete" json:"CannotDelete,omitempty"`
+}
+
+func (m *RetentionPlan) Reset()                    { *m = RetentionPlan{} }
+func (m *RetentionPlan) String() string            { return proto.CompactTextString(m) }
+func (*RetentionPlan) ProtoMessage()               {}
+func (*RetentionPlan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *RetentionPlan) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *RetentionPlan) GetRule() *RetentionRule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+func (m *RetentionPlan) GetDeletions() []*PlannedDeletion {
+	if m != nil {
+		return m.Deletions
+	}
+	return nil
+}
+
+func (m *RetentionPlan) GetKeptBuilds() uint32 {
+	if m != nil {
+		return m.KeptBuilds
+	}
+	return 0
+}
+
+func (m *RetentionPlan) GetBytesSaved() uint64 {
+	if m != nil {
+		return m.BytesSaved
+	}
+	return 0
+}
+
+func (m *RetentionPlan) GetCannotDelete() bool {
+	if m != nil {
+		return m.CannotDelete
+	}
+	return false
+}
+
+type DiffRequest struct {
+	ArtefactID uint64 `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch     string `protobuf:"bytes,2,opt,name=Branch" json:"Branch,omitempty"`
+	BuildA     uint64 `protobuf:"varint,3,opt,name=BuildA" json:"BuildA,omitempty"`
+	BuildB     uint64 `protobuf:"varint,4,opt,name=BuildB" json:"BuildB,omitempty"`
+	Dir        string `protobuf:"bytes,5,opt,name=Dir" json:"Dir,omitempty"`
+}
+
+func (m *DiffRequest) Reset()                    { *m = DiffRequest{} }
+func (m *DiffRequest) String() string            { return proto.CompactTextString(m) }
+func (*DiffRequest) ProtoMessage()               {}
+func (*DiffRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *DiffRequest) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *DiffRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *DiffRequest) GetBuildA() uint64 {
+	if m != nil {
+		return m.BuildA
+	}
+	return 0
+}
+
+func (m *DiffRequest) GetBuildB() uint64 {
+	if m != nil {
+		return m.BuildB
+	}
+	return 0
+}
+
+func (m *DiffRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type FileDiff struct {
+	Path        string     `protobuf:"bytes,1,opt,name=Path" json:"Path,omitempty"`
+	Change      FileChange `protobuf:"varint,2,opt,name=Change,enum=artefact.FileChange" json:"Change,omitempty"`
+	SizeA       uint64     `protobuf:"varint,3,opt,name=SizeA" json:"SizeA,omitempty"`
+	SizeB       uint64     `protobuf:"varint,4,opt,name=SizeB" json:"SizeB,omitempty"`
+	SizeDelta   int64      `protobuf:"varint,5,opt,name=SizeDelta" json:"SizeDelta,omitempty"`
+	SHA256A     string     `protobuf:"bytes,6,opt,name=SHA256A" json:"SHA256A,omitempty"`
+	SHA256B     string     `protobuf:"bytes,7,opt,name=SHA256B" json:"SHA256B,omitempty"`
+	UnifiedDiff string     `protobuf:"bytes,8,opt,name=UnifiedDiff" json:"UnifiedDiff,omitempty"`
+}
+
+func (m *FileDiff) Reset()                    { *m = FileDiff{} }
+func (m *FileDiff) String() string            { return proto.CompactTextString(m) }
+func (*FileDiff) ProtoMessage()               {}
+func (*FileDiff) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *FileDiff) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileDiff) GetChange() FileChange {
+	if m != nil {
+		return m.Change
+	}
+	return FileChange_FileAdded
+}
+
+func (m *FileDiff) GetSizeA() uint64 {
+	if m != nil {
+		return m.SizeA
+	}
+	return 0
+}
+
+func (m *FileDiff) GetSizeB() uint64 {
+	if m != nil {
+		return m.SizeB
+	}
+	return 0
+}
+
+func (m *FileDiff) GetSizeDelta() int64 {
+	if m != nil {
+		return m.SizeDelta
+	}
+	return 0
+}
+
+func (m *FileDiff) GetSHA256A() string {
+	if m != nil {
+		return m.SHA256A
+	}
+	return ""
+}
+
+func (m *FileDiff) GetSHA256B() string {
+	if m != nil {
+		return m.SHA256B
+	}
+	return ""
+}
+
+func (m *FileDiff) GetUnifiedDiff() string {
+	if m != nil {
+		return m.UnifiedDiff
+	}
+	return ""
+}
+
+// the files which differ between two builds, sorted by path
+type BuildDiff struct {
+	ArtefactID uint64      `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch     string      `protobuf:"bytes,2,opt,name=Branch" json:"Branch,omitempty"`
+	BuildA     uint64      `protobuf:"varint,3,opt,name=BuildA" json:"BuildA,omitempty"`
+	BuildB     uint64      `protobuf:"varint,4,opt,name=BuildB" json:"BuildB,omitempty"`
+	Files      []*FileDiff `protobuf:"bytes,5,rep,name=Files" json:"Files,omitempty"`
+	SizeDelta  int64       `protobuf:"varint,6,opt,name=SizeDelta" json:"SizeDelta,omitempty"`
+	Unchanged  uint32      `protobuf:"varint,7,opt,name=Unchanged" json:"Unchanged,omitempty"`
+}
+
+func (m *BuildDiff) Reset()                    { *m = BuildDiff{} }
+func (m *BuildDiff) String() string            { return proto.CompactTextString(m) }
+func (*BuildDiff) ProtoMessage()               {}
+func (*BuildDiff) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *BuildDiff) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *BuildDiff) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *BuildDiff) GetBuildA() uint64 {
+	if m != nil {
+		return m.BuildA
+	}
+	return 0
+}
+
+func (m *BuildDiff) GetBuildB() uint64 {
+	if m != nil {
+		return m.BuildB
+	}
+	return 0
+}
+
+func (m *BuildDiff) GetFiles() []*FileDiff {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *BuildDiff) GetSizeDelta() int64 {
+	if m != nil {
+		return m.SizeDelta
+	}
+	return 0
+}
+
+func (m *BuildDiff) GetUnchanged() uint32 {
+	if m != nil {
+		return m.Unchanged
+	}
+	return 0
+}
+
+type MarkBuildRequest struct {
+	ArtefactID uint64     `protobuf:"varint,1,opt,name=ArtefactID" json:"ArtefactID,omitempty"`
+	Branch     string     `protobuf:"bytes,2,opt,name=Branch" json:"Branch,omitempty"`
+	BuildID    uint64     `protobuf:"varint,3,opt,name=BuildID" json:"BuildID,omitempty"`
+	State      BuildState `protobuf:"varint,4,opt,name=State,enum=artefact.BuildState" json:"State,omitempty"`
+	Reason     string     `protobuf:"bytes,5,opt,name=Reason" json:"Reason,omitempty"`
+}
+
+func (m *MarkBuildRequest) Reset()                    { *m = MarkBuildRequest{} }
+func (m *MarkBuildRequest) String() string            { return proto.CompactTextString(m) }
+func (*MarkBuildRequest) ProtoMessage()               {}
+func (*MarkBuildRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *MarkBuildRequest) GetArtefactID() uint64 {
+	if m != nil {
+		return m.ArtefactID
+	}
+	return 0
+}
+
+func (m *MarkBuildRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *MarkBuildRequest) GetBuildID() uint64 {
+	if m != nil {
+		return m.BuildID
+	}
+	return 0
+}
+
+func (m *MarkBuildRequest) GetState() BuildState {
+	if m != nil {
+		return m.State
+	}
+	return BuildState_Available
+}
+
+func (m *MarkBuildRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ArtefactList)(nil), "artefact.ArtefactList")
+	proto.RegisterType((*DegradedSource)(nil), "artefact.DegradedSource")
+	proto.RegisterType((*DownloadRequest)(nil), "artefact.DownloadRequest")
+	proto.RegisterType((*FileStream)(nil), "artefact.FileStream")
+	proto.RegisterType((*Reference)(nil), "artefact.Reference")
+	proto.RegisterType((*ArtefactRef)(nil), "artefact.ArtefactRef")
+	proto.RegisterType((*SerialReference)(nil), "artefact.SerialReference")
+	proto.RegisterType((*Contents)(nil), "artefact.Contents")
+	proto.RegisterType((*SetAccessRequest)(nil), "artefact.SetAccessRequest")
+	proto.RegisterType((*FindRequest)(nil), "artefact.FindRequest")
+	proto.RegisterType((*ArtefactFilter)(nil), "artefact.ArtefactFilter")
+	proto.RegisterType((*ListRequest)(nil), "artefact.ListRequest")
+	proto.RegisterType((*GetVersionRequest)(nil), "artefact.GetVersionRequest")
+	proto.RegisterType((*BuildList)(nil), "artefact.BuildList")
+	proto.RegisterType((*BuildInfo)(nil), "artefact.BuildInfo")
+	proto.RegisterType((*BuildForCommitRequest)(nil), "artefact.BuildForCommitRequest")
+	proto.RegisterType((*BuildListRequest)(nil), "artefact.BuildListRequest")
+	proto.RegisterType((*DirListRequest)(nil), "artefact.DirListRequest")
+	proto.RegisterType((*FileRequest)(nil), "artefact.FileRequest")
+	proto.RegisterType((*ArchiveRequest)(nil), "artefact.ArchiveRequest")
+	proto.RegisterType((*FileInfo)(nil), "artefact.FileInfo")
+	proto.RegisterType((*DirInfo)(nil), "artefact.DirInfo")
+	proto.RegisterType((*ArtefactInfo)(nil), "artefact.ArtefactInfo")
+	proto.RegisterType((*DirListing)(nil), "artefact.DirListing")
+	proto.RegisterType((*FileStreamResponse)(nil), "artefact.FileStreamResponse")
+	proto.RegisterType((*FileExistsInfo)(nil), "artefact.FileExistsInfo")
+	proto.RegisterType((*ID)(nil), "artefact.ID")
+	proto.RegisterType((*ArtefactID)(nil), "artefact.ArtefactID")
+	proto.RegisterType((*FileChecksum)(nil), "artefact.FileChecksum")
+	proto.RegisterType((*RepoArtefact)(nil), "artefact.RepoArtefact")
+	proto.RegisterType((*Webhook)(nil), "artefact.Webhook")
+	proto.RegisterType((*WebhookList)(nil), "artefact.WebhookList")
+	proto.RegisterType((*ArtefactMeta)(nil), "artefact.ArtefactMeta")
+	proto.RegisterType((*CreateArtefactRequest)(nil), "artefact.CreateArtefactRequest")
+	proto.RegisterType((*CreateArtefactResponse)(nil), "artefact.CreateArtefactResponse")
+	proto.RegisterType((*BranchList)(nil), "artefact.BranchList")
+	proto.RegisterType((*WatchRequest)(nil), "artefact.WatchRequest")
+	proto.RegisterType((*BuildEvent)(nil), "artefact.BuildEvent")
+	proto.RegisterType((*LatestBuild)(nil), "artefact.LatestBuild")
+	proto.RegisterType((*BuildRepoServer)(nil), "artefact.BuildRepoServer")
+	proto.RegisterType((*BuildRepoServerList)(nil), "artefact.BuildRepoServerList")
+	proto.RegisterType((*BuildRepoAddress)(nil), "artefact.BuildRepoAddress")
+	proto.RegisterType((*FindFilesRequest)(nil), "artefact.FindFilesRequest")
+	proto.RegisterType((*FileMatch)(nil), "artefact.FileMatch")
+	proto.RegisterType((*FileMatchList)(nil), "artefact.FileMatchList")
+	proto.RegisterType((*Channel)(nil), "artefact.Channel")
+	proto.RegisterType((*ChannelChange)(nil), "artefact.ChannelChange")
+	proto.RegisterType((*PromoteRequest)(nil), "artefact.PromoteRequest")
+	proto.RegisterType((*ChannelList)(nil), "artefact.ChannelList")
+	proto.RegisterType((*ChannelChangeList)(nil), "artefact.ChannelChangeList")
+	proto.RegisterType((*BuildMark)(nil), "artefact.BuildMark")
+	proto.RegisterType((*BuildRecord)(nil), "artefact.BuildRecord")
+	proto.RegisterType((*RetentionRule)(nil), "artefact.RetentionRule")
+	proto.RegisterType((*PlannedDeletion)(nil), "artefact.PlannedDeletion")
+	proto.RegisterType((*RetentionPlan)(nil), "artefact.RetentionPlan")
+	proto.RegisterType((*DiffRequest)(nil), "artefact.DiffRequest")
+	proto.RegisterType((*FileDiff)(nil), "artefact.FileDiff")
+	proto.RegisterType((*BuildDiff)(nil), "artefact.BuildDiff")
+	proto.RegisterType((*MarkBuildRequest)(nil), "artefact.MarkBuildRequest")
+	proto.RegisterEnum("artefact.ContentType", ContentType_name, ContentType_value)
+	proto.RegisterEnum("artefact.ArtefactOrder", ArtefactOrder_name, ArtefactOrder_value)
+	proto.RegisterEnum("artefact.BuildState", BuildState_name, BuildState_value)
+	proto.RegisterEnum("artefact.ArchiveFormat", ArchiveFormat_name, ArchiveFormat_value)
+	proto.RegisterEnum("artefact.FileChange", FileChange_name, FileChange_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for ArtefactService service
+
+type ArtefactServiceClient interface {
+	// list *latest* version of all artefacts (for this user)
+	List(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*ArtefactList, error)
+	// get contents of a directory or artefact.
+	GetContents(ctx context.Context, in *Reference, opts ...grpc.CallOption) (*Contents, error)
+	// download a file via http
+	StreamHTTP(ctx context.Context, in *h2gproxy.StreamRequest, opts ...grpc.CallOption) (ArtefactService_StreamHTTPClient, error)
+	// download a file via gRPC stream
+	GetFile(ctx context.Context, in *Reference, opts ...grpc.CallOption) (ArtefactService_GetFileClient, error)
+	// set access to a repo/artefact
+	SetAccess(ctx context.Context, in *SetAccessRequest, opts ...grpc.CallOption) (*common.Void, error)
+	// finds artefacts based on fuzzy string matches
+	Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*ArtefactList, error)
+	// get a specific version of a repository
+	GetRepoVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*Contents, error)
+	// get builds for a given artefact
+	GetArtefactBuilds(ctx context.Context, in *ArtefactID, opts ...grpc.CallOption) (*BuildList, error)
+	// get list of files for a given artefact, build and directory
+	GetDirListing(ctx context.Context, in *DirListRequest, opts ...grpc.CallOption) (*DirListing, error)
+	// get a file as stream
+	GetFileStream(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (ArtefactService_GetFileStreamClient, error)
+	// does file exist
+	DoesFileExist(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (*FileExistsInfo, error)
+	// get which repo fed this artefact
+	GetRepoForArtefact(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ID, error)
+	// get an artefact for a given repo
+	GetArtefactForRepo(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ID, error)
+	// get an artefact for a given repo
+	GetArtefactByID(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ArtefactID, error)
+	// get an artefactid for a given repo
+	GetArtefactIDForRepo(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ArtefactID, error)
+	// get artefact metadata by artefactid
+	MetaByID(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ArtefactMeta, error)
+	// create artefact if required. if it exists already it will not be recreated. URL may be added or updated
+	CreateArtefactIfRequired(ctx context.Context, in *CreateArtefactRequest, opts ...grpc.CallOption) (*CreateArtefactResponse, error)
+	LatestBuildForGoEasyops(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*LatestBuild, error)
+	// get a directory (recursively) as zip or tar.gz archive
+	GetDirArchive(ctx context.Context, in *ArchiveRequest, opts ...grpc.CallOption) (ArtefactService_GetDirArchiveClient, error)
+	// stream an event each time the latest build of a watched artefact changes
+	WatchArtefacts(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ArtefactService_WatchArtefactsClient, error)
+	// register a webhook. it is called for each new build of an artefact (or all artefacts in a domain). Secret is generated if empty
+	AddWebhook(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error)
+	// list the webhooks this user registered (all webhooks for root)
+	ListWebhooks(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*WebhookList, error)
+	// remove a webhook
+	DeleteWebhook(ctx context.Context, in *ID, opts ...grpc.CallOption) (*common.Void, error)
+	// list the branches of an artefact (by artefactid)
+	ListBranches(ctx context.Context, in *ID, opts ...grpc.CallOption) (*BranchList, error)
+	// list artefacts, with filters and in pages
+	ListArtefacts(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ArtefactList, error)
+	// list builds of an artefact, newest first and in pages
+	ListBuilds(ctx context.Context, in *BuildListRequest, opts ...grpc.CallOption) (*BuildList, error)
+	// (root only) add a buildrepo at runtime. it is connected in the background if it is not reachable
+	AddBuildRepo(ctx context.Context, in *BuildRepoAddress, opts ...grpc.CallOption) (*BuildRepoServer, error)
+	// (root only) stop using a buildrepo
+	RemoveBuildRepo(ctx context.Context, in *BuildRepoAddress, opts ...grpc.CallOption) (*common.Void, error)
+	// (root only) list buildrepos and their state
+	ListBuildRepos(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*BuildRepoServerList, error)
+	// find files by name (glob or regex) in the latest build of all artefacts or in all builds of one artefact
+	FindFiles(ctx context.Context, in *FindFilesRequest, opts ...grpc.CallOption) (*FileMatchList, error)
+	// (artefact admins) pin a build to a release channel. links may use the channel instead of a version, e.g. version/stable/...
+	PromoteBuild(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*Channel, error)
+	// the release channels of an artefact (by artefactid)
+	ListChannels(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ChannelList, error)
+	// all changes to the release channels of an artefact (by artefactid)
+	ChannelHistory(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ChannelChangeList, error)
+	// (artefact admins) deprecate or yank a build, or make it available again
+	MarkBuild(ctx context.Context, in *MarkBuildRequest, opts ...grpc.CallOption) (*common.Void, error)
+	// (artefact admins) set the retention rule of an artefact. a rule which keeps nothing (all zero) removes it
+	SetRetentionRule(ctx context.Context, in *RetentionRule, opts ...grpc.CallOption) (*RetentionRule, error)
+	// which builds of an artefact (by artefactid) its retention rule would delete
+	PlanRetention(ctx context.Context, in *ID, opts ...grpc.CallOption) (*RetentionPlan, error)
+	// the files which were added, removed or modified between two builds of an artefact
+	DiffBuilds(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*BuildDiff, error)
+	// the newest build of an artefact which was built from a git commit. builds with unknown commit (see BuildInfo) are looked up in the gitserver
+	GetBuildForCommit(ctx context.Context, in *BuildForCommitRequest, opts ...grpc.CallOption) (*BuildInfo, error)
+}
+
+type artefactServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewArtefactServiceClient(cc *grpc.ClientConn) ArtefactServiceClient {
+	return &artefactServiceClient{cc}
+}
+
+func (c *artefactServiceClient) List(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*ArtefactList, error) {
+	out := new(ArtefactList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) GetContents(ctx context.Context, in *Reference, opts ...grpc.CallOption) (*Contents, error) {
+	out := new(Contents)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetContents", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) StreamHTTP(ctx context.Context, in *h2gproxy.StreamRequest, opts ...grpc.CallOption) (ArtefactService_StreamHTTPClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ArtefactService_serviceDesc.Streams[0], c.cc, "/artefact.ArtefactService/StreamHTTP", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &artefactServiceStreamHTTPClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArtefactService_StreamHTTPClient interface {
+	Recv() (*h2gproxy.StreamDataResponse, error)
+	grpc.ClientStream
+}
+
+type artefactServiceStreamHTTPClient struct {
+	grpc.ClientStream
+}
+
+func (x *artefactServiceStreamHTTPClient) Recv() (*h2gproxy.StreamDataResponse, error) {
+	m := new(h2gproxy.StreamDataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *artefactServiceClient) GetFile(ctx context.Context, in *Reference, opts ...grpc.CallOption) (ArtefactService_GetFileClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ArtefactService_serviceDesc.Streams[1], c.cc, "/artefact.ArtefactService/GetFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &artefactServiceGetFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArtefactService_GetFileClient interface {
+	Recv() (*h2gproxy.StreamDataResponse, error)
+	grpc.ClientStream
+}
+
+type artefactServiceGetFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *artefactServiceGetFileClient) Recv() (*h2gproxy.StreamDataResponse, error) {
+	m := new(h2gproxy.StreamDataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *artefactServiceClient) SetAccess(ctx context.Context, in *SetAccessRequest, opts ...grpc.CallOption) (*common.Void, error) {
+	out := new(common.Void)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/SetAccess", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*ArtefactList, error) {
+	out := new(ArtefactList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/Find", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) GetRepoVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*Contents, error) {
+	out := new(Contents)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetRepoVersion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) GetArtefactBuilds(ctx context.Context, in *ArtefactID, opts ...grpc.CallOption) (*BuildList, error) {
+	out := new(BuildList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetArtefactBuilds", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) GetDirListing(ctx context.Context, in *DirListRequest, opts ...grpc.CallOption) (*DirListing, error) {
+	out := new(DirListing)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetDirListing", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) GetFileStream(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (ArtefactService_GetFileStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ArtefactService_serviceDesc.Streams[2], c.cc, "/artefact.ArtefactService/GetFileStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &artefactServiceGetFileStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArtefactService_GetFileStreamClient interface {
+	Recv() (*FileStreamResponse, error)
+	grpc.ClientStream
+}
+
+type artefactServiceGetFileStreamClient struct {
+	grpc.ClientStream
 }
 
 func (x *artefactServiceGetFileStreamClient) Recv() (*FileStreamResponse, error) {
@@ -1179,6 +3256,232 @@ func (c *artefactServiceClient) LatestBuildForGoEasyops(ctx context.Context, in
 	return out, nil
 }
 
+func (c *artefactServiceClient) GetDirArchive(ctx context.Context, in *ArchiveRequest, opts ...grpc.CallOption) (ArtefactService_GetDirArchiveClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ArtefactService_serviceDesc.Streams[3], c.cc, "/artefact.ArtefactService/GetDirArchive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &artefactServiceGetDirArchiveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArtefactService_GetDirArchiveClient interface {
+	Recv() (*FileStreamResponse, error)
+	grpc.ClientStream
+}
+
+type artefactServiceGetDirArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *artefactServiceGetDirArchiveClient) Recv() (*FileStreamResponse, error) {
+	m := new(FileStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *artefactServiceClient) WatchArtefacts(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ArtefactService_WatchArtefactsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ArtefactService_serviceDesc.Streams[4], c.cc, "/artefact.ArtefactService/WatchArtefacts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &artefactServiceWatchArtefactsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArtefactService_WatchArtefactsClient interface {
+	Recv() (*BuildEvent, error)
+	grpc.ClientStream
+}
+
+type artefactServiceWatchArtefactsClient struct {
+	grpc.ClientStream
+}
+
+func (x *artefactServiceWatchArtefactsClient) Recv() (*BuildEvent, error) {
+	m := new(BuildEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *artefactServiceClient) AddWebhook(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/AddWebhook", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) ListWebhooks(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*WebhookList, error) {
+	out := new(WebhookList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/ListWebhooks", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) DeleteWebhook(ctx context.Context, in *ID, opts ...grpc.CallOption) (*common.Void, error) {
+	out := new(common.Void)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/DeleteWebhook", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) ListBranches(ctx context.Context, in *ID, opts ...grpc.CallOption) (*BranchList, error) {
+	out := new(BranchList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/ListBranches", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) ListArtefacts(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ArtefactList, error) {
+	out := new(ArtefactList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/ListArtefacts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) ListBuilds(ctx context.Context, in *BuildListRequest, opts ...grpc.CallOption) (*BuildList, error) {
+	out := new(BuildList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/ListBuilds", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) AddBuildRepo(ctx context.Context, in *BuildRepoAddress, opts ...grpc.CallOption) (*BuildRepoServer, error) {
+	out := new(BuildRepoServer)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/AddBuildRepo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) RemoveBuildRepo(ctx context.Context, in *BuildRepoAddress, opts ...grpc.CallOption) (*common.Void, error) {
+	out := new(common.Void)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/RemoveBuildRepo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) ListBuildRepos(ctx context.Context, in *common.Void, opts ...grpc.CallOption) (*BuildRepoServerList, error) {
+	out := new(BuildRepoServerList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/ListBuildRepos", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) FindFiles(ctx context.Context, in *FindFilesRequest, opts ...grpc.CallOption) (*FileMatchList, error) {
+	out := new(FileMatchList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/FindFiles", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) PromoteBuild(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*Channel, error) {
+	out := new(Channel)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/PromoteBuild", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) ListChannels(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ChannelList, error) {
+	out := new(ChannelList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/ListChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) ChannelHistory(ctx context.Context, in *ID, opts ...grpc.CallOption) (*ChannelChangeList, error) {
+	out := new(ChannelChangeList)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/ChannelHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) MarkBuild(ctx context.Context, in *MarkBuildRequest, opts ...grpc.CallOption) (*common.Void, error) {
+	out := new(common.Void)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/MarkBuild", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) SetRetentionRule(ctx context.Context, in *RetentionRule, opts ...grpc.CallOption) (*RetentionRule, error) {
+	out := new(RetentionRule)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/SetRetentionRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) PlanRetention(ctx context.Context, in *ID, opts ...grpc.CallOption) (*RetentionPlan, error) {
+	out := new(RetentionPlan)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/PlanRetention", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) DiffBuilds(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*BuildDiff, error) {
+	out := new(BuildDiff)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/DiffBuilds", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *artefactServiceClient) GetBuildForCommit(ctx context.Context, in *BuildForCommitRequest, opts ...grpc.CallOption) (*BuildInfo, error) {
+	out := new(BuildInfo)
+	err := grpc.Invoke(ctx, "/artefact.ArtefactService/GetBuildForCommit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ArtefactService service
 
 type ArtefactServiceServer interface {
@@ -1217,341 +3520,747 @@ type ArtefactServiceServer interface {
 	// create artefact if required. if it exists already it will not be recreated. URL may be added or updated
 	CreateArtefactIfRequired(context.Context, *CreateArtefactRequest) (*CreateArtefactResponse, error)
 	LatestBuildForGoEasyops(context.Context, *common.Void) (*LatestBuild, error)
+	// get a directory (recursively) as zip or tar.gz archive
+	GetDirArchive(*ArchiveRequest, ArtefactService_GetDirArchiveServer) error
+	// stream an event each time the latest build of a watched artefact changes
+	WatchArtefacts(*WatchRequest, ArtefactService_WatchArtefactsServer) error
+	// register a webhook. it is called for each new build of an artefact (or all artefacts in a domain). Secret is generated if empty
+	AddWebhook(context.Context, *Webhook) (*Webhook, error)
+	// list the webhooks this user registered (all webhooks for root)
+	ListWebhooks(context.Context, *common.Void) (*WebhookList, error)
+	// remove a webhook
+	DeleteWebhook(context.Context, *ID) (*common.Void, error)
+	// list the branches of an artefact (by artefactid)
+	ListBranches(context.Context, *ID) (*BranchList, error)
+	// list artefacts, with filters and in pages
+	ListArtefacts(context.Context, *ListRequest) (*ArtefactList, error)
+	// list builds of an artefact, newest first and in pages
+	ListBuilds(context.Context, *BuildListRequest) (*BuildList, error)
+	// (root only) add a buildrepo at runtime. it is connected in the background if it is not reachable
+	AddBuildRepo(context.Context, *BuildRepoAddress) (*BuildRepoServer, error)
+	// (root only) stop using a buildrepo
+	RemoveBuildRepo(context.Context, *BuildRepoAddress) (*common.Void, error)
+	// (root only) list buildrepos and their state
+	ListBuildRepos(context.Context, *common.Void) (*BuildRepoServerList, error)
+	// find files by name (glob or regex) in the latest build of all artefacts or in all builds of one artefact
+	FindFiles(context.Context, *FindFilesRequest) (*FileMatchList, error)
+	// (artefact admins) pin a build to a release channel. links may use the channel instead of a version, e.g. version/stable/...
+	PromoteBuild(context.Context, *PromoteRequest) (*Channel, error)
+	// the release channels of an artefact (by artefactid)
+	ListChannels(context.Context, *ID) (*ChannelList, error)
+	// all changes to the release channels of an artefact (by artefactid)
+	ChannelHistory(context.Context, *ID) (*ChannelChangeList, error)
+	// (artefact admins) deprecate or yank a build, or make it available again
+	MarkBuild(context.Context, *MarkBuildRequest) (*common.Void, error)
+	// (artefact admins) set the retention rule of an artefact. a rule which keeps nothing (all zero) removes it
+	SetRetentionRule(context.Context, *RetentionRule) (*RetentionRule, error)
+	// which builds of an artefact (by artefactid) its retention rule would delete
+	PlanRetention(context.Context, *ID) (*RetentionPlan, error)
+	// the files which were added, removed or modified between two builds of an artefact
+	DiffBuilds(context.Context, *DiffRequest) (*BuildDiff, error)
+	// the newest build of an artefact which was built from a git commit. builds with unknown commit (see BuildInfo) are looked up in the gitserver
+	GetBuildForCommit(context.Context, *BuildForCommitRequest) (*BuildInfo, error)
+}
+
+func RegisterArtefactServiceServer(s *grpc.Server, srv ArtefactServiceServer) {
+	s.RegisterService(&_ArtefactService_serviceDesc, srv)
+}
+
+func _ArtefactService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).List(ctx, req.(*common.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetContents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Reference)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).GetContents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/GetContents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).GetContents(ctx, req.(*Reference))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_StreamHTTP_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(h2gproxy.StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArtefactServiceServer).StreamHTTP(m, &artefactServiceStreamHTTPServer{stream})
+}
+
+type ArtefactService_StreamHTTPServer interface {
+	Send(*h2gproxy.StreamDataResponse) error
+	grpc.ServerStream
+}
+
+type artefactServiceStreamHTTPServer struct {
+	grpc.ServerStream
+}
+
+func (x *artefactServiceStreamHTTPServer) Send(m *h2gproxy.StreamDataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArtefactService_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Reference)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArtefactServiceServer).GetFile(m, &artefactServiceGetFileServer{stream})
+}
+
+type ArtefactService_GetFileServer interface {
+	Send(*h2gproxy.StreamDataResponse) error
+	grpc.ServerStream
+}
+
+type artefactServiceGetFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *artefactServiceGetFileServer) Send(m *h2gproxy.StreamDataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArtefactService_SetAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).SetAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/SetAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).SetAccess(ctx, req.(*SetAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).Find(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/Find",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).Find(ctx, req.(*FindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetRepoVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).GetRepoVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/GetRepoVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).GetRepoVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetArtefactBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArtefactID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).GetArtefactBuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/GetArtefactBuilds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).GetArtefactBuilds(ctx, req.(*ArtefactID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetDirListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DirListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).GetDirListing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/GetDirListing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).GetDirListing(ctx, req.(*DirListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetFileStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArtefactServiceServer).GetFileStream(m, &artefactServiceGetFileStreamServer{stream})
+}
+
+type ArtefactService_GetFileStreamServer interface {
+	Send(*FileStreamResponse) error
+	grpc.ServerStream
+}
+
+type artefactServiceGetFileStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *artefactServiceGetFileStreamServer) Send(m *FileStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArtefactService_DoesFileExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).DoesFileExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/DoesFileExist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).DoesFileExist(ctx, req.(*FileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetRepoForArtefact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).GetRepoForArtefact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/GetRepoForArtefact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).GetRepoForArtefact(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetArtefactForRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).GetArtefactForRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/GetArtefactForRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).GetArtefactForRepo(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetArtefactByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).GetArtefactByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/GetArtefactByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).GetArtefactByID(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterArtefactServiceServer(s *grpc.Server, srv ArtefactServiceServer) {
-	s.RegisterService(&_ArtefactService_serviceDesc, srv)
+func _ArtefactService_GetArtefactIDForRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).GetArtefactIDForRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/GetArtefactIDForRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).GetArtefactIDForRepo(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(common.Void)
+func _ArtefactService_MetaByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).List(ctx, in)
+		return srv.(ArtefactServiceServer).MetaByID(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/List",
+		FullMethod: "/artefact.ArtefactService/MetaByID",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).List(ctx, req.(*common.Void))
+		return srv.(ArtefactServiceServer).MetaByID(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetContents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Reference)
+func _ArtefactService_CreateArtefactIfRequired_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateArtefactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).GetContents(ctx, in)
+		return srv.(ArtefactServiceServer).CreateArtefactIfRequired(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/GetContents",
+		FullMethod: "/artefact.ArtefactService/CreateArtefactIfRequired",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).GetContents(ctx, req.(*Reference))
+		return srv.(ArtefactServiceServer).CreateArtefactIfRequired(ctx, req.(*CreateArtefactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_StreamHTTP_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(h2gproxy.StreamRequest)
+func _ArtefactService_LatestBuildForGoEasyops_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).LatestBuildForGoEasyops(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/LatestBuildForGoEasyops",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).LatestBuildForGoEasyops(ctx, req.(*common.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_GetDirArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ArchiveRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArtefactServiceServer).StreamHTTP(m, &artefactServiceStreamHTTPServer{stream})
+	return srv.(ArtefactServiceServer).GetDirArchive(m, &artefactServiceGetDirArchiveServer{stream})
 }
 
-type ArtefactService_StreamHTTPServer interface {
-	Send(*h2gproxy.StreamDataResponse) error
+type ArtefactService_GetDirArchiveServer interface {
+	Send(*FileStreamResponse) error
 	grpc.ServerStream
 }
 
-type artefactServiceStreamHTTPServer struct {
+type artefactServiceGetDirArchiveServer struct {
 	grpc.ServerStream
 }
 
-func (x *artefactServiceStreamHTTPServer) Send(m *h2gproxy.StreamDataResponse) error {
+func (x *artefactServiceGetDirArchiveServer) Send(m *FileStreamResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArtefactService_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Reference)
+func _ArtefactService_WatchArtefacts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ArtefactServiceServer).GetFile(m, &artefactServiceGetFileServer{stream})
+	return srv.(ArtefactServiceServer).WatchArtefacts(m, &artefactServiceWatchArtefactsServer{stream})
 }
 
-type ArtefactService_GetFileServer interface {
-	Send(*h2gproxy.StreamDataResponse) error
+type ArtefactService_WatchArtefactsServer interface {
+	Send(*BuildEvent) error
 	grpc.ServerStream
 }
 
-type artefactServiceGetFileServer struct {
+type artefactServiceWatchArtefactsServer struct {
 	grpc.ServerStream
 }
 
-func (x *artefactServiceGetFileServer) Send(m *h2gproxy.StreamDataResponse) error {
+func (x *artefactServiceWatchArtefactsServer) Send(m *BuildEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _ArtefactService_SetAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetAccessRequest)
+func _ArtefactService_AddWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Webhook)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).SetAccess(ctx, in)
+		return srv.(ArtefactServiceServer).AddWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/SetAccess",
+		FullMethod: "/artefact.ArtefactService/AddWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).SetAccess(ctx, req.(*SetAccessRequest))
+		return srv.(ArtefactServiceServer).AddWebhook(ctx, req.(*Webhook))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FindRequest)
+func _ArtefactService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Void)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).Find(ctx, in)
+		return srv.(ArtefactServiceServer).ListWebhooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/Find",
+		FullMethod: "/artefact.ArtefactService/ListWebhooks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).Find(ctx, req.(*FindRequest))
+		return srv.(ArtefactServiceServer).ListWebhooks(ctx, req.(*common.Void))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetRepoVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetVersionRequest)
+func _ArtefactService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).GetRepoVersion(ctx, in)
+		return srv.(ArtefactServiceServer).DeleteWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/GetRepoVersion",
+		FullMethod: "/artefact.ArtefactService/DeleteWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).GetRepoVersion(ctx, req.(*GetVersionRequest))
+		return srv.(ArtefactServiceServer).DeleteWebhook(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetArtefactBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ArtefactID)
+func _ArtefactService_ListBranches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).GetArtefactBuilds(ctx, in)
+		return srv.(ArtefactServiceServer).ListBranches(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/GetArtefactBuilds",
+		FullMethod: "/artefact.ArtefactService/ListBranches",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).GetArtefactBuilds(ctx, req.(*ArtefactID))
+		return srv.(ArtefactServiceServer).ListBranches(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetDirListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DirListRequest)
+func _ArtefactService_ListArtefacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).GetDirListing(ctx, in)
+		return srv.(ArtefactServiceServer).ListArtefacts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/GetDirListing",
+		FullMethod: "/artefact.ArtefactService/ListArtefacts",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).GetDirListing(ctx, req.(*DirListRequest))
+		return srv.(ArtefactServiceServer).ListArtefacts(ctx, req.(*ListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetFileStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(FileRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _ArtefactService_ListBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(ArtefactServiceServer).GetFileStream(m, &artefactServiceGetFileStreamServer{stream})
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).ListBuilds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/ListBuilds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).ListBuilds(ctx, req.(*BuildListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type ArtefactService_GetFileStreamServer interface {
-	Send(*FileStreamResponse) error
-	grpc.ServerStream
+func _ArtefactService_AddBuildRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildRepoAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).AddBuildRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/AddBuildRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).AddBuildRepo(ctx, req.(*BuildRepoAddress))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type artefactServiceGetFileStreamServer struct {
-	grpc.ServerStream
+func _ArtefactService_RemoveBuildRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildRepoAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).RemoveBuildRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/RemoveBuildRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).RemoveBuildRepo(ctx, req.(*BuildRepoAddress))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *artefactServiceGetFileStreamServer) Send(m *FileStreamResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _ArtefactService_ListBuildRepos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).ListBuildRepos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/ListBuildRepos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).ListBuildRepos(ctx, req.(*common.Void))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_DoesFileExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FileRequest)
+func _ArtefactService_FindFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindFilesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).DoesFileExist(ctx, in)
+		return srv.(ArtefactServiceServer).FindFiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/DoesFileExist",
+		FullMethod: "/artefact.ArtefactService/FindFiles",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).DoesFileExist(ctx, req.(*FileRequest))
+		return srv.(ArtefactServiceServer).FindFiles(ctx, req.(*FindFilesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetRepoForArtefact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _ArtefactService_PromoteBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).GetRepoForArtefact(ctx, in)
+		return srv.(ArtefactServiceServer).PromoteBuild(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/GetRepoForArtefact",
+		FullMethod: "/artefact.ArtefactService/PromoteBuild",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).GetRepoForArtefact(ctx, req.(*ID))
+		return srv.(ArtefactServiceServer).PromoteBuild(ctx, req.(*PromoteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetArtefactForRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ArtefactService_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).GetArtefactForRepo(ctx, in)
+		return srv.(ArtefactServiceServer).ListChannels(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/GetArtefactForRepo",
+		FullMethod: "/artefact.ArtefactService/ListChannels",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).GetArtefactForRepo(ctx, req.(*ID))
+		return srv.(ArtefactServiceServer).ListChannels(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetArtefactByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ArtefactService_ChannelHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).GetArtefactByID(ctx, in)
+		return srv.(ArtefactServiceServer).ChannelHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/GetArtefactByID",
+		FullMethod: "/artefact.ArtefactService/ChannelHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).GetArtefactByID(ctx, req.(*ID))
+		return srv.(ArtefactServiceServer).ChannelHistory(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_GetArtefactIDForRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ID)
+func _ArtefactService_MarkBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkBuildRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).GetArtefactIDForRepo(ctx, in)
+		return srv.(ArtefactServiceServer).MarkBuild(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/GetArtefactIDForRepo",
+		FullMethod: "/artefact.ArtefactService/MarkBuild",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).GetArtefactIDForRepo(ctx, req.(*ID))
+		return srv.(ArtefactServiceServer).MarkBuild(ctx, req.(*MarkBuildRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_MetaByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ArtefactService_SetRetentionRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetentionRule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArtefactServiceServer).SetRetentionRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/artefact.ArtefactService/SetRetentionRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArtefactServiceServer).SetRetentionRule(ctx, req.(*RetentionRule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArtefactService_PlanRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).MetaByID(ctx, in)
+		return srv.(ArtefactServiceServer).PlanRetention(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/MetaByID",
+		FullMethod: "/artefact.ArtefactService/PlanRetention",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).MetaByID(ctx, req.(*ID))
+		return srv.(ArtefactServiceServer).PlanRetention(ctx, req.(*ID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_CreateArtefactIfRequired_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateArtefactRequest)
+func _ArtefactService_DiffBuilds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).CreateArtefactIfRequired(ctx, in)
+		return srv.(ArtefactServiceServer).DiffBuilds(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/CreateArtefactIfRequired",
+		FullMethod: "/artefact.ArtefactService/DiffBuilds",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).CreateArtefactIfRequired(ctx, req.(*CreateArtefactRequest))
+		return srv.(ArtefactServiceServer).DiffBuilds(ctx, req.(*DiffRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ArtefactService_LatestBuildForGoEasyops_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(common.Void)
+func _ArtefactService_GetBuildForCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildForCommitRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ArtefactServiceServer).LatestBuildForGoEasyops(ctx, in)
+		return srv.(ArtefactServiceServer).GetBuildForCommit(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/artefact.ArtefactService/LatestBuildForGoEasyops",
+		FullMethod: "/artefact.ArtefactService/GetBuildForCommit",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ArtefactServiceServer).LatestBuildForGoEasyops(ctx, req.(*common.Void))
+		return srv.(ArtefactServiceServer).GetBuildForCommit(ctx, req.(*BuildForCommitRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1620,6 +4329,78 @@ var _ArtefactService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LatestBuildForGoEasyops",
 			Handler:    _ArtefactService_LatestBuildForGoEasyops_Handler,
 		},
+		{
+			MethodName: "AddWebhook",
+			Handler:    _ArtefactService_AddWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _ArtefactService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _ArtefactService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListBranches",
+			Handler:    _ArtefactService_ListBranches_Handler,
+		},
+		{
+			MethodName: "ListArtefacts",
+			Handler:    _ArtefactService_ListArtefacts_Handler,
+		},
+		{
+			MethodName: "ListBuilds",
+			Handler:    _ArtefactService_ListBuilds_Handler,
+		},
+		{
+			MethodName: "AddBuildRepo",
+			Handler:    _ArtefactService_AddBuildRepo_Handler,
+		},
+		{
+			MethodName: "RemoveBuildRepo",
+			Handler:    _ArtefactService_RemoveBuildRepo_Handler,
+		},
+		{
+			MethodName: "ListBuildRepos",
+			Handler:    _ArtefactService_ListBuildRepos_Handler,
+		},
+		{
+			MethodName: "FindFiles",
+			Handler:    _ArtefactService_FindFiles_Handler,
+		},
+		{
+			MethodName: "PromoteBuild",
+			Handler:    _ArtefactService_PromoteBuild_Handler,
+		},
+		{
+			MethodName: "ListChannels",
+			Handler:    _ArtefactService_ListChannels_Handler,
+		},
+		{
+			MethodName: "ChannelHistory",
+			Handler:    _ArtefactService_ChannelHistory_Handler,
+		},
+		{
+			MethodName: "MarkBuild",
+			Handler:    _ArtefactService_MarkBuild_Handler,
+		},
+		{
+			MethodName: "SetRetentionRule",
+			Handler:    _ArtefactService_SetRetentionRule_Handler,
+		},
+		{
+			MethodName: "PlanRetention",
+			Handler:    _ArtefactService_PlanRetention_Handler,
+		},
+		{
+			MethodName: "DiffBuilds",
+			Handler:    _ArtefactService_DiffBuilds_Handler,
+		},
+		{
+			MethodName: "GetBuildForCommit",
+			Handler:    _ArtefactService_GetBuildForCommit_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1637,6 +4418,16 @@ var _ArtefactService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ArtefactService_GetFileStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetDirArchive",
+			Handler:       _ArtefactService_GetDirArchive_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchArtefacts",
+			Handler:       _ArtefactService_WatchArtefacts_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "golang.conradwood.net/apis/artefact/artefact.proto",
 }
@@ -1644,94 +4435,220 @@ var _ArtefactService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("golang.conradwood.net/apis/artefact/artefact.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1410 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x57, 0xff, 0x72, 0x1b, 0xb5,
-	0x13, 0xff, 0x9e, 0x7d, 0x49, 0xec, 0xb5, 0x9d, 0xb8, 0xfa, 0x26, 0xe9, 0x8d, 0xe9, 0x80, 0xe7,
-	0x0a, 0x8c, 0xfb, 0x63, 0xdc, 0x60, 0x5a, 0x98, 0x29, 0x4c, 0xdb, 0xb4, 0x97, 0x18, 0x77, 0x52,
-	0xe8, 0x28, 0x6e, 0x07, 0x98, 0x01, 0x46, 0xb5, 0x95, 0x54, 0x83, 0x7d, 0x32, 0x77, 0x6a, 0x9b,
-	0xc0, 0x53, 0xf0, 0x10, 0xfc, 0xc9, 0xab, 0xf0, 0x10, 0x3c, 0x09, 0x23, 0xe9, 0x74, 0x27, 0xd9,
-	0x97, 0x96, 0x19, 0xfe, 0x3a, 0xed, 0x6a, 0xb5, 0x3f, 0x3e, 0xbb, 0xda, 0xd5, 0xc1, 0xe0, 0x94,
-	0xcf, 0x48, 0x7c, 0xda, 0x9f, 0xf0, 0x38, 0x21, 0xd3, 0x37, 0x9c, 0x4f, 0xfb, 0x31, 0x15, 0xb7,
-	0xc8, 0x82, 0xa5, 0xb7, 0x48, 0x22, 0xe8, 0x09, 0x99, 0x88, 0x7c, 0xd1, 0x5f, 0x24, 0x5c, 0x70,
-	0x54, 0x33, 0x74, 0xa7, 0xff, 0x96, 0xd3, 0x13, 0x3e, 0x9f, 0xf3, 0x38, 0xfb, 0xe8, 0x93, 0x9d,
-	0xb7, 0x59, 0x7b, 0x39, 0x38, 0x5d, 0x24, 0xfc, 0xec, 0x3c, 0x5f, 0xe8, 0x33, 0xe1, 0x03, 0x68,
-	0xee, 0x67, 0xf6, 0x8e, 0x58, 0x2a, 0xd0, 0x1e, 0xd4, 0x0d, 0x9d, 0x06, 0x5e, 0xb7, 0xda, 0x6b,
-	0x0c, 0x50, 0x3f, 0xf7, 0xf0, 0x11, 0x8f, 0x05, 0x8d, 0x45, 0x8a, 0x0b, 0xa1, 0xf0, 0x16, 0x6c,
-	0x45, 0xfc, 0x4d, 0x3c, 0xe3, 0x64, 0x8a, 0xe9, 0x2f, 0xaf, 0x68, 0x2a, 0xd0, 0x15, 0xa8, 0x63,
-	0x7a, 0x42, 0x13, 0x1a, 0x4f, 0x68, 0xe0, 0x75, 0xbd, 0x5e, 0x1d, 0x17, 0x8c, 0xb0, 0x0b, 0x70,
-	0xc8, 0x66, 0xf4, 0x58, 0x24, 0x94, 0xcc, 0x11, 0x02, 0x3f, 0x22, 0x82, 0x28, 0xb1, 0x26, 0x56,
-	0xeb, 0xf0, 0x9a, 0x75, 0xfe, 0x1d, 0xca, 0xbe, 0x80, 0x86, 0x71, 0x05, 0xd3, 0x13, 0xa9, 0xed,
-	0x6b, 0x32, 0x37, 0x72, 0x6a, 0x8d, 0x02, 0xd8, 0x78, 0x4e, 0x93, 0x94, 0xf1, 0x38, 0xa8, 0x74,
-	0xbd, 0x9e, 0x8f, 0x0d, 0x19, 0xfe, 0xee, 0xc1, 0xd6, 0x31, 0x4d, 0x18, 0x99, 0x15, 0xe6, 0x02,
-	0xd8, 0xc0, 0xf4, 0x64, 0x7c, 0xbe, 0xd0, 0x4a, 0x5a, 0xd8, 0x90, 0x17, 0xeb, 0x41, 0xdb, 0xb0,
-	0x36, 0xa6, 0x67, 0x22, 0x0d, 0xaa, 0xdd, 0x6a, 0xaf, 0x8e, 0x35, 0x81, 0x76, 0x61, 0x3d, 0xe2,
-	0x73, 0xc2, 0xe2, 0xc0, 0x57, 0xde, 0x64, 0x94, 0x0c, 0xe8, 0xe1, 0x2b, 0x36, 0x9b, 0x62, 0xba,
-	0xe0, 0xc1, 0x9a, 0x0e, 0x28, 0x67, 0x84, 0x7f, 0xf9, 0x50, 0x33, 0x30, 0xa3, 0xeb, 0xd0, 0xce,
-	0x3d, 0x33, 0xb6, 0x75, 0x68, 0x2b, 0x7c, 0xd4, 0x83, 0xad, 0x9c, 0x77, 0x44, 0x04, 0x4d, 0x85,
-	0x72, 0xb3, 0x8e, 0x97, 0xd9, 0xe8, 0x26, 0x6c, 0x1c, 0xc4, 0x22, 0x61, 0x54, 0x3b, 0x5c, 0x9e,
-	0x61, 0x23, 0x92, 0x43, 0xea, 0x97, 0x43, 0xba, 0xe6, 0x42, 0xd1, 0x85, 0xc6, 0xfe, 0x74, 0xce,
-	0xe2, 0xfd, 0xc9, 0x84, 0xa6, 0x69, 0xb0, 0xde, 0xf5, 0x7a, 0x35, 0x6c, 0xb3, 0xd0, 0x35, 0xf0,
-	0x15, 0xba, 0x1b, 0x5d, 0xaf, 0xb7, 0x39, 0xd8, 0x59, 0x31, 0x2d, 0x37, 0xb1, 0x12, 0x41, 0x9f,
-	0x40, 0xcd, 0x24, 0x37, 0xa8, 0x75, 0xbd, 0x5e, 0xc3, 0x16, 0xb7, 0xd2, 0x8e, 0x73, 0x31, 0xe9,
-	0xed, 0x53, 0x22, 0x5e, 0x06, 0x75, 0xed, 0xad, 0x5c, 0xa3, 0x10, 0x9a, 0xa6, 0x42, 0xc9, 0x8b,
-	0x19, 0x0d, 0x40, 0x39, 0xe5, 0xf0, 0xac, 0x64, 0x35, 0x9c, 0x64, 0xdd, 0x06, 0x30, 0xba, 0x47,
-	0x51, 0xd0, 0x54, 0x4e, 0x6c, 0xaf, 0x3a, 0x31, 0x8a, 0xb0, 0x25, 0xe7, 0xa6, 0xb8, 0xb5, 0x94,
-	0x62, 0xe9, 0x8f, 0xfc, 0xa6, 0x4c, 0xf0, 0xe4, 0x7c, 0x14, 0x05, 0x9b, 0x0a, 0x42, 0x87, 0x87,
-	0x3e, 0x84, 0xd6, 0x11, 0x8b, 0x7f, 0x1e, 0x73, 0x83, 0xf3, 0x96, 0xd2, 0xe2, 0x32, 0xa5, 0x26,
-	0xcd, 0xc8, 0x12, 0xde, 0x56, 0x42, 0x0e, 0x2f, 0x9c, 0x43, 0xfb, 0x98, 0x0a, 0x0d, 0xbe, 0xb9,
-	0xa0, 0x37, 0x60, 0x7d, 0x4c, 0x92, 0x53, 0x2a, 0x54, 0x35, 0x35, 0x06, 0xff, 0x2f, 0x22, 0xca,
-	0x8b, 0x05, 0x67, 0x22, 0x12, 0x9a, 0x67, 0x29, 0x4d, 0x46, 0x51, 0x56, 0x4f, 0x19, 0x25, 0xab,
-	0x7e, 0x98, 0x90, 0x58, 0x04, 0x55, 0x85, 0xa7, 0x26, 0xc2, 0x1b, 0xd0, 0x38, 0x64, 0xb1, 0xdd,
-	0x0a, 0x64, 0xc5, 0x3c, 0x21, 0x62, 0xf2, 0xd2, 0xdc, 0xde, 0x9c, 0x11, 0x7e, 0x07, 0x97, 0x86,
-	0x54, 0x64, 0xd1, 0x98, 0x23, 0x65, 0x77, 0xb8, 0x48, 0x4f, 0xc5, 0x49, 0x8f, 0x55, 0x88, 0x55,
-	0xf7, 0x6e, 0x5f, 0xcd, 0x52, 0xa0, 0xba, 0xda, 0x2e, 0xac, 0x2b, 0x42, 0xb7, 0x34, 0x1f, 0x67,
-	0x54, 0xf8, 0x2d, 0x6c, 0x46, 0x2c, 0x91, 0x22, 0xc6, 0xf8, 0x36, 0xac, 0xa9, 0x3d, 0x65, 0xdd,
-	0xc7, 0x9a, 0x40, 0x6d, 0xa8, 0x46, 0x2c, 0xc9, 0x6c, 0xcb, 0x25, 0x7a, 0xdf, 0xa9, 0x0b, 0x6d,
-	0xdb, 0xe2, 0x84, 0x3f, 0x49, 0x18, 0x66, 0xd4, 0xa8, 0x75, 0xc5, 0xbd, 0x65, 0xf1, 0xc2, 0x6c,
-	0xc5, 0x36, 0xdb, 0x81, 0x9a, 0x54, 0x12, 0x4b, 0x34, 0xaa, 0xca, 0x76, 0x4e, 0x87, 0x0f, 0xf4,
-	0xde, 0x28, 0x3e, 0xe1, 0xa5, 0x88, 0x75, 0xa1, 0x81, 0xe9, 0x8c, 0x08, 0xf6, 0x9a, 0x16, 0xae,
-	0xdb, 0xac, 0xf0, 0x3e, 0x6c, 0x44, 0x2c, 0xf9, 0x0f, 0x0a, 0x06, 0xc5, 0xec, 0x50, 0x5a, 0x36,
-	0xa1, 0x92, 0x07, 0x57, 0x19, 0x45, 0xb9, 0xd6, 0x4a, 0xa1, 0x35, 0xfc, 0xd3, 0x03, 0xc8, 0x20,
-	0x67, 0xf1, 0x29, 0xea, 0xc1, 0x9a, 0x8c, 0xa2, 0x64, 0xd4, 0x98, 0xe0, 0xb0, 0x16, 0x40, 0x1f,
-	0x81, 0x1f, 0xb1, 0x24, 0x0d, 0x2a, 0x4a, 0xf0, 0x52, 0x21, 0x98, 0xc5, 0x80, 0xd5, 0x36, 0xba,
-	0xeb, 0xfa, 0xa4, 0x60, 0x6b, 0x0c, 0x76, 0x4b, 0x6e, 0xac, 0x3c, 0xe3, 0xfa, 0x6f, 0x7a, 0x87,
-	0x5f, 0xf4, 0x8e, 0xf0, 0x31, 0xa0, 0x62, 0x58, 0x61, 0x9a, 0x2e, 0x78, 0x9c, 0x52, 0x93, 0x98,
-	0x94, 0xfd, 0x4a, 0xb3, 0x78, 0x73, 0x5a, 0x96, 0xe4, 0x53, 0x72, 0x2e, 0x1b, 0x8b, 0x0a, 0xbc,
-	0x89, 0x0d, 0x19, 0x7e, 0x09, 0x9b, 0x52, 0xea, 0xe0, 0x8c, 0xa5, 0x22, 0x55, 0x16, 0x77, 0x61,
-	0x5d, 0x53, 0x4a, 0x4b, 0x0d, 0x67, 0x94, 0xf4, 0xe4, 0x58, 0xea, 0xd6, 0xd5, 0xa0, 0xd6, 0xe1,
-	0xb6, 0x44, 0x77, 0x19, 0xe3, 0x50, 0xd8, 0x85, 0xb5, 0x92, 0x81, 0x8b, 0xae, 0x8d, 0xc9, 0x4c,
-	0xd5, 0xca, 0x77, 0x1b, 0xaa, 0xcf, 0xf0, 0x51, 0x16, 0xbc, 0x5c, 0xca, 0x48, 0x1e, 0x25, 0x94,
-	0x08, 0x3a, 0x55, 0x5d, 0xbe, 0x85, 0x0d, 0x19, 0xfe, 0x56, 0xa0, 0xfc, 0x84, 0x0a, 0xb2, 0x62,
-	0x77, 0xb9, 0xc3, 0x55, 0x4a, 0x3a, 0xdc, 0xe7, 0xd0, 0xd0, 0x1d, 0x4a, 0x17, 0x7e, 0x75, 0xb9,
-	0xbf, 0x5b, 0x9b, 0xd8, 0x96, 0x0c, 0xff, 0xf0, 0x60, 0x47, 0x3b, 0x52, 0x8c, 0x00, 0x7d, 0xcb,
-	0x3e, 0x86, 0xcd, 0x6f, 0x92, 0x53, 0x12, 0xb3, 0x94, 0x08, 0xc6, 0xe3, 0xcc, 0xa5, 0x3a, 0x5e,
-	0xe2, 0x4a, 0xf7, 0xcc, 0x51, 0xab, 0x40, 0x1d, 0x9e, 0x1c, 0xa7, 0x79, 0xc7, 0xce, 0x30, 0xd4,
-	0x68, 0x2d, 0xb3, 0x25, 0xc8, 0x43, 0x26, 0x0a, 0xec, 0x32, 0x2a, 0xfc, 0x11, 0x76, 0x97, 0xdd,
-	0xcc, 0xca, 0xc7, 0x02, 0x56, 0xe7, 0xdd, 0x90, 0xe8, 0x3a, 0xf8, 0x12, 0x50, 0xe5, 0x51, 0x69,
-	0xd9, 0xca, 0x5d, 0xac, 0x64, 0xc2, 0x27, 0x0e, 0x80, 0x52, 0xa9, 0x5a, 0xe4, 0x89, 0x30, 0xa4,
-	0x9c, 0x25, 0xcf, 0x62, 0x76, 0x36, 0x66, 0x73, 0x9a, 0x0a, 0x32, 0x5f, 0x28, 0xed, 0x2d, 0xec,
-	0x32, 0xaf, 0xdf, 0x86, 0x86, 0x35, 0x81, 0x51, 0x0b, 0xea, 0x11, 0x4b, 0xe8, 0x44, 0x66, 0xab,
-	0xfd, 0x3f, 0x54, 0x03, 0x5f, 0xd6, 0x6e, 0xdb, 0x43, 0xcd, 0x62, 0x28, 0xb7, 0x2b, 0x83, 0xbf,
-	0x6b, 0xb0, 0x65, 0xc8, 0x63, 0x9a, 0xbc, 0x66, 0x13, 0x8a, 0x6e, 0x82, 0xaf, 0xba, 0x6e, 0xb3,
-	0x9f, 0x3d, 0x4f, 0x9f, 0x73, 0x36, 0xed, 0x94, 0x04, 0xa3, 0xa4, 0x3e, 0x83, 0xc6, 0x90, 0x8a,
-	0xfc, 0xc9, 0x53, 0x36, 0x8a, 0x3a, 0x25, 0x0f, 0x14, 0x74, 0x00, 0xa0, 0x6f, 0xe5, 0x57, 0xe3,
-	0xf1, 0x53, 0x74, 0xb9, 0x9f, 0x3f, 0x6c, 0xcd, 0x5d, 0x55, 0x35, 0xd1, 0xb9, 0xb2, 0xbc, 0x21,
-	0x5f, 0x98, 0x26, 0x13, 0x7b, 0x1e, 0xba, 0x07, 0x1b, 0x43, 0x2a, 0x64, 0x6c, 0xe5, 0xa6, 0xdf,
-	0x75, 0xfe, 0x0e, 0xd4, 0xf3, 0xf1, 0x8a, 0x3a, 0x85, 0x86, 0xe5, 0x99, 0xdb, 0x71, 0xd0, 0x40,
-	0x77, 0x24, 0x9e, 0xf1, 0x14, 0xed, 0xd8, 0x1d, 0x2f, 0x1f, 0x9b, 0x17, 0x82, 0xb5, 0x0f, 0x9b,
-	0x43, 0x2a, 0x64, 0xf1, 0x99, 0x27, 0xc0, 0x7b, 0x85, 0xe4, 0xca, 0x28, 0x2d, 0xc5, 0xed, 0x9e,
-	0x9a, 0xb9, 0x46, 0xab, 0x1e, 0x84, 0xa8, 0xf4, 0x49, 0xd3, 0xb1, 0x00, 0x29, 0x66, 0xe9, 0x7d,
-	0x68, 0x0d, 0xa9, 0xb0, 0x7a, 0x78, 0xe0, 0xf4, 0x62, 0x6b, 0x98, 0x76, 0xb6, 0x57, 0x76, 0xa4,
-	0xfc, 0xa1, 0x52, 0x60, 0xfd, 0x02, 0xec, 0xb8, 0x5d, 0xbf, 0xc8, 0x9c, 0xc3, 0x76, 0x5b, 0xf0,
-	0x9e, 0x87, 0x1e, 0x40, 0x2b, 0xe2, 0x34, 0xcd, 0x5b, 0xea, 0x45, 0x7a, 0x02, 0x97, 0x6d, 0xb5,
-	0xdf, 0x3d, 0x40, 0x19, 0x9a, 0x87, 0x3c, 0xc9, 0x9f, 0x90, 0xcd, 0x42, 0x7e, 0x14, 0x75, 0x1c,
-	0x2a, 0x3b, 0x61, 0x44, 0x0f, 0x79, 0xa2, 0x1e, 0x74, 0x6f, 0x3b, 0x71, 0x07, 0xb6, 0x6c, 0xb8,
-	0x65, 0xe7, 0x73, 0xc5, 0x4b, 0xa1, 0x47, 0x77, 0x61, 0xdb, 0x3a, 0x36, 0x8a, 0xca, 0x4d, 0x95,
-	0x9f, 0xdd, 0x83, 0x9a, 0x6c, 0x10, 0x25, 0xb6, 0x2e, 0x68, 0x28, 0xe8, 0x07, 0x08, 0xdc, 0x56,
-	0x35, 0x3a, 0x91, 0xe8, 0xb1, 0x84, 0x4e, 0xd1, 0x07, 0x56, 0x0d, 0x95, 0x75, 0xdd, 0x4e, 0xf7,
-	0x62, 0x81, 0xac, 0xdf, 0xdd, 0x83, 0xcb, 0x56, 0xa7, 0x3a, 0xe4, 0xc9, 0x90, 0x1f, 0x90, 0xf4,
-	0x9c, 0x2f, 0xd2, 0xa5, 0x1e, 0x51, 0xde, 0xfe, 0x1f, 0x3e, 0x86, 0xab, 0x31, 0x15, 0xf6, 0x7f,
-	0x6d, 0xf6, 0xa7, 0x2b, 0x7f, 0x6d, 0xf3, 0x23, 0xdf, 0x5f, 0xfd, 0x17, 0x7f, 0xdb, 0x2f, 0xd6,
-	0xd5, 0x7f, 0xef, 0xa7, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0xf3, 0xc0, 0xab, 0x77, 0x9b, 0x0f,
-	0x00, 0x00,
+	// 3427 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xbc, 0x3a, 0xcd, 0x6f, 0x1b, 0xc7,
+	0xf5, 0x5e, 0x7e, 0xf3, 0x51, 0xa4, 0xe8, 0xb1, 0x6c, 0xef, 0x8f, 0xf1, 0x2f, 0x15, 0x36, 0x69,
+	0x20, 0x2b, 0x8e, 0xec, 0x28, 0x71, 0x52, 0x24, 0x8d, 0x13, 0xca, 0x94, 0x64, 0x26, 0x76, 0x22,
+	0x8c, 0xe4, 0xa4, 0x0d, 0xda, 0x02, 0x1b, 0xee, 0x90, 0x5a, 0x98, 0xdc, 0x55, 0x76, 0x47, 0x8a,
+	0x94, 0xe6, 0x58, 0xa0, 0xd7, 0xde, 0x8a, 0x16, 0x05, 0x8a, 0x1c, 0x5a, 0x34, 0x87, 0xa2, 0x87,
+	0xa2, 0xe8, 0xb1, 0xd7, 0xa2, 0x87, 0x5e, 0x7a, 0xea, 0xa9, 0xb7, 0xf6, 0xd2, 0x7f, 0xa0, 0xb7,
+	0x62, 0xbe, 0x76, 0x67, 0x96, 0xbb, 0x92, 0xad, 0xa0, 0x3e, 0x91, 0xef, 0xcd, 0x9b, 0x99, 0xf7,
+	0x35, 0xef, 0xbd, 0x79, 0xb3, 0xb0, 0x3e, 0x09, 0xa7, 0x6e, 0x30, 0x59, 0x1b, 0x85, 0x41, 0xe4,
+	0x7a, 0x9f, 0x85, 0xa1, 0xb7, 0x16, 0x10, 0x7a, 0xd3, 0x3d, 0xf0, 0xe3, 0x9b, 0x6e, 0x44, 0xc9,
+	0xd8, 0x1d, 0xd1, 0xe4, 0xcf, 0xda, 0x41, 0x14, 0xd2, 0x10, 0x35, 0x14, 0xdc, 0x5b, 0x3b, 0x65,
+	0xf6, 0x28, 0x9c, 0xcd, 0xc2, 0x40, 0xfe, 0x88, 0x99, 0xbd, 0xd3, 0x76, 0xdb, 0x5f, 0x9f, 0x1c,
+	0x44, 0xe1, 0xf1, 0x49, 0xf2, 0x47, 0xcc, 0x71, 0xfe, 0x6c, 0xc1, 0x42, 0x5f, 0x6e, 0x78, 0xdf,
+	0x8f, 0x29, 0xba, 0x05, 0x4d, 0x05, 0xc7, 0xb6, 0xb5, 0x5c, 0x5e, 0x69, 0xad, 0xa3, 0xb5, 0x84,
+	0xc5, 0xbb, 0x61, 0x40, 0x49, 0x40, 0x63, 0x9c, 0x12, 0xa1, 0xe7, 0xa1, 0xfd, 0x3e, 0x39, 0xa6,
+	0x3b, 0xee, 0x84, 0xec, 0x85, 0x8f, 0x48, 0x60, 0x57, 0x96, 0xad, 0x95, 0x26, 0x36, 0x91, 0xe8,
+	0x55, 0x68, 0x0c, 0xc8, 0x24, 0x72, 0x3d, 0xe2, 0xd9, 0x25, 0xbe, 0xac, 0x9d, 0x2e, 0xab, 0x46,
+	0x76, 0xc3, 0xc3, 0x68, 0x44, 0x70, 0x42, 0x89, 0x56, 0xa1, 0x7b, 0xd7, 0xa5, 0xee, 0x34, 0x9c,
+	0x1c, 0x92, 0x87, 0x07, 0x9e, 0x4b, 0x89, 0x67, 0x97, 0x97, 0xad, 0x95, 0x36, 0x9e, 0xc3, 0x3b,
+	0xdf, 0x83, 0x8e, 0xb9, 0x0e, 0xba, 0x02, 0xb5, 0x41, 0x38, 0x73, 0xfd, 0xc0, 0xb6, 0x38, 0x4b,
+	0x12, 0x42, 0xd7, 0xa0, 0xb9, 0x71, 0xe8, 0x4f, 0x3d, 0x4c, 0x0e, 0x42, 0xbb, 0xc4, 0x87, 0x52,
+	0x04, 0x5a, 0x82, 0xea, 0x66, 0x14, 0x85, 0x11, 0xdf, 0xa8, 0x89, 0x05, 0xe0, 0xdc, 0x84, 0xc5,
+	0x41, 0xf8, 0x59, 0x30, 0x0d, 0x5d, 0x0f, 0x93, 0x4f, 0x0f, 0x49, 0x4c, 0xd9, 0x32, 0x98, 0x8c,
+	0x49, 0x44, 0x82, 0x11, 0x91, 0x3b, 0xa4, 0x08, 0x67, 0x19, 0x60, 0xcb, 0x9f, 0x92, 0x5d, 0x1a,
+	0x11, 0x77, 0x86, 0x10, 0x54, 0x06, 0x2e, 0x75, 0x39, 0xd9, 0x02, 0xe6, 0xff, 0x9d, 0xeb, 0xda,
+	0xfc, 0x33, 0x16, 0x7b, 0x13, 0x5a, 0x4a, 0xe1, 0x98, 0x8c, 0xd9, 0x6a, 0xef, 0xbb, 0x33, 0x45,
+	0xc7, 0xff, 0x23, 0x1b, 0xea, 0x1f, 0x92, 0x28, 0xf6, 0xc3, 0x80, 0x8b, 0x54, 0xc1, 0x0a, 0x74,
+	0xbe, 0xb2, 0x60, 0x71, 0x97, 0x44, 0xbe, 0x3b, 0x4d, 0xb7, 0xb3, 0xa1, 0x8e, 0xc9, 0x78, 0xef,
+	0xe4, 0x40, 0x2c, 0xd2, 0xc6, 0x0a, 0x2c, 0x5e, 0x87, 0x29, 0x66, 0x8f, 0x1c, 0xd3, 0xd8, 0x2e,
+	0x2f, 0x97, 0x99, 0x62, 0x38, 0xa0, 0x29, 0xb9, 0x52, 0xac, 0xe4, 0x6a, 0x56, 0xc9, 0x57, 0xa0,
+	0xb6, 0x11, 0xb9, 0xc1, 0x68, 0xdf, 0xae, 0x89, 0x59, 0x02, 0x72, 0xbe, 0xaa, 0x41, 0x43, 0x39,
+	0x19, 0xb3, 0x7e, 0xc2, 0xb1, 0xe2, 0x49, 0x88, 0x3c, 0x87, 0x47, 0x2b, 0xb0, 0x98, 0xe0, 0xee,
+	0xbb, 0x94, 0xc4, 0x54, 0x5a, 0x36, 0x8b, 0x46, 0x37, 0xa0, 0xbe, 0x19, 0xd0, 0xc8, 0x27, 0x42,
+	0x90, 0x7c, 0xff, 0x56, 0x24, 0x89, 0xaa, 0x2b, 0xf9, 0xaa, 0xae, 0x9a, 0x2a, 0x5a, 0x86, 0x56,
+	0xdf, 0x9b, 0xf9, 0x41, 0x7f, 0x34, 0x22, 0x71, 0xcc, 0x65, 0x6b, 0x60, 0x1d, 0x85, 0xae, 0x43,
+	0x85, 0x6b, 0xbd, 0xbe, 0x6c, 0xad, 0x74, 0xd6, 0x2f, 0xcf, 0x6d, 0xcd, 0x06, 0x31, 0x27, 0x41,
+	0x2f, 0x43, 0x43, 0x19, 0xdd, 0x6e, 0x2c, 0x5b, 0x2b, 0x2d, 0x9d, 0x5c, 0x73, 0x07, 0x9c, 0x90,
+	0x31, 0x6e, 0x77, 0x5c, 0xba, 0x6f, 0x37, 0x05, 0xb7, 0xec, 0x3f, 0x72, 0x60, 0x41, 0x79, 0xae,
+	0xfb, 0xc9, 0x94, 0xd8, 0xc0, 0x99, 0x32, 0x70, 0x9a, 0x11, 0x5b, 0x86, 0x11, 0x5f, 0x05, 0x50,
+	0x6b, 0x0f, 0x07, 0xf6, 0x02, 0x67, 0x62, 0x69, 0x9e, 0x89, 0xe1, 0x00, 0x6b, 0x74, 0xa6, 0xe9,
+	0xdb, 0x59, 0xd3, 0x3b, 0xb0, 0xc0, 0x7e, 0x63, 0x9f, 0x86, 0xd1, 0xc9, 0x70, 0x60, 0x77, 0xb8,
+	0x0a, 0x0d, 0x1c, 0x8b, 0x29, 0xf7, 0xfd, 0xe0, 0xd1, 0x5e, 0xa8, 0xf4, 0xbc, 0x28, 0x62, 0x8a,
+	0x81, 0x64, 0x2b, 0x09, 0x84, 0x34, 0x78, 0x97, 0x13, 0x19, 0x38, 0xcd, 0xd1, 0x2e, 0xea, 0x8e,
+	0x96, 0xee, 0xd0, 0x8f, 0x46, 0xfb, 0xfe, 0x11, 0xb1, 0x91, 0xbe, 0x83, 0x44, 0xb2, 0xd9, 0xbb,
+	0xf7, 0xfa, 0xeb, 0xb7, 0x5f, 0xb3, 0x2f, 0x89, 0xd9, 0x02, 0x42, 0x2f, 0x40, 0x87, 0x0b, 0xb4,
+	0xe7, 0xcf, 0x48, 0x4c, 0xdd, 0xd9, 0x81, 0xbd, 0xc4, 0x4f, 0x51, 0x06, 0xcb, 0x8e, 0xcc, 0xee,
+	0x28, 0x8c, 0x88, 0x7d, 0x79, 0xd9, 0x5a, 0xb1, 0xb0, 0x00, 0x50, 0x0f, 0x1a, 0x77, 0xf7, 0xdd,
+	0x20, 0x20, 0xd3, 0xd8, 0xbe, 0xc2, 0xcf, 0x52, 0x02, 0xa3, 0xeb, 0x50, 0xe5, 0x6b, 0xd8, 0x57,
+	0xb9, 0xb2, 0x2f, 0xa5, 0xca, 0xe6, 0xe8, 0x61, 0x30, 0x0e, 0xb1, 0xa0, 0x70, 0x66, 0xd0, 0xdd,
+	0x25, 0x54, 0xf8, 0x95, 0x8a, 0x49, 0x2f, 0x42, 0x6d, 0xcf, 0x8d, 0x26, 0x84, 0xda, 0x56, 0x76,
+	0x7e, 0x72, 0x0e, 0xb0, 0x24, 0x61, 0xd2, 0x3d, 0x8c, 0x49, 0x34, 0x1c, 0xc8, 0xa3, 0x22, 0x21,
+	0xc6, 0xf5, 0x76, 0xe4, 0x06, 0x94, 0x47, 0xc0, 0x06, 0x16, 0x80, 0xf3, 0x4f, 0x0b, 0x5a, 0x5b,
+	0x7e, 0xa0, 0x87, 0x3f, 0x76, 0x1a, 0x1e, 0xb8, 0x74, 0xb4, 0xaf, 0x22, 0x56, 0x82, 0x40, 0xb7,
+	0xa0, 0xb6, 0xe5, 0x4f, 0x29, 0x89, 0xf8, 0xda, 0x46, 0xb4, 0x57, 0x9e, 0x22, 0xc6, 0xb1, 0xa4,
+	0x43, 0x2f, 0x41, 0xf5, 0x83, 0xc8, 0x23, 0x22, 0xee, 0x76, 0xd6, 0xaf, 0xce, 0x4f, 0xe0, 0xc3,
+	0x58, 0x50, 0x31, 0x25, 0xb2, 0xec, 0xb2, 0xeb, 0x7f, 0x2e, 0x0e, 0x67, 0x1b, 0x27, 0x30, 0x63,
+	0x2d, 0x4d, 0x47, 0x32, 0xf6, 0x24, 0x08, 0xf4, 0x2c, 0xc0, 0x03, 0xf7, 0x18, 0x93, 0xf8, 0x70,
+	0x4a, 0xc5, 0x19, 0x6d, 0x63, 0x0d, 0xe3, 0xfc, 0xc4, 0x82, 0x8e, 0xc9, 0x63, 0x61, 0x26, 0x79,
+	0x01, 0x3a, 0x1f, 0x44, 0x13, 0x37, 0xf0, 0x63, 0x97, 0xfa, 0x61, 0x90, 0x68, 0x32, 0x83, 0x65,
+	0x5b, 0x32, 0xd5, 0xec, 0x44, 0x64, 0xec, 0x1f, 0xcb, 0xc4, 0xa2, 0x61, 0xd8, 0x38, 0xb3, 0x29,
+	0xdd, 0xf5, 0x83, 0x91, 0x12, 0x47, 0xc3, 0x38, 0xbf, 0xb1, 0xa0, 0xc5, 0xd2, 0xb3, 0xd2, 0x7d,
+	0xaa, 0x5d, 0xeb, 0x49, 0xb5, 0x5b, 0x7a, 0x62, 0xed, 0x96, 0x4f, 0xd3, 0x6e, 0x25, 0xa3, 0x5d,
+	0xe7, 0x53, 0xb8, 0xb8, 0x4d, 0xa8, 0x3c, 0xa2, 0x8a, 0xdf, 0xbc, 0x84, 0x95, 0xea, 0xb4, 0x64,
+	0xe8, 0x54, 0x8b, 0xae, 0x65, 0x33, 0xba, 0xa6, 0x67, 0xb9, 0x62, 0x24, 0x0d, 0x2a, 0xe3, 0x0d,
+	0x2f, 0x60, 0x18, 0x11, 0x03, 0x44, 0xf5, 0x52, 0xc1, 0x12, 0x9a, 0x2f, 0x53, 0x4a, 0x79, 0x65,
+	0xca, 0x75, 0xa8, 0xb2, 0x23, 0xa6, 0x52, 0x43, 0xfe, 0xf1, 0xe3, 0x14, 0xce, 0x97, 0x25, 0xb9,
+	0x2d, 0x03, 0x19, 0xd7, 0x02, 0x18, 0x70, 0x21, 0x2b, 0x58, 0x81, 0x68, 0x15, 0xaa, 0xbb, 0xd4,
+	0xa5, 0x44, 0x6a, 0x7e, 0x29, 0xb3, 0x24, 0x1f, 0xc3, 0x82, 0x84, 0xe5, 0x0f, 0x01, 0x13, 0x37,
+	0x96, 0xf2, 0x37, 0xb1, 0x8e, 0x2a, 0xd2, 0x01, 0x8f, 0x29, 0xe1, 0x6c, 0xe6, 0xb3, 0x38, 0x2d,
+	0x3c, 0x3e, 0x81, 0x99, 0xe8, 0xe2, 0xff, 0x03, 0x12, 0xc7, 0xee, 0x84, 0xc8, 0x9c, 0x6b, 0x22,
+	0x99, 0x59, 0xd3, 0x70, 0x56, 0xe7, 0x36, 0x4f, 0x11, 0x49, 0x4c, 0xbf, 0x17, 0xc6, 0x22, 0x1b,
+	0x35, 0x71, 0x8a, 0xd0, 0x22, 0x49, 0x53, 0x8f, 0x24, 0xce, 0x2e, 0x5c, 0xe6, 0x44, 0x5b, 0x61,
+	0x24, 0x36, 0x53, 0x0e, 0xf1, 0xac, 0x91, 0x58, 0x84, 0xc6, 0x34, 0x8c, 0x21, 0x4e, 0xc9, 0x14,
+	0xc7, 0xf9, 0x91, 0x05, 0xdd, 0xc4, 0xde, 0x8f, 0xbb, 0x60, 0xaa, 0xb7, 0x52, 0x56, 0x6f, 0xe7,
+	0x74, 0xf4, 0x03, 0xe8, 0x0c, 0xfc, 0x48, 0xe7, 0x61, 0x49, 0xc5, 0x6e, 0xb1, 0xbd, 0x00, 0x50,
+	0x17, 0xca, 0x03, 0x3f, 0x92, 0xdb, 0xb2, 0xbf, 0x19, 0x5e, 0xcb, 0xa7, 0xf0, 0x6a, 0xfa, 0xf9,
+	0x2f, 0x79, 0x04, 0x9e, 0x92, 0xc7, 0x95, 0x39, 0xe1, 0xa7, 0xa4, 0xf3, 0xd3, 0x83, 0x06, 0x5b,
+	0x24, 0x60, 0xe7, 0x51, 0x38, 0x58, 0x02, 0x17, 0x7a, 0xd7, 0x0b, 0xd0, 0xf9, 0x90, 0x44, 0xfe,
+	0xf8, 0xe4, 0xee, 0x3e, 0x19, 0x3d, 0x8a, 0x0f, 0x67, 0xdc, 0xc7, 0x1a, 0x38, 0x83, 0x75, 0xbe,
+	0xe4, 0xa1, 0x93, 0xe7, 0xce, 0xaf, 0xc7, 0xa4, 0x54, 0x5a, 0x39, 0x55, 0x5a, 0x11, 0x6b, 0x37,
+	0xa1, 0xb6, 0x15, 0x46, 0x33, 0x97, 0xda, 0xd5, 0xf9, 0xc8, 0xc6, 0x39, 0x11, 0xc3, 0x58, 0x92,
+	0x39, 0xdf, 0x11, 0xf2, 0xf3, 0x53, 0x9b, 0x17, 0x97, 0x96, 0xa1, 0x85, 0xc9, 0xd4, 0xa5, 0xfe,
+	0x11, 0x49, 0xed, 0xa6, 0xa3, 0xb4, 0xaa, 0xa0, 0xac, 0x57, 0x05, 0xce, 0xdb, 0x50, 0x1f, 0xf8,
+	0xd1, 0xf9, 0x17, 0x76, 0xd6, 0xd3, 0xcb, 0x18, 0x5f, 0xa5, 0x03, 0xa5, 0x44, 0x67, 0xa5, 0xe1,
+	0x20, 0x59, 0xb5, 0x94, 0xae, 0xea, 0xfc, 0xd6, 0x02, 0x90, 0x7e, 0xe8, 0x07, 0x13, 0xb4, 0x02,
+	0x55, 0x26, 0x5d, 0xce, 0xdd, 0x4d, 0x09, 0x8d, 0x05, 0x01, 0xfa, 0x26, 0x54, 0x06, 0x7e, 0x14,
+	0xcb, 0xdb, 0xd8, 0xc5, 0x94, 0x50, 0xca, 0x80, 0xf9, 0x30, 0x7a, 0xc3, 0xe4, 0x89, 0x8b, 0xdc,
+	0x5a, 0xbf, 0x92, 0x53, 0x04, 0xb2, 0x39, 0x26, 0xff, 0xaa, 0x1c, 0xad, 0xa4, 0xe5, 0xa8, 0xf3,
+	0x05, 0xa0, 0xf4, 0x5e, 0x84, 0x49, 0x7c, 0x10, 0x06, 0x31, 0x51, 0x4e, 0x19, 0xb3, 0x63, 0x28,
+	0xe4, 0x4d, 0x60, 0x16, 0x5a, 0x77, 0xdc, 0x13, 0x56, 0xab, 0x72, 0xc1, 0x17, 0xb0, 0x02, 0x8b,
+	0x0c, 0xc1, 0x66, 0x7c, 0xe4, 0x46, 0x81, 0x1f, 0x4c, 0xe4, 0xd6, 0x0a, 0x74, 0xf6, 0xa0, 0xc3,
+	0xd6, 0xdd, 0x3c, 0xf6, 0x63, 0x1a, 0x73, 0x1e, 0xaf, 0x40, 0x4d, 0x40, 0x7c, 0xdf, 0x06, 0x96,
+	0x10, 0xe3, 0x9d, 0x07, 0x05, 0xe1, 0x96, 0xfc, 0x7f, 0xa1, 0xe1, 0x97, 0x98, 0x9d, 0xb2, 0xd6,
+	0x72, 0x7e, 0x61, 0xe9, 0xae, 0x3f, 0x67, 0xcc, 0xa2, 0xfc, 0xa7, 0x8c, 0x5c, 0xd6, 0x5c, 0xa7,
+	0x0b, 0xe5, 0x87, 0xf8, 0xbe, 0x14, 0x86, 0xfd, 0x65, 0x22, 0xde, 0x8d, 0x08, 0xbf, 0x10, 0x57,
+	0xc5, 0x05, 0x4e, 0x82, 0x39, 0x35, 0x49, 0x2d, 0xaf, 0x26, 0x71, 0xfe, 0x62, 0xc1, 0x02, 0xd3,
+	0x85, 0x3a, 0xbc, 0x73, 0x0c, 0x9a, 0x27, 0xb7, 0x74, 0x4a, 0x98, 0x2a, 0x1b, 0x27, 0x52, 0x4b,
+	0x85, 0x15, 0x33, 0x15, 0x2a, 0x7f, 0xa8, 0x6a, 0xd7, 0x93, 0x54, 0xa7, 0x35, 0xc3, 0x86, 0x4a,
+	0xff, 0x75, 0x4d, 0xff, 0x9a, 0xd0, 0x0d, 0x43, 0x68, 0xe7, 0x0b, 0x71, 0xa9, 0x48, 0x2e, 0x42,
+	0x59, 0x59, 0xb2, 0x97, 0x8e, 0x52, 0xce, 0xa5, 0xe3, 0xac, 0xb0, 0x6c, 0x43, 0x5d, 0xf5, 0x20,
+	0x44, 0x85, 0xa6, 0x40, 0xe7, 0xf7, 0x16, 0xd4, 0x3f, 0x22, 0x9f, 0xec, 0x87, 0xe1, 0xa3, 0xf3,
+	0x68, 0x51, 0xba, 0x41, 0xd9, 0x70, 0x83, 0x79, 0x93, 0x33, 0x4d, 0x91, 0x51, 0x44, 0xa8, 0xd4,
+	0x9f, 0x84, 0x58, 0x9a, 0x92, 0x6a, 0xd8, 0x38, 0x91, 0x4a, 0x4c, 0x11, 0xba, 0xce, 0xea, 0xa6,
+	0xce, 0xbe, 0x0d, 0x2d, 0xc9, 0x34, 0x2f, 0x9c, 0x5e, 0x82, 0x86, 0x04, 0x55, 0xf0, 0xd0, 0x62,
+	0x82, 0x1c, 0xc1, 0x09, 0x89, 0xf3, 0xc3, 0x34, 0x2e, 0x3c, 0x20, 0xd4, 0x3d, 0x97, 0xc6, 0x5f,
+	0x87, 0x96, 0xb8, 0xa6, 0x89, 0x0c, 0x50, 0xce, 0x5e, 0x72, 0xb5, 0x41, 0xac, 0x53, 0x3a, 0xbf,
+	0xb2, 0xe0, 0xb2, 0x10, 0x23, 0xbd, 0x07, 0x8b, 0x74, 0x33, 0xef, 0xfd, 0x56, 0x6e, 0x45, 0xee,
+	0xa4, 0xec, 0x6b, 0x21, 0xd5, 0xc0, 0xb1, 0x9e, 0x42, 0x72, 0x6d, 0x35, 0x6c, 0x94, 0x45, 0x33,
+	0xd3, 0x6c, 0xfb, 0x34, 0xb5, 0x97, 0x84, 0x9c, 0x1f, 0xc0, 0x95, 0x2c, 0x9b, 0x32, 0xe0, 0x69,
+	0x66, 0x11, 0x71, 0x47, 0x81, 0x68, 0x15, 0x2a, 0x4c, 0xa1, 0x76, 0xa9, 0x28, 0xd0, 0xb2, 0x51,
+	0xcc, 0x69, 0x9c, 0xf7, 0x01, 0xc4, 0xa1, 0xe3, 0x16, 0xec, 0x41, 0x43, 0x40, 0x32, 0xfc, 0x37,
+	0x71, 0x02, 0xb3, 0x1a, 0x70, 0x40, 0xc6, 0xee, 0xe1, 0x94, 0x1a, 0x65, 0x90, 0x89, 0x74, 0xee,
+	0xc1, 0xc2, 0x47, 0xec, 0xfa, 0xa6, 0xb4, 0xb9, 0x9c, 0xf6, 0x9d, 0x86, 0x03, 0x55, 0x51, 0xeb,
+	0xa8, 0xa2, 0x28, 0xe6, 0xfc, 0xd5, 0x12, 0x57, 0x1a, 0x6f, 0xf3, 0x88, 0x04, 0x67, 0x57, 0x01,
+	0x39, 0x99, 0xad, 0xf0, 0x64, 0x14, 0x55, 0x02, 0xcf, 0x02, 0x7c, 0x30, 0xf5, 0x54, 0xe8, 0x11,
+	0x9d, 0x19, 0x0d, 0xc3, 0x2f, 0x61, 0xe4, 0x33, 0x35, 0x5e, 0x13, 0xe3, 0x29, 0xe6, 0xf4, 0x02,
+	0xd8, 0xf9, 0x99, 0x65, 0x38, 0xeb, 0x29, 0x05, 0xff, 0xf3, 0xd0, 0x7e, 0x18, 0xf8, 0xc7, 0xe9,
+	0x5a, 0x25, 0xbe, 0x96, 0x89, 0x34, 0x2a, 0xdc, 0x72, 0xa6, 0x60, 0x2f, 0x92, 0x30, 0x2d, 0xb3,
+	0xab, 0x46, 0x99, 0xfd, 0x07, 0x4b, 0xf3, 0xd4, 0x5d, 0x12, 0x1d, 0x91, 0x88, 0xf1, 0xd7, 0xf7,
+	0xbc, 0x88, 0xb5, 0xa1, 0xc4, 0x09, 0x50, 0x60, 0x61, 0xe2, 0x61, 0x71, 0x24, 0x0c, 0x02, 0x32,
+	0x52, 0x5d, 0xd6, 0x06, 0x4e, 0x11, 0x6c, 0xf4, 0xbe, 0x1b, 0x53, 0xd1, 0x1a, 0x95, 0xc5, 0x70,
+	0x82, 0x60, 0xbb, 0xdd, 0x23, 0xee, 0x94, 0xee, 0x9f, 0xc8, 0xca, 0x50, 0x81, 0x3c, 0xb3, 0xbb,
+	0xfe, 0xf4, 0x30, 0x22, 0xea, 0xae, 0x9d, 0xc0, 0xce, 0xbb, 0x70, 0x29, 0xc3, 0x36, 0xf7, 0xe3,
+	0x57, 0xa0, 0x2e, 0x20, 0x15, 0x88, 0xfe, 0x2f, 0x73, 0x67, 0x4a, 0xe9, 0xb1, 0xa2, 0x74, 0x6e,
+	0x40, 0x37, 0x19, 0x53, 0x92, 0x16, 0xea, 0xc0, 0xf9, 0xb9, 0x05, 0x5d, 0xd6, 0xcc, 0xe0, 0x45,
+	0x86, 0xf2, 0x76, 0x5e, 0x68, 0x50, 0x4a, 0x22, 0x75, 0xcd, 0x57, 0x20, 0x2b, 0x52, 0x31, 0x99,
+	0x90, 0x63, 0xae, 0xb1, 0x06, 0x16, 0xc0, 0x79, 0xeb, 0xf8, 0x4c, 0x03, 0xa2, 0x3a, 0xd7, 0x80,
+	0xf8, 0x93, 0x05, 0x4d, 0xc6, 0x98, 0xe8, 0xa4, 0x3c, 0x8d, 0xa3, 0x93, 0x14, 0xe1, 0x55, 0xbd,
+	0x08, 0x57, 0xe9, 0xba, 0xa6, 0xa5, 0xeb, 0xb9, 0xce, 0x5c, 0x3d, 0xa7, 0x33, 0xc7, 0xd4, 0xdb,
+	0x4e, 0x24, 0x90, 0xd9, 0xa5, 0xce, 0x81, 0xa4, 0x32, 0xbd, 0x64, 0x56, 0xa6, 0x7c, 0x10, 0x2b,
+	0x1a, 0x7e, 0x16, 0xa3, 0xc3, 0x60, 0xc4, 0x03, 0xa4, 0x50, 0x7a, 0x8a, 0xe0, 0x86, 0x22, 0x81,
+	0xc7, 0xea, 0x3b, 0x71, 0x67, 0x53, 0x20, 0x0b, 0xeb, 0xc3, 0xc0, 0x23, 0xc7, 0x66, 0xa2, 0x36,
+	0x70, 0xce, 0x1f, 0x2d, 0xa8, 0xcb, 0x7e, 0xdb, 0x13, 0x67, 0xeb, 0xbc, 0xe2, 0xac, 0x48, 0xa9,
+	0x5a, 0x84, 0xa8, 0x9a, 0x11, 0xe2, 0x1a, 0x34, 0x25, 0x33, 0x69, 0xc6, 0x4e, 0x10, 0x7a, 0x9d,
+	0x51, 0x37, 0xeb, 0x8c, 0x7f, 0x59, 0xd0, 0x96, 0x9c, 0xb3, 0x9f, 0x09, 0x79, 0x62, 0xfe, 0xed,
+	0x44, 0x74, 0x29, 0x82, 0x02, 0xff, 0x67, 0x51, 0x35, 0x8d, 0x59, 0x75, 0xa3, 0xc9, 0x68, 0x44,
+	0xdb, 0x46, 0x36, 0xda, 0x7e, 0x01, 0x9d, 0x9d, 0x28, 0x9c, 0x85, 0xf4, 0xb1, 0xef, 0x91, 0x9a,
+	0xb6, 0x4b, 0xa6, 0xb6, 0x9f, 0x58, 0x66, 0x56, 0x19, 0x49, 0x12, 0x55, 0x19, 0x25, 0xfd, 0xda,
+	0xb9, 0xca, 0x48, 0x8e, 0xa4, 0x2d, 0x5c, 0x67, 0x0b, 0x2e, 0x1a, 0x46, 0xe2, 0x6b, 0xbc, 0x2c,
+	0x98, 0x98, 0x24, 0xfe, 0x7f, 0x75, 0x6e, 0x09, 0x31, 0x8e, 0x15, 0x9d, 0xf3, 0x37, 0x4b, 0xf6,
+	0x5c, 0x1e, 0xb8, 0xd1, 0xa3, 0xa7, 0x50, 0x9d, 0x5f, 0x81, 0xda, 0x77, 0xdd, 0xe0, 0x91, 0xbc,
+	0x51, 0x34, 0xb0, 0x84, 0x18, 0x5e, 0xf6, 0xa3, 0x64, 0x85, 0x9e, 0xb6, 0xa2, 0xce, 0x61, 0xd9,
+	0xdf, 0x95, 0xa0, 0x25, 0x03, 0xf5, 0x28, 0x8c, 0xbc, 0xa7, 0x20, 0x57, 0xf1, 0x55, 0x89, 0x3d,
+	0x20, 0xb8, 0x31, 0x55, 0x4f, 0x21, 0x32, 0x3f, 0x19, 0x38, 0x23, 0x4f, 0xd7, 0xcf, 0x6a, 0xac,
+	0x35, 0xf2, 0x1a, 0x6b, 0xcb, 0x52, 0x60, 0xa3, 0x43, 0xa6, 0xa3, 0xcc, 0xe6, 0x1a, 0x64, 0x9a,
+	0x6b, 0xce, 0x3f, 0x2c, 0x68, 0x63, 0x42, 0x49, 0xc0, 0x4a, 0x57, 0x7c, 0x38, 0x7d, 0xf2, 0x53,
+	0xdf, 0x83, 0xc6, 0x7b, 0x84, 0x1c, 0x30, 0xb9, 0x54, 0x93, 0x4b, 0xc1, 0x6a, 0x6c, 0xe0, 0x9e,
+	0xc4, 0xaa, 0x8f, 0xae, 0x60, 0xb4, 0x06, 0x88, 0xff, 0x97, 0xba, 0x20, 0x1e, 0xa7, 0x12, 0x4a,
+	0xcc, 0x19, 0x39, 0x77, 0x5c, 0xfb, 0xa9, 0x05, 0x8b, 0x3b, 0x53, 0x76, 0x08, 0xbc, 0x01, 0x99,
+	0x12, 0x6a, 0x36, 0x7b, 0xad, 0x22, 0x3b, 0x67, 0xce, 0x39, 0x4b, 0x62, 0x27, 0x94, 0x3f, 0xeb,
+	0x89, 0x24, 0xc6, 0x00, 0xdd, 0xfa, 0x95, 0xd3, 0xad, 0x5f, 0x9d, 0xb7, 0xbe, 0xf3, 0x1f, 0x5d,
+	0xf7, 0x8c, 0xc5, 0x33, 0xe3, 0xd0, 0x8b, 0x50, 0x61, 0x36, 0x92, 0xe5, 0xfb, 0x55, 0xfd, 0xfd,
+	0x45, 0x33, 0x21, 0xe6, 0x44, 0xe8, 0x75, 0x68, 0x2a, 0x81, 0x55, 0xcb, 0x59, 0xab, 0x75, 0x32,
+	0x2a, 0xc1, 0x29, 0x2d, 0xe3, 0xe2, 0x3d, 0x72, 0x40, 0x65, 0xa7, 0x5b, 0x08, 0xa6, 0x61, 0xd8,
+	0x38, 0x17, 0x7f, 0xd7, 0x3d, 0x22, 0x2a, 0xab, 0x6b, 0x18, 0x26, 0xfb, 0x5d, 0x37, 0x08, 0x42,
+	0xca, 0x97, 0x24, 0xf2, 0xa5, 0xd2, 0xc0, 0x39, 0x3f, 0xb6, 0xa0, 0x35, 0xf0, 0xc7, 0xe3, 0xaf,
+	0xdb, 0x62, 0x55, 0x1d, 0xf9, 0xbe, 0x34, 0x8c, 0x84, 0x12, 0xfc, 0x86, 0x3c, 0xb0, 0x12, 0x52,
+	0xbd, 0xbf, 0x6a, 0xd2, 0xfb, 0x73, 0xfe, 0x6d, 0x89, 0xf6, 0x10, 0xe3, 0x26, 0xa9, 0x4a, 0x2c,
+	0xad, 0x2a, 0xb9, 0x01, 0x35, 0x11, 0x35, 0xe7, 0x9b, 0xec, 0xa2, 0xc5, 0xc1, 0xc6, 0xb0, 0xa4,
+	0xe1, 0xaf, 0x72, 0xfe, 0xe7, 0x44, 0xf1, 0x23, 0x00, 0x85, 0x55, 0xdc, 0x08, 0x80, 0xb9, 0x34,
+	0xfb, 0x33, 0x20, 0x53, 0xea, 0x72, 0x96, 0xca, 0x38, 0x45, 0x30, 0xe7, 0x12, 0xed, 0x8a, 0xbe,
+	0x74, 0x77, 0x05, 0xa6, 0x23, 0x1b, 0x32, 0x6a, 0x28, 0x90, 0x85, 0x83, 0x87, 0x81, 0x3f, 0xf6,
+	0x89, 0xc7, 0xc4, 0x91, 0x21, 0x43, 0x47, 0x39, 0x7f, 0x57, 0x81, 0x9f, 0xcb, 0xfb, 0xb4, 0xd4,
+	0x9e, 0x74, 0x0e, 0xab, 0x79, 0x9d, 0x43, 0xee, 0x08, 0x82, 0xc0, 0xd4, 0x49, 0x2d, 0xab, 0x13,
+	0x16, 0x04, 0x82, 0x11, 0xd7, 0xb4, 0x3a, 0xe8, 0x29, 0x82, 0xbd, 0x64, 0x75, 0x59, 0x3e, 0x93,
+	0x29, 0xe0, 0xeb, 0x79, 0x96, 0x16, 0x0b, 0xca, 0x05, 0x8f, 0x2e, 0x95, 0xb3, 0x1f, 0x5d, 0xd2,
+	0xfc, 0x56, 0xd5, 0xf3, 0xdb, 0xea, 0xab, 0xd0, 0xd2, 0x1e, 0xe5, 0x51, 0x1b, 0x9a, 0x03, 0x3f,
+	0x22, 0x23, 0xd6, 0xbb, 0xe8, 0x5e, 0x40, 0x0d, 0xa8, 0x30, 0x6d, 0x74, 0x2d, 0xb4, 0x90, 0xbe,
+	0xd3, 0x77, 0x4b, 0xab, 0x6b, 0xd0, 0x36, 0x5e, 0xd4, 0x10, 0x40, 0x6d, 0xe3, 0x84, 0x15, 0x8a,
+	0xdd, 0x0b, 0xe8, 0x22, 0xb4, 0x37, 0x4e, 0xb4, 0x5b, 0x64, 0xd7, 0x5a, 0x7d, 0x5d, 0xde, 0x93,
+	0x05, 0x2f, 0x6d, 0x68, 0xf6, 0x8f, 0x5c, 0x7f, 0xca, 0x5e, 0xe5, 0xbb, 0x17, 0x50, 0x07, 0x60,
+	0x40, 0x0e, 0x22, 0xc2, 0xcb, 0xde, 0xae, 0xc5, 0xd6, 0x12, 0x49, 0xb9, 0x5b, 0x5a, 0x7d, 0x0e,
+	0xda, 0x46, 0x83, 0x1b, 0xd5, 0xa1, 0xfc, 0xf1, 0x70, 0xa7, 0x7b, 0x01, 0x35, 0xa1, 0xba, 0xd7,
+	0xc7, 0xdb, 0x1f, 0x77, 0xad, 0xd5, 0x3b, 0xe2, 0x2b, 0x14, 0xe9, 0xf8, 0x6d, 0x71, 0xaf, 0xe8,
+	0x7b, 0x1e, 0xf1, 0xba, 0x17, 0xd0, 0xa2, 0x7a, 0x4e, 0x98, 0x85, 0x47, 0x7c, 0xf9, 0xae, 0xe8,
+	0x08, 0x3e, 0x08, 0x3d, 0xee, 0x8c, 0xdd, 0xd2, 0xfa, 0xaf, 0x11, 0x2c, 0x2a, 0x71, 0xd8, 0x4d,
+	0xcb, 0x1f, 0x11, 0x74, 0x03, 0x2a, 0xbc, 0xa4, 0x59, 0x58, 0x93, 0x9f, 0x1f, 0x7d, 0x18, 0xfa,
+	0x5e, 0x2f, 0xa7, 0x51, 0xc1, 0xa9, 0x5e, 0x83, 0xd6, 0x36, 0xa1, 0xc9, 0x37, 0x1d, 0x79, 0x0f,
+	0xd2, 0xbd, 0x9c, 0x2f, 0x30, 0xd0, 0x26, 0x80, 0xe8, 0x11, 0xdf, 0xdb, 0xdb, 0xdb, 0x41, 0x57,
+	0xd7, 0x92, 0x0f, 0x97, 0x54, 0xe7, 0x98, 0xbb, 0x4e, 0xef, 0x5a, 0x76, 0x60, 0xe0, 0x52, 0x57,
+	0x75, 0x59, 0x6e, 0x59, 0xe8, 0x0e, 0xd4, 0xb7, 0x09, 0x7b, 0x0c, 0x25, 0xf9, 0x5b, 0x9f, 0x35,
+	0xff, 0x36, 0x34, 0x93, 0x47, 0x76, 0xd4, 0x4b, 0x57, 0xc8, 0xbe, 0xbc, 0xf7, 0x0c, 0x6d, 0xa0,
+	0xdb, 0xcc, 0x3b, 0x02, 0x0f, 0x5d, 0xd6, 0x4f, 0x51, 0xf2, 0x76, 0x5e, 0xa8, 0xac, 0x3e, 0x74,
+	0xb6, 0x09, 0x65, 0x57, 0x58, 0xf5, 0xe6, 0xf9, 0x4c, 0x4a, 0x39, 0xf7, 0xac, 0x9a, 0xab, 0xb7,
+	0x3b, 0xfc, 0xfd, 0x55, 0xad, 0x2a, 0xd3, 0x41, 0xee, 0x37, 0x1b, 0xbd, 0xec, 0xeb, 0x26, 0x67,
+	0xe1, 0x6d, 0x68, 0x6f, 0x13, 0xaa, 0xbd, 0x28, 0xd8, 0xc6, 0xcb, 0x80, 0xf6, 0xde, 0xd5, 0x5b,
+	0x9a, 0x1b, 0x61, 0xf4, 0x5b, 0xd0, 0x96, 0x1a, 0x17, 0x0a, 0x35, 0x75, 0x90, 0xbc, 0x5e, 0xf5,
+	0xae, 0x99, 0x68, 0xf3, 0x41, 0xe0, 0x96, 0x85, 0xde, 0x81, 0xf6, 0x20, 0x24, 0x71, 0xd2, 0xae,
+	0x2f, 0x5a, 0xc7, 0x36, 0xd1, 0x5a, 0x6b, 0xff, 0x16, 0x20, 0xa9, 0xcd, 0xad, 0x30, 0x52, 0x72,
+	0xa3, 0x85, 0x94, 0x7e, 0x38, 0xe8, 0x19, 0x90, 0x9c, 0xa1, 0x48, 0xb7, 0xc2, 0x88, 0x4d, 0x3e,
+	0x75, 0xc6, 0x6d, 0x58, 0xd4, 0xd5, 0xcd, 0xba, 0x9a, 0x26, 0x79, 0xae, 0xea, 0xd1, 0x1b, 0xb0,
+	0xa4, 0x4d, 0x1b, 0x0e, 0xf2, 0xb7, 0xca, 0x9f, 0x7b, 0x0b, 0x1a, 0xac, 0xf9, 0x97, 0xb3, 0x57,
+	0x41, 0xb3, 0x10, 0x7d, 0x1f, 0x6c, 0xb3, 0x0d, 0x39, 0xe4, 0x49, 0xdd, 0x8f, 0x88, 0x87, 0xbe,
+	0xa1, 0xf9, 0x50, 0x5e, 0x47, 0xb5, 0xb7, 0x5c, 0x4c, 0x20, 0x7b, 0x99, 0x77, 0xe0, 0xaa, 0x16,
+	0xd3, 0xb6, 0xc2, 0x68, 0x3b, 0xdc, 0x74, 0xe3, 0x93, 0xf0, 0x20, 0xce, 0xc4, 0x88, 0xfc, 0xd6,
+	0x2e, 0x1a, 0x2a, 0x97, 0x53, 0x9f, 0xdd, 0xd8, 0x73, 0x6f, 0x78, 0x8f, 0xef, 0x34, 0x1d, 0xde,
+	0xc0, 0x4c, 0x3f, 0x4f, 0xd4, 0x74, 0xa2, 0xb7, 0x36, 0x7b, 0xd9, 0x94, 0xc0, 0xfb, 0x94, 0xb7,
+	0x2c, 0xb4, 0x0e, 0xd0, 0xf7, 0x3c, 0xd5, 0xcd, 0x9f, 0x6f, 0x81, 0xf7, 0xe6, 0x51, 0xe8, 0x15,
+	0xf6, 0x21, 0x52, 0x4c, 0x25, 0x78, 0x8a, 0xd4, 0x7a, 0xbf, 0xfd, 0x45, 0x68, 0x8b, 0x42, 0x4b,
+	0xad, 0x92, 0x75, 0x33, 0x3d, 0x9e, 0xac, 0x8b, 0x1d, 0x92, 0x76, 0x6e, 0xa1, 0x9f, 0x68, 0xed,
+	0xe0, 0x3b, 0xac, 0x55, 0x13, 0xd3, 0x54, 0x15, 0xba, 0xfa, 0xb5, 0x63, 0x5c, 0x14, 0x8c, 0xde,
+	0x02, 0xe0, 0x7b, 0x8a, 0x10, 0xd2, 0xcb, 0x09, 0x16, 0x6a, 0x85, 0xdc, 0x40, 0xb2, 0x09, 0x0b,
+	0x7d, 0xcf, 0x4b, 0xbf, 0xfb, 0xea, 0xe5, 0x34, 0xf1, 0x64, 0x3b, 0xae, 0x57, 0xdc, 0xe0, 0x43,
+	0x6f, 0xc2, 0xa2, 0x48, 0x50, 0x8f, 0xb7, 0x92, 0xa9, 0xb6, 0xb7, 0xa0, 0x93, 0x88, 0xc0, 0xa8,
+	0xb2, 0xa6, 0xf9, 0xff, 0xc2, 0x7d, 0xb9, 0x08, 0xef, 0x40, 0x33, 0x69, 0x12, 0xea, 0xbb, 0x66,
+	0x3b, 0x87, 0xbd, 0xab, 0x39, 0xcd, 0x2c, 0xbe, 0xc2, 0x9b, 0xb0, 0x20, 0xfb, 0x18, 0xb2, 0x6b,
+	0xac, 0x55, 0xf7, 0x46, 0x7f, 0xa3, 0x37, 0xdf, 0x52, 0x50, 0x6e, 0x25, 0xc1, 0xac, 0xd1, 0x2f,
+	0xcf, 0x4d, 0x90, 0x3b, 0x76, 0x24, 0x78, 0xcf, 0x8f, 0x59, 0xad, 0x92, 0x99, 0xf6, 0x4c, 0x41,
+	0xdf, 0x81, 0x4f, 0xbe, 0x0d, 0xcd, 0xa4, 0x38, 0xd3, 0x05, 0xce, 0x56, 0x6c, 0x19, 0x35, 0x0f,
+	0xf8, 0x97, 0x68, 0xe6, 0x1d, 0xb5, 0xe8, 0xe6, 0xd3, 0x2b, 0x1a, 0x40, 0xaf, 0x41, 0x9b, 0xdd,
+	0x78, 0x12, 0x64, 0x86, 0xf1, 0xbc, 0x79, 0x8c, 0x1e, 0x7d, 0x8b, 0x3d, 0x80, 0x8f, 0xc7, 0xd2,
+	0x4f, 0x2f, 0xeb, 0x49, 0x69, 0x3c, 0x2e, 0x72, 0x51, 0x36, 0x86, 0x86, 0x3c, 0x57, 0x9a, 0x5f,
+	0xa8, 0xe8, 0x01, 0x31, 0xf7, 0xdb, 0x95, 0x5e, 0xde, 0x47, 0x41, 0x1b, 0xef, 0xc2, 0x73, 0x01,
+	0xa1, 0xfa, 0xb7, 0xd7, 0xf2, 0x6b, 0x6c, 0xf6, 0xf9, 0x75, 0x32, 0xe1, 0xe3, 0xe7, 0x1e, 0xe3,
+	0x8b, 0xf0, 0x4f, 0x6a, 0xfc, 0xdb, 0xec, 0x57, 0xfe, 0x3b, 0x00, 0x27, 0xd6, 0xfa, 0xb9, 0x3f,
+	0x2e, 0x00, 0x00,
 }
@@ -0,0 +1,25 @@
+package buildrepo
+
+import (
+	"context"
+	br "golang.conradwood.net/apis/buildrepo"
+	"io"
+)
+
+// a Backend serves the builds of one domain. This is usually a buildrepo server,
+// but may also be a local directory (for development or tests)
+type Backend interface {
+	ListRepos(ctx context.Context) (*br.ListReposResponse, error)
+	ListFiles(ctx context.Context, req *br.ListFilesRequest) (*br.ListFilesResponse, error)
+	GetFile(ctx context.Context, req *br.GetFileRequest, target io.Writer) error
+	GetLatestVersion(ctx context.Context, req *br.GetLatestVersionRequest) (*br.GetLatestVersionResponse, error)
+	ListVersions(ctx context.Context, req *br.ListVersionsRequest) (*br.ListVersionsResponse, error)
+	ListBranches(ctx context.Context, req *br.ListBranchesRequest) (*br.ListBranchesResponse, error)
+	GetRepositoryMeta(ctx context.Context, req *br.GetRepoMetaRequest) (*br.RepoMetaInfo, error)
+	DoesFileExist(ctx context.Context, req *br.GetFileRequest) (*br.FileExistsInfo, error)
+	GetFileMetaData(ctx context.Context, req *br.GetMetaRequest) (*br.GetMetaResponse, error)
+	// remove a build (and all its files)
+	DeleteBuild(ctx context.Context, repository string, branch string, build uint64) error
+	// false if DeleteBuild is not supported
+	CanDelete() bool
+}
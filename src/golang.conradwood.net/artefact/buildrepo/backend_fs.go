@@ -0,0 +1,313 @@
+package buildrepo
+
+import (
+	"context"
+	"fmt"
+	br "golang.conradwood.net/apis/buildrepo"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// optional file in a repository directory, containing the repositoryid
+	FS_REPOSITORYID_FILE = "repositoryid"
+)
+
+// a backend serving builds from a local directory. layout:
+// <root>/<repository>/<branch>/<build>/<files...>
+// builds are numeric directory names, the highest one is "latest".
+type fsBackend struct {
+	root   string
+	domain string
+}
+
+func newFSBackend(root, domain string) (*fsBackend, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	st, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !st.IsDir() {
+		return nil, fmt.Errorf("\"%s\" is not a directory", root)
+	}
+	return &fsBackend{root: root, domain: domain}, nil
+}
+
+// the path of a directory below root. each element (repository, branch, build...) is a single directory name,
+// elements which are empty, contain a path separator or ".." are rejected
+func (f *fsBackend) path(elements ...string) (string, error) {
+	for _, e := range elements {
+		if e == "" || strings.ContainsAny(e, "/\\") || strings.Contains(e, "..") {
+			return "", fmt.Errorf("invalid path element \"%s\"", e)
+		}
+	}
+	return filepath.Join(append([]string{f.root}, elements...)...), nil
+}
+func (f *fsBackend) buildPath(file *br.File) (string, error) {
+	return f.inBuild(file.Repository, file.Branch, file.BuildID, file.Filename)
+}
+
+// the path of a build directory
+func (f *fsBackend) buildDir(repo, branch string, build uint64) (string, error) {
+	return f.path(repo, branch, fmt.Sprintf("%d", build))
+}
+
+// the path of a file or directory in a build. it cannot escape the build (e.g. into another repository)
+func (f *fsBackend) inBuild(repo, branch string, build uint64, rel string) (string, error) {
+	dir, err := f.buildDir(repo, branch, build)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, filepath.Clean("/"+rel)), nil
+}
+
+// names of the subdirectories of a directory
+func (f *fsBackend) subdirs(dir string) ([]string, error) {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, de := range des {
+		if de.IsDir() {
+			res = append(res, de.Name())
+		}
+	}
+	return res, nil
+}
+
+// numeric builds of a branch, sorted ascending
+func (f *fsBackend) builds(repo, branch string) ([]uint64, error) {
+	dir, err := f.path(repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := f.subdirs(dir)
+	if err != nil {
+		return nil, err
+	}
+	var res []uint64
+	for _, d := range dirs {
+		b, err := strconv.ParseUint(d, 10, 64)
+		if err != nil {
+			continue
+		}
+		res = append(res, b)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+	return res, nil
+}
+
+func (f *fsBackend) ListRepos(ctx context.Context) (*br.ListReposResponse, error) {
+	dirs, err := f.subdirs(f.root)
+	if err != nil {
+		return nil, err
+	}
+	res := &br.ListReposResponse{}
+	for _, d := range dirs {
+		res.Entries = append(res.Entries, &br.RepoEntry{Name: d, Type: 2, Domain: f.domain})
+	}
+	return res, nil
+}
+
+func (f *fsBackend) ListFiles(ctx context.Context, req *br.ListFilesRequest) (*br.ListFilesResponse, error) {
+	buildroot, err := f.buildDir(req.Repository, req.Branch, req.BuildID)
+	if err != nil {
+		return nil, err
+	}
+	start, err := f.inBuild(req.Repository, req.Branch, req.BuildID, req.Dir)
+	if err != nil {
+		return nil, err
+	}
+	res := &br.ListFilesResponse{}
+	err = filepath.WalkDir(start, func(path string, de os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == start {
+			return nil
+		}
+		rel, err := filepath.Rel(buildroot, path)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Dir(rel)
+		if dir == "." {
+			dir = ""
+		}
+		entry := &br.RepoEntry{Name: de.Name(), Type: 1, Dir: dir, Domain: f.domain}
+		if de.IsDir() {
+			entry.Type = 2
+		}
+		res.Entries = append(res.Entries, entry)
+		if de.IsDir() && !req.Recursive {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// write a file to a writer
+func (f *fsBackend) GetFile(ctx context.Context, req *br.GetFileRequest, target io.Writer) error {
+	fname, err := f.buildPath(req.File)
+	if err != nil {
+		return err
+	}
+	fd, err := os.Open(fname)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	bs := int(req.Blocksize)
+	if bs == 0 {
+		bs = 8192
+	}
+	buf := make([]byte, bs)
+	for {
+		n, err := fd.Read(buf)
+		if n > 0 {
+			_, werr := target.Write(buf[:n])
+			if werr != nil {
+				return werr
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fsBackend) GetLatestVersion(ctx context.Context, req *br.GetLatestVersionRequest) (*br.GetLatestVersionResponse, error) {
+	builds, err := f.builds(req.Repository, req.Branch)
+	if err != nil {
+		return nil, err
+	}
+	if len(builds) == 0 {
+		return nil, fmt.Errorf("no builds for %s in branch %s", req.Repository, req.Branch)
+	}
+	latest := builds[len(builds)-1]
+	bm := &br.BuildMeta{
+		BuildID:    latest,
+		Branch:     req.Branch,
+		Repository: req.Repository,
+	}
+	dir, err := f.buildDir(req.Repository, req.Branch, latest)
+	if err != nil {
+		return nil, err
+	}
+	st, err := os.Stat(dir)
+	if err == nil {
+		bm.Timestamp = uint32(st.ModTime().Unix())
+	}
+	rmi, err := f.GetRepositoryMeta(ctx, &br.GetRepoMetaRequest{Path: req.Repository})
+	if err == nil {
+		bm.RepositoryID = rmi.RepositoryID
+	}
+	return &br.GetLatestVersionResponse{BuildID: latest, BuildMeta: bm}, nil
+}
+
+func (f *fsBackend) ListVersions(ctx context.Context, req *br.ListVersionsRequest) (*br.ListVersionsResponse, error) {
+	builds, err := f.builds(req.Repository, req.Branch)
+	if err != nil {
+		return nil, err
+	}
+	res := &br.ListVersionsResponse{}
+	for _, b := range builds {
+		res.Entries = append(res.Entries, &br.RepoEntry{Name: fmt.Sprintf("%d", b), Type: 2, Domain: f.domain})
+	}
+	return res, nil
+}
+
+func (f *fsBackend) ListBranches(ctx context.Context, req *br.ListBranchesRequest) (*br.ListBranchesResponse, error) {
+	dir, err := f.path(req.Repository)
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := f.subdirs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &br.ListBranchesResponse{Branches: dirs}, nil
+}
+
+// the repositoryid is read from <root>/<repository>/repositoryid, if it exists
+func (f *fsBackend) GetRepositoryMeta(ctx context.Context, req *br.GetRepoMetaRequest) (*br.RepoMetaInfo, error) {
+	fname, err := f.path(req.Path, FS_REPOSITORYID_FILE)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repositoryid for %s: %s", req.Path, err)
+	}
+	return &br.RepoMetaInfo{RepositoryID: id}, nil
+}
+
+func (f *fsBackend) DoesFileExist(ctx context.Context, req *br.GetFileRequest) (*br.FileExistsInfo, error) {
+	fname, err := f.buildPath(req.File)
+	if err != nil {
+		return nil, err
+	}
+	st, err := os.Stat(fname)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &br.FileExistsInfo{Exists: false}, nil
+		}
+		return nil, err
+	}
+	return &br.FileExistsInfo{Exists: !st.IsDir(), Size: uint64(st.Size())}, nil
+}
+
+func (f *fsBackend) GetFileMetaData(ctx context.Context, req *br.GetMetaRequest) (*br.GetMetaResponse, error) {
+	fname, err := f.buildPath(req.File)
+	if err != nil {
+		return nil, err
+	}
+	st, err := os.Stat(fname)
+	if err != nil {
+		return nil, err
+	}
+	if st.IsDir() {
+		return nil, fmt.Errorf("\"%s\" is a directory", req.File.Filename)
+	}
+	return &br.GetMetaResponse{Size: uint64(st.Size())}, nil
+}
+
+func (f *fsBackend) CanDelete() bool {
+	return true
+}
+func (f *fsBackend) DeleteBuild(ctx context.Context, repository string, branch string, build uint64) error {
+	dir, err := f.buildDir(repository, branch, build)
+	if err != nil {
+		return err
+	}
+	st, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !st.IsDir() {
+		return fmt.Errorf("\"%s\" is not a build", dir)
+	}
+	return os.RemoveAll(dir)
+}
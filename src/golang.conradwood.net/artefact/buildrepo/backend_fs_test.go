@@ -0,0 +1,173 @@
+package buildrepo
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	br "golang.conradwood.net/apis/buildrepo"
+)
+
+// a directory with two builds of repository "foo" and one of repository "secret"
+func testFSBackend(t *testing.T) *fsBackend {
+	root := t.TempDir()
+	files := map[string]string{
+		"foo/repositoryid":           "42\n",
+		"foo/main/1/a.txt":           "build 1",
+		"foo/main/2/a.txt":           "build 2",
+		"foo/main/2/dist/b.bin":      "binary",
+		"foo/main/2/dist/sub/c.yaml": "c: 1",
+		"secret/main/1/key":          "do not serve",
+	}
+	for name, content := range files {
+		fname := filepath.Join(root, name)
+		err := os.MkdirAll(filepath.Dir(fname), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(fname, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := newFSBackend(root, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// "dir/name" of each entry, sorted
+func entryNames(entries []*br.RepoEntry) []string {
+	var res []string
+	for _, e := range entries {
+		res = append(res, filepath.Join(e.Dir, e.Name))
+	}
+	sort.Strings(res)
+	return res
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFSListFiles(t *testing.T) {
+	f := testFSBackend(t)
+	ctx := context.Background()
+	lfr, err := f.ListFiles(ctx, &br.ListFilesRequest{Repository: "foo", Branch: "main", BuildID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := entryNames(lfr.Entries); !sameStrings(names, []string{"a.txt", "dist"}) {
+		t.Errorf("non-recursive listing: %v", names)
+	}
+	lfr, err = f.ListFiles(ctx, &br.ListFilesRequest{Repository: "foo", Branch: "main", BuildID: 2, Dir: "dist", Recursive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := entryNames(lfr.Entries); !sameStrings(names, []string{"dist/b.bin", "dist/sub", "dist/sub/c.yaml"}) {
+		t.Errorf("recursive listing: %v", names)
+	}
+	for _, e := range lfr.Entries {
+		if e.Name == "sub" && e.Type != 2 {
+			t.Errorf("directory with type %d", e.Type)
+		}
+		if e.Name == "b.bin" && e.Type != 1 {
+			t.Errorf("file with type %d", e.Type)
+		}
+	}
+}
+
+func TestFSGetFile(t *testing.T) {
+	f := testFSBackend(t)
+	buf := &bytes.Buffer{}
+	err := f.GetFile(context.Background(), &br.GetFileRequest{File: &br.File{Repository: "foo", Branch: "main", BuildID: 1, Filename: "a.txt"}, Blocksize: 3}, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "build 1" {
+		t.Errorf("got \"%s\"", buf.String())
+	}
+}
+
+func TestFSVersions(t *testing.T) {
+	f := testFSBackend(t)
+	ctx := context.Background()
+	lvr, err := f.ListVersions(ctx, &br.ListVersionsRequest{Repository: "foo", Branch: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := entryNames(lvr.Entries); !sameStrings(names, []string{"1", "2"}) {
+		t.Errorf("versions: %v", names)
+	}
+	glv, err := f.GetLatestVersion(ctx, &br.GetLatestVersionRequest{Repository: "foo", Branch: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if glv.BuildID != 2 || glv.BuildMeta.RepositoryID != 42 {
+		t.Errorf("latest version %d (repositoryid %d), expected 2 (42)", glv.BuildID, glv.BuildMeta.RepositoryID)
+	}
+}
+
+// files outside the build (in other repositories or outside the root) must not be served
+func TestFSTraversal(t *testing.T) {
+	f := testFSBackend(t)
+	ctx := context.Background()
+	for _, fname := range []string{"../../../secret/main/1/key", "/../../../secret/main/1/key", "dist/../../../../secret/main/1/key", "../../../../../../../../etc/passwd"} {
+		file := &br.File{Repository: "foo", Branch: "main", BuildID: 2, Filename: fname}
+		buf := &bytes.Buffer{}
+		err := f.GetFile(ctx, &br.GetFileRequest{File: file}, buf)
+		if err == nil {
+			t.Errorf("GetFile(\"%s\") served \"%s\"", fname, buf.String())
+		}
+		fei, err := f.DoesFileExist(ctx, &br.GetFileRequest{File: file})
+		if err != nil || fei.Exists {
+			t.Errorf("DoesFileExist(\"%s\"): %v, %v", fname, fei, err)
+		}
+	}
+	lfr, err := f.ListFiles(ctx, &br.ListFilesRequest{Repository: "foo", Branch: "main", BuildID: 2, Dir: "../../../secret", Recursive: true})
+	if err == nil {
+		for _, e := range lfr.Entries {
+			if e.Name == "key" {
+				t.Errorf("ListFiles escaped the build: %s/%s", e.Dir, e.Name)
+			}
+		}
+	}
+	// neither repository nor branch may escape the repository or root
+	for _, file := range []*br.File{
+		&br.File{Repository: "../..", Branch: "x", BuildID: 1, Filename: "key"},
+		&br.File{Repository: "foo", Branch: "../secret/main", BuildID: 1, Filename: "key"},
+		&br.File{Repository: "foo", Branch: "..", BuildID: 1, Filename: "secret/main/1/key"},
+		&br.File{Repository: "foo/../secret", Branch: "main", BuildID: 1, Filename: "key"},
+		&br.File{Repository: "foo", Branch: "", BuildID: 1, Filename: "main/1/a.txt"},
+	} {
+		buf := &bytes.Buffer{}
+		err = f.GetFile(ctx, &br.GetFileRequest{File: file}, buf)
+		if err == nil {
+			t.Errorf("GetFile(%s/%s) served \"%s\"", file.Repository, file.Branch, buf.String())
+		}
+		fei, err := f.DoesFileExist(ctx, &br.GetFileRequest{File: file})
+		if err == nil && fei.Exists {
+			t.Errorf("DoesFileExist(%s/%s) found a file", file.Repository, file.Branch)
+		}
+	}
+	lfr, err = f.ListFiles(ctx, &br.ListFilesRequest{Repository: "foo", Branch: "../secret/main", BuildID: 1})
+	if err == nil {
+		t.Errorf("ListFiles with a traversing branch listed %d entries", len(lfr.Entries))
+	}
+	_, err = f.ListVersions(ctx, &br.ListVersionsRequest{Repository: "foo", Branch: "../secret/main"})
+	if err == nil {
+		t.Errorf("ListVersions with a traversing branch succeeded")
+	}
+}
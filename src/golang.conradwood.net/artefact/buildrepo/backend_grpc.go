@@ -0,0 +1,71 @@
+package buildrepo
+
+import (
+	"context"
+	br "golang.conradwood.net/apis/buildrepo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+)
+
+// a backend talking to a buildrepo server
+type grpcBackend struct {
+	client br.BuildRepoManagerClient
+	conn   *grpc.ClientConn // closed when the buildrepo is removed
+}
+
+func (g *grpcBackend) ListRepos(ctx context.Context) (*br.ListReposResponse, error) {
+	return g.client.ListRepos(ctx, &br.ListReposRequest{})
+}
+func (g *grpcBackend) ListFiles(ctx context.Context, req *br.ListFilesRequest) (*br.ListFilesResponse, error) {
+	return g.client.ListFiles(ctx, req)
+}
+
+// write a file to a writer
+func (g *grpcBackend) GetFile(ctx context.Context, req *br.GetFileRequest, target io.Writer) error {
+	stream, err := g.client.GetFileAsStream(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		block, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		_, err = target.Write(block.Data[:block.Size])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (g *grpcBackend) GetLatestVersion(ctx context.Context, req *br.GetLatestVersionRequest) (*br.GetLatestVersionResponse, error) {
+	return g.client.GetLatestVersion(ctx, req)
+}
+func (g *grpcBackend) ListVersions(ctx context.Context, req *br.ListVersionsRequest) (*br.ListVersionsResponse, error) {
+	return g.client.ListVersions(ctx, req)
+}
+func (g *grpcBackend) ListBranches(ctx context.Context, req *br.ListBranchesRequest) (*br.ListBranchesResponse, error) {
+	return g.client.ListBranches(ctx, req)
+}
+func (g *grpcBackend) GetRepositoryMeta(ctx context.Context, req *br.GetRepoMetaRequest) (*br.RepoMetaInfo, error) {
+	return g.client.GetRepositoryMeta(ctx, req)
+}
+func (g *grpcBackend) DoesFileExist(ctx context.Context, req *br.GetFileRequest) (*br.FileExistsInfo, error) {
+	return g.client.DoesFileExist(ctx, req)
+}
+func (g *grpcBackend) GetFileMetaData(ctx context.Context, req *br.GetMetaRequest) (*br.GetMetaResponse, error) {
+	return g.client.GetFileMetaData(ctx, req)
+}
+
+// the BuildRepoManager api has no call to delete builds
+func (g *grpcBackend) CanDelete() bool {
+	return false
+}
+func (g *grpcBackend) DeleteBuild(ctx context.Context, repository string, branch string, build uint64) error {
+	return status.Errorf(codes.Unimplemented, "buildrepo server cannot delete build %d of %s (branch %s)", build, repository, branch)
+}
@@ -5,9 +5,8 @@ import (
 	"flag"
 	"fmt"
 	br "golang.conradwood.net/apis/buildrepo"
-	"golang.conradwood.net/apis/common"
-	"golang.conradwood.net/go-easyops/authremote"
-	"golang.conradwood.net/go-easyops/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
 	"strings"
 	"sync"
@@ -17,22 +16,21 @@ import (
 * consolidate multiple repositories
  */
 var (
-	user_buildrepos    = flag.String("buildrepos", "", "if set, a comma delimited mapping with buildrepos addresses")
+	user_buildrepos    = flag.String("buildrepos", "", "if set, a comma delimited mapping with buildrepos addresses. several buildrepos may serve the same domain (replicas)")
+	filesystem_repos   = flag.String("filesystem_repos", "", "if set, a comma delimited list of domain=directory. builds are served from the directory (layout: <directory>/<repository>/<branch>/<build>/...)")
 	default_buildrepos = []string{"buildrepo.vpn.conrad.localdomain", "scbuildrepo.singingcat.localdomain"}
 
-	clients = make(map[string]br.BuildRepoManagerClient)
+	clients = make(map[string]Backend)
 	debug   = flag.Bool("debug_repos", false, "debug repo code")
 	br_meta = make(map[string]*build_repo_meta)
 )
 
-type build_repo_meta struct {
-	Address string
-	Domain  string
-}
-
 func get_list_of_buildrepos() []string {
 	var res []string
 	if *user_buildrepos == "" {
+		if *filesystem_repos != "" {
+			return nil
+		}
 		return default_buildrepos
 	}
 	for _, brepoadr := range strings.Split(*user_buildrepos, ",") {
@@ -41,33 +39,42 @@ func get_list_of_buildrepos() []string {
 	}
 	return res
 }
-func get_build_repo_map() map[string]*build_repo_meta {
-	return br_meta
+
+// returns domain->directory
+func get_list_of_filesystem_repos() map[string]string {
+	res := make(map[string]string)
+	if *filesystem_repos == "" {
+		return res
+	}
+	for _, fsr := range strings.Split(*filesystem_repos, ",") {
+		fsr = strings.Trim(fsr, " ")
+		idx := strings.Index(fsr, "=")
+		if idx < 1 {
+			panic(fmt.Sprintf("invalid filesystem repo \"%s\" (expected domain=directory)", fsr))
+		}
+		res[fsr[:idx]] = fsr[idx+1:]
+	}
+	return res
 }
 func CreateBuildrepo() *BuildRepo {
 	m := get_list_of_buildrepos()
-	debugf("Creating buildrepo clients for %d repos\n", len(m))
-	if len(m) == 0 {
+	fsm := get_list_of_filesystem_repos()
+	debugf("Creating buildrepo clients for %d repos and %d directories\n", len(m), len(fsm))
+	if len(m) == 0 && len(fsm) == 0 {
 		panic("need at least one buildrepo")
 	}
-	for _, v := range m {
-		adr := fmt.Sprintf("%s:5005", v)
-		fmt.Printf("Connecting to buildrepo at: %s\n", adr)
-		c, err := client.ConnectWithIP(adr)
+	for domain, dir := range fsm {
+		fb, err := newFSBackend(dir, domain)
 		if err != nil {
-			panic(fmt.Sprintf("Failed to connect to buildrepo @ %s: %s", v, err))
+			panic(fmt.Sprintf("Failed to use directory %s as buildrepo: %s", dir, err))
 		}
-		brm := br.NewBuildRepoManagerClient(c)
-		clients[v] = brm
-		ctx := authremote.Context()
-		mi, err := brm.GetManagerInfo(ctx, &common.Void{})
+		registerFSBackend(fb)
+	}
+	for _, v := range m {
+		_, err := AddBuildRepo(v)
 		if err != nil {
-			fmt.Printf("failed to get manager info from buildrepo %s: %s\n", adr, err)
-		} else {
-			fmt.Printf("buildrepo at %s serves domain %s\n", v, mi.Domain)
-			br_meta[v] = &build_repo_meta{Address: v, Domain: mi.Domain}
+			panic(fmt.Sprintf("Failed to add buildrepo %s: %s", v, err))
 		}
-		debugf("Connected to %s\n", adr)
 	}
 	res := &BuildRepo{}
 	return res
@@ -76,49 +83,71 @@ func CreateBuildrepo() *BuildRepo {
 type BuildRepo struct {
 }
 type RepoList struct {
-	Entries []*RepoEntry
+	Entries  []*RepoEntry
+	Degraded []*DegradedSource // domains which could not be listed and buildrepos which are not connected
+}
+type DegradedSource struct {
+	Domain string // "" (empty) for buildrepos which never connected
+	Server string // the last replica tried
+	Error  error
 }
 type RepoEntry struct {
 	*br.RepoEntry
 	Server string
 }
 
-// get repos from all build servers
+// get repos from all domains. each domain is asked once, even if it has several replicas.
+// domains which fail are reported in RepoList.Degraded, as are buildrepos which are not connected (whose domain
+// may not even be known). it only returns an error if all domains fail
 func (b *BuildRepo) ListRepos(ctx context.Context) (*RepoList, error) {
-	debugf("Listing repos in %d clients\n", len(clients))
+	domains := connectedDomains()
+	debugf("Listing repos in %d domains\n", len(domains))
 	var wg sync.WaitGroup
-	var terr error
 	res := &RepoList{}
+	seen := make(map[string]bool)
 	var addlock sync.Mutex
-	for target, c := range clients {
+	for _, domain := range domains {
 		wg.Add(1)
-		go func(t string, bc br.BuildRepoManagerClient) {
+		go func(domain string) {
 			defer wg.Done()
-			lr, err := bc.ListRepos(ctx, &br.ListReposRequest{})
+			var lr *br.ListReposResponse
+			var t string
+			err := withReplica(domain, func(target string, c Backend) error {
+				var err error
+				t = target
+				lr, err = c.ListRepos(ctx)
+				return err
+			})
+			addlock.Lock()
+			defer addlock.Unlock()
 			if err != nil {
-				terr = err
+				fmt.Printf("WARNING: failed to list repos in domain \"%s\" (%s): %s\n", domain, t, err)
+				res.Degraded = append(res.Degraded, &DegradedSource{Domain: domain, Server: t, Error: err})
 				return
 			}
-			addlock.Lock()
 			for _, e := range lr.Entries {
 				// compatibility - older buildrepos do not provide domains for entries (yet)
 				// if so use a default.
 				// TODO: update buildrepo server
 				if e.Domain == "" {
-					d := GetDomainForBuildRepo(t)
-					fmt.Printf("WARNING: Buildrepo server %s did not provide domain for \"%s\", using \"%s\"\n", t, e.Name, d)
-					e.Domain = d
+					fmt.Printf("WARNING: Buildrepo server %s did not provide domain for \"%s\", using \"%s\"\n", t, e.Name, domain)
+					e.Domain = domain
 				}
+				key := e.Domain + "/" + e.Name
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
 				re := &RepoEntry{e, t}
 				res.Entries = append(res.Entries, re)
 			}
-			addlock.Unlock()
-		}(target, c)
+		}(domain)
 	}
 	wg.Wait()
-	if terr != nil {
-		return nil, terr
+	if len(res.Degraded) > 0 && len(res.Degraded) == len(domains) {
+		return nil, res.Degraded[0].Error
 	}
+	res.Degraded = append(res.Degraded, disconnectedRepos()...)
 	return res, nil
 }
 
@@ -127,12 +156,17 @@ func (b *BuildRepo) ListFiles(ctx context.Context, domain string, blvr *br.ListF
 	if domain == "" {
 		return nil, "", fmt.Errorf("missing domain for artefact %s", blvr.Repository)
 	}
-	t := GetBuildRepoForDomain(domain)
-	c := clients[t]
-	if c == nil {
+	var lfr *br.ListFilesResponse
+	var t string
+	err := withReplica(domain, func(target string, c Backend) error {
+		var err error
+		t = target
+		lfr, err = c.ListFiles(ctx, blvr)
+		return err
+	})
+	if err == errNoReplica {
 		return nil, "", fmt.Errorf("(1) no buildrepo server serving %s in domain %s", blvr.Repository, domain)
 	}
-	lfr, err := c.ListFiles(ctx, blvr)
 	return lfr, t, err
 }
 
@@ -140,71 +174,67 @@ func (b *BuildRepo) DoesFileExist(ctx context.Context, domain string, blvr *br.G
 	if domain == "" {
 		return nil, fmt.Errorf("missing domain for artefact %s", blvr.File.Repository)
 	}
-	t := GetBuildRepoForDomain(domain)
-	c := clients[t]
-	if c == nil {
+	var res *br.FileExistsInfo
+	err := withReplica(domain, func(t string, c Backend) error {
+		var err error
+		res, err = c.DoesFileExist(ctx, blvr)
+		return err
+	})
+	if err == errNoReplica {
 		return nil, fmt.Errorf("(2) no buildrepo server serving %s in domain %s", blvr.File.Repository, domain)
 	}
-	res, err := c.DoesFileExist(ctx, blvr)
 	return res, err
-
 }
 
 func (b *BuildRepo) GetRepositoryMeta(ctx context.Context, domain string, blvr *br.GetRepoMetaRequest) (*br.RepoMetaInfo, error) {
 	if domain == "" {
 		return nil, fmt.Errorf("missing domain for artefact %s", blvr.Path)
 	}
-
-	t := GetBuildRepoForDomain(domain)
-	c := clients[t]
-	if c == nil {
+	var res *br.RepoMetaInfo
+	err := withReplica(domain, func(t string, c Backend) error {
+		var err error
+		res, err = c.GetRepositoryMeta(ctx, blvr)
+		return err
+	})
+	if err == errNoReplica {
 		return nil, fmt.Errorf("(3) no buildrepo server serving %s in domain %s", blvr.Path, domain)
 	}
-	res, err := c.GetRepositoryMeta(ctx, blvr)
 	return res, err
-
 }
 
-// write a file to a writer
+// write a file to a writer. fails over to another replica only if nothing was written yet
 func (b *BuildRepo) GetFile(ctx context.Context, domain string, blvr *br.GetFileRequest, target io.Writer) error {
 	if domain == "" {
 		return fmt.Errorf("missing domain for artefact %s", blvr.File.Repository)
 	}
-	t := GetBuildRepoForDomain(domain)
-	c := clients[t]
-	if c == nil {
-		return fmt.Errorf("(4) no buildrepo server serving %s in domain %s", blvr.File.Repository, domain)
-	}
-	stream, err := c.GetFileAsStream(ctx, blvr)
-	if err != nil {
-		return err
-	}
-	for {
-		block, err := stream.Recv()
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return err
-		}
-		_, err = target.Write(block.Data[:block.Size])
-		if err != nil {
-			return err
+	cw := &countingwriter{target: target}
+	err := withReplica(domain, func(t string, c Backend) error {
+		err := c.GetFile(ctx, blvr, cw)
+		if err != nil && cw.count > 0 && status.Code(err) == codes.Unavailable {
+			markUnavailable(t, err)
+			return fmt.Errorf("download of %s from %s interrupted after %d bytes: %s", blvr.File.Filename, t, cw.count, err)
 		}
+		return err
+	})
+	if err == errNoReplica {
+		return fmt.Errorf("(4) no buildrepo server serving %s in domain %s", blvr.File.Repository, domain)
 	}
-	return nil
+	return err
 }
 
 func (b *BuildRepo) GetLatestVersion(ctx context.Context, domain string, glvr *br.GetLatestVersionRequest) (*br.GetLatestVersionResponse, error) {
 	if domain == "" {
 		return nil, fmt.Errorf("missing domain for artefact %s", glvr.Repository)
 	}
-	t := GetBuildRepoForDomain(domain)
-	c := clients[t]
-	if c == nil {
+	var v *br.GetLatestVersionResponse
+	err := withReplica(domain, func(t string, c Backend) error {
+		var err error
+		v, err = c.GetLatestVersion(ctx, glvr)
+		return err
+	})
+	if err == errNoReplica {
 		return nil, fmt.Errorf("(5) no buildrepo server serving %s in domain %s", glvr.Repository, domain)
 	}
-	v, err := c.GetLatestVersion(ctx, glvr)
 	if err != nil {
 		return nil, err
 	}
@@ -214,47 +244,118 @@ func (b *BuildRepo) ListVersions(ctx context.Context, domain string, glvr *br.Li
 	if domain == "" {
 		return nil, fmt.Errorf("missing domain for artefact %s", glvr.Repository)
 	}
-	t := GetBuildRepoForDomain(domain)
-	c := clients[t]
-	if c == nil {
+	var v *br.ListVersionsResponse
+	err := withReplica(domain, func(t string, c Backend) error {
+		var err error
+		v, err = c.ListVersions(ctx, glvr)
+		return err
+	})
+	if err == errNoReplica {
 		return nil, fmt.Errorf("(6) no buildrepo server serving %s in domain %s", glvr.Repository, domain)
 	}
-	v, err := c.ListVersions(ctx, glvr)
 	if err != nil {
 		return nil, err
 	}
 	return v, nil
 }
-func GetBuildRepoForDomain(domain string) string {
-	for k, v := range get_build_repo_map() {
-		if v.Domain == domain {
-			return k
-		}
+func (b *BuildRepo) ListBranches(ctx context.Context, domain string, lbr *br.ListBranchesRequest) (*br.ListBranchesResponse, error) {
+	if domain == "" {
+		return nil, fmt.Errorf("missing domain for artefact %s", lbr.Repository)
 	}
-	fmt.Printf("WARNING: no buildrepo for domain \"%s\"\n", domain)
-	for k, v := range get_build_repo_map() {
-		fmt.Printf("Address %s serves %s\n", k, v)
+	var v *br.ListBranchesResponse
+	err := withReplica(domain, func(t string, c Backend) error {
+		var err error
+		v, err = c.ListBranches(ctx, lbr)
+		return err
+	})
+	if err == errNoReplica {
+		return nil, fmt.Errorf("(7) no buildrepo server serving %s in domain %s", lbr.Repository, domain)
 	}
-	return ""
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
 }
 
-func GetDomainForBuildRepo(target string) string {
-	for k, v := range get_build_repo_map() {
-		if k == target {
-			return v.Domain
+// true if every replica serving the domain can delete builds
+func (b *BuildRepo) CanDeleteBuilds(domain string) bool {
+	rs := replicasForDomain(domain)
+	if len(rs) == 0 {
+		return false
+	}
+	for _, r := range rs {
+		if !r.backend.CanDelete() {
+			return false
 		}
 	}
-	return ""
+	return true
 }
 
-func (b *BuildRepo) GetBuildRepoManagerClient(repo string, domain string) br.BuildRepoManagerClient {
-	a := GetBuildRepoForDomain(domain)
-	if a == "" {
-		return nil
+// delete a build from every replica serving the domain
+func (b *BuildRepo) DeleteBuild(ctx context.Context, domain string, repository string, branch string, build uint64) error {
+	if domain == "" {
+		return fmt.Errorf("missing domain for artefact %s", repository)
+	}
+	rs := replicasForDomain(domain)
+	if len(rs) == 0 {
+		return fmt.Errorf("(9) no buildrepo server serving %s in domain %s", repository, domain)
 	}
-	return clients[a]
+	var xerr error
+	for _, r := range rs {
+		err := r.backend.DeleteBuild(ctx, repository, branch, build)
+		if err != nil {
+			fmt.Printf("failed to delete build %d of %s (branch %s) from buildrepo %s: %s\n", build, repository, branch, r.address, err)
+			xerr = err
+		}
+	}
+	return xerr
 }
 
+// the preferred replica serving a domain, "" if there is none
+func GetBuildRepoForDomain(domain string) string {
+	rs := replicasForDomain(domain)
+	if len(rs) > 0 {
+		return rs[0].address
+	}
+	fmt.Printf("WARNING: no buildrepo for domain \"%s\"\n", domain)
+	for _, st := range ListBuildRepos() {
+		fmt.Printf("Address %s serves %s (connected=%v)\n", st.Address, st.Domain, st.Connected)
+	}
+	return ""
+}
 
+func GetDomainForBuildRepo(target string) string {
+	reglock.RLock()
+	defer reglock.RUnlock()
+	v := br_meta[target]
+	if v == nil {
+		return ""
+	}
+	return v.Domain
+}
 
+func (b *BuildRepo) GetFileMetaData(ctx context.Context, domain string, gmr *br.GetMetaRequest) (*br.GetMetaResponse, error) {
+	if domain == "" {
+		return nil, fmt.Errorf("missing domain for artefact %s", gmr.File.Repository)
+	}
+	var res *br.GetMetaResponse
+	err := withReplica(domain, func(t string, c Backend) error {
+		var err error
+		res, err = c.GetFileMetaData(ctx, gmr)
+		return err
+	})
+	if err == errNoReplica {
+		return nil, fmt.Errorf("(8) no buildrepo server serving %s in domain %s", gmr.File.Repository, domain)
+	}
+	return res, err
+}
 
+// the backend of the preferred replica serving a domain, nil if there is none.
+// calls made directly on the backend do not fail over
+func (b *BuildRepo) GetBackend(repo string, domain string) Backend {
+	rs := replicasForDomain(domain)
+	if len(rs) == 0 {
+		return nil
+	}
+	return rs[0].backend
+}
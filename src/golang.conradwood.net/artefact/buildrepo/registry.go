@@ -0,0 +1,186 @@
+package buildrepo
+
+import (
+	"flag"
+	"fmt"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/apis/common"
+	"golang.conradwood.net/go-easyops/authremote"
+	"golang.conradwood.net/go-easyops/client"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+* the set of buildrepos may change at runtime. buildrepos which cannot be reached are retried
+* in the background. clients and br_meta are only accessed with reglock held.
+ */
+var (
+	buildrepo_retry = flag.Duration("buildrepo_retry", time.Duration(30)*time.Second, "how often to retry connecting to an unreachable buildrepo")
+	reglock         sync.RWMutex
+)
+
+type build_repo_meta struct {
+	Address     string
+	Domain      string
+	Connected   bool
+	Healthy     bool   // false if the last call failed with Unavailable
+	Failures    uint32 // number of calls which failed with Unavailable
+	LastError   string
+	lastFailure time.Time
+	removed     bool
+}
+
+// state of a buildrepo, as returned by ListBuildRepos()
+type BuildRepoStatus struct {
+	Address   string
+	Domain    string
+	Connected bool
+	Healthy   bool
+	Failures  uint32
+	LastError string
+}
+
+func (m *build_repo_meta) status() *BuildRepoStatus {
+	return &BuildRepoStatus{
+		Address:   m.Address,
+		Domain:    m.Domain,
+		Connected: m.Connected,
+		Healthy:   m.Healthy,
+		Failures:  m.Failures,
+		LastError: m.LastError,
+	}
+}
+
+// add a buildrepo server (by hostname). if it cannot be reached it is retried in the background
+func AddBuildRepo(address string) (*BuildRepoStatus, error) {
+	address = strings.Trim(address, " ")
+	if address == "" {
+		return nil, fmt.Errorf("missing buildrepo address")
+	}
+	if strings.HasPrefix(address, "file://") {
+		return nil, fmt.Errorf("directories can only be added with -filesystem_repos")
+	}
+	reglock.Lock()
+	meta := br_meta[address]
+	if meta != nil {
+		reglock.Unlock()
+		return getStatus(address), nil
+	}
+	meta = &build_repo_meta{Address: address}
+	br_meta[address] = meta
+	reglock.Unlock()
+
+	err := connectBuildRepo(meta)
+	if err != nil {
+		fmt.Printf("buildrepo %s unreachable, retrying in background: %s\n", address, err)
+		go retryBuildRepo(meta)
+	}
+	return getStatus(address), nil
+}
+
+// stop using a buildrepo
+func RemoveBuildRepo(address string) error {
+	reglock.Lock()
+	defer reglock.Unlock()
+	meta := br_meta[address]
+	if meta == nil {
+		return fmt.Errorf("no buildrepo \"%s\"", address)
+	}
+	meta.removed = true
+	if gb, ok := clients[address].(*grpcBackend); ok && gb.conn != nil {
+		gb.conn.Close()
+	}
+	delete(br_meta, address)
+	delete(clients, address)
+	fmt.Printf("Removed buildrepo %s (domain \"%s\")\n", address, meta.Domain)
+	return nil
+}
+
+// all buildrepos, sorted by address
+func ListBuildRepos() []*BuildRepoStatus {
+	reglock.RLock()
+	var res []*BuildRepoStatus
+	for _, m := range br_meta {
+		res = append(res, m.status())
+	}
+	reglock.RUnlock()
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Address < res[j].Address
+	})
+	return res
+}
+
+func getStatus(address string) *BuildRepoStatus {
+	reglock.RLock()
+	defer reglock.RUnlock()
+	m := br_meta[address]
+	if m == nil {
+		return &BuildRepoStatus{Address: address}
+	}
+	return m.status()
+}
+
+func registerFSBackend(fb *fsBackend) {
+	adr := "file://" + fb.root
+	reglock.Lock()
+	clients[adr] = fb
+	br_meta[adr] = &build_repo_meta{Address: adr, Domain: fb.domain, Connected: true, Healthy: true}
+	reglock.Unlock()
+	fmt.Printf("Serving domain %s from %s\n", fb.domain, fb.root)
+}
+
+func connectBuildRepo(meta *build_repo_meta) error {
+	adr := fmt.Sprintf("%s:5005", meta.Address)
+	fmt.Printf("Connecting to buildrepo at: %s\n", adr)
+	c, err := client.ConnectWithIP(adr)
+	if err != nil {
+		return setConnectError(meta, err)
+	}
+	brm := br.NewBuildRepoManagerClient(c)
+	ctx := authremote.Context()
+	mi, err := brm.GetManagerInfo(ctx, &common.Void{})
+	if err != nil {
+		c.Close()
+		return setConnectError(meta, fmt.Errorf("failed to get manager info: %s", err))
+	}
+	reglock.Lock()
+	defer reglock.Unlock()
+	if meta.removed {
+		c.Close()
+		return nil
+	}
+	meta.Domain = mi.Domain
+	meta.Connected = true
+	meta.Healthy = true
+	meta.LastError = ""
+	clients[meta.Address] = &grpcBackend{client: brm, conn: c}
+	fmt.Printf("buildrepo at %s serves domain %s\n", meta.Address, mi.Domain)
+	return nil
+}
+
+func setConnectError(meta *build_repo_meta, err error) error {
+	reglock.Lock()
+	meta.LastError = err.Error()
+	reglock.Unlock()
+	return err
+}
+
+func retryBuildRepo(meta *build_repo_meta) {
+	for {
+		time.Sleep(*buildrepo_retry)
+		reglock.RLock()
+		removed := meta.removed
+		reglock.RUnlock()
+		if removed {
+			return
+		}
+		err := connectBuildRepo(meta)
+		if err == nil {
+			return
+		}
+		debugf("buildrepo %s still unreachable: %s\n", meta.Address, err)
+	}
+}
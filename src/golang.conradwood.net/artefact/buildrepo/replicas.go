@@ -0,0 +1,155 @@
+package buildrepo
+
+import (
+	"flag"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"sort"
+	"time"
+)
+
+/*
+* a domain may be served by several buildrepos (replicas). calls go to a healthy replica and fail
+* over to the next one if a replica is unavailable. unavailable replicas are avoided for a while.
+ */
+var (
+	replica_recheck = flag.Duration("replica_recheck", time.Duration(60)*time.Second, "how long an unavailable buildrepo replica is avoided before it is tried again")
+	errNoReplica    = fmt.Errorf("no buildrepo replica")
+)
+
+type replica struct {
+	address  string
+	backend  Backend
+	healthy  bool
+	failures uint32
+}
+
+// the replicas serving a domain, preferred ones first
+func replicasForDomain(domain string) []*replica {
+	reglock.RLock()
+	now := time.Now()
+	var res []*replica
+	for k, v := range br_meta {
+		if !v.Connected || v.Domain != domain {
+			continue
+		}
+		c := clients[k]
+		if c == nil {
+			continue
+		}
+		healthy := v.Healthy || now.Sub(v.lastFailure) > *replica_recheck
+		res = append(res, &replica{address: k, backend: c, healthy: healthy, failures: v.Failures})
+	}
+	reglock.RUnlock()
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].healthy != res[j].healthy {
+			return res[i].healthy
+		}
+		if res[i].failures != res[j].failures {
+			return res[i].failures < res[j].failures
+		}
+		return res[i].address < res[j].address
+	})
+	return res
+}
+
+// all domains with at least one connected buildrepo
+func connectedDomains() []string {
+	reglock.RLock()
+	defer reglock.RUnlock()
+	seen := make(map[string]bool)
+	var res []string
+	for _, v := range br_meta {
+		if !v.Connected || seen[v.Domain] {
+			continue
+		}
+		seen[v.Domain] = true
+		res = append(res, v.Domain)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// buildrepos which are not connected, e.g. because they are still retried in the background
+func disconnectedRepos() []*DegradedSource {
+	reglock.RLock()
+	defer reglock.RUnlock()
+	var res []*DegradedSource
+	for k, v := range br_meta {
+		if v.Connected {
+			continue
+		}
+		err := fmt.Errorf("not connected")
+		if v.LastError != "" {
+			err = fmt.Errorf("not connected: %s", v.LastError)
+		}
+		res = append(res, &DegradedSource{Domain: v.Domain, Server: k, Error: err})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Server < res[j].Server
+	})
+	return res
+}
+
+// call f with the replicas of a domain until one succeeds or fails with an error other than Unavailable.
+// returns errNoReplica if no replica serves the domain
+func withReplica(domain string, f func(t string, c Backend) error) error {
+	rs := replicasForDomain(domain)
+	if len(rs) == 0 {
+		return errNoReplica
+	}
+	var err error
+	for i, r := range rs {
+		err = f(r.address, r.backend)
+		if err == nil {
+			markAvailable(r.address)
+			return nil
+		}
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		markUnavailable(r.address, err)
+		if i < len(rs)-1 {
+			fmt.Printf("buildrepo %s (domain \"%s\") unavailable, failing over to %s: %s\n", r.address, domain, rs[i+1].address, err)
+		}
+	}
+	return err
+}
+
+func markAvailable(address string) {
+	reglock.Lock()
+	defer reglock.Unlock()
+	m := br_meta[address]
+	if m == nil || m.Healthy {
+		return
+	}
+	m.Healthy = true
+	fmt.Printf("buildrepo %s is available again\n", address)
+}
+
+func markUnavailable(address string, err error) {
+	reglock.Lock()
+	defer reglock.Unlock()
+	m := br_meta[address]
+	if m == nil {
+		return
+	}
+	m.Healthy = false
+	m.Failures++
+	m.lastFailure = time.Now()
+	m.LastError = err.Error()
+}
+
+// counts bytes written, so that a download is not failed over once it started
+type countingwriter struct {
+	target io.Writer
+	count  uint64
+}
+
+func (cw *countingwriter) Write(buf []byte) (int, error) {
+	n, err := cw.target.Write(buf)
+	cw.count = cw.count + uint64(n)
+	return n, err
+}
@@ -22,6 +22,19 @@ var (
 	repoid      = flag.Uint("repoid", 0, "repository id (resolve to artefact)")
 	browsedir   = flag.String("dir", "", "browse this dir")
 	browsebuild = flag.Uint("buildid", 0, "build id")
+	branch      = flag.String("branch", "", "branch to browse (default: the default branch of the repository)")
+	branches    = flag.Bool("branches", false, "list branches of an artefact")
+	watch       = flag.Bool("watch", false, "print new builds as they appear (of -artefactid, or all artefacts)")
+	findfiles   = flag.String("findfiles", "", "find files matching this glob in the latest build of all artefacts (or in all builds of -artefactid)")
+	regex       = flag.Bool("regex", false, "with -findfiles: the pattern is a regular expression")
+	promote     = flag.String("promote", "", "pin build -buildid of -artefactid to this release channel (e.g. stable)")
+	channels    = flag.Bool("channels", false, "list release channels (and their history) of -artefactid")
+	mark        = flag.String("mark", "", "mark build -buildid of -artefactid as available, deprecated or yanked")
+	reason      = flag.String("reason", "", "with -mark: why the build is deprecated or yanked")
+	retention   = flag.Bool("retention", false, "show which builds of -artefactid its retention rule would delete")
+	diff        = flag.Bool("diff", false, "show the differences between build -buildid and build -to of -artefactid (limited to -dir)")
+	tobuild     = flag.Uint("to", 0, "with -diff: the build to compare -buildid with")
+	commit      = flag.String("commit", "", "find the build of -artefactid which was built from this git commit")
 	echoClient  pb.ArtefactServiceClient
 )
 
@@ -44,6 +57,42 @@ func main() {
 		Browse()
 		os.Exit(0)
 	}
+	if *branches {
+		listBranches()
+		os.Exit(0)
+	}
+	if *watch {
+		doWatch()
+		os.Exit(0)
+	}
+	if *findfiles != "" {
+		doFindFiles()
+		os.Exit(0)
+	}
+	if *promote != "" {
+		doPromote()
+		os.Exit(0)
+	}
+	if *channels {
+		listChannels()
+		os.Exit(0)
+	}
+	if *mark != "" {
+		doMark()
+		os.Exit(0)
+	}
+	if *retention {
+		planRetention()
+		os.Exit(0)
+	}
+	if *diff {
+		diffBuilds()
+		os.Exit(0)
+	}
+	if *commit != "" {
+		buildForCommit()
+		os.Exit(0)
+	}
 	started := time.Now()
 	response, err := echoClient.List(ctx, &common.Void{})
 	utils.Bail("Failed to ping server", err)
@@ -80,6 +129,9 @@ func show(response *pb.ArtefactList) {
 		t.NewRow()
 	}
 	fmt.Printf("%s\n", t.ToPrettyString())
+	for _, d := range response.GetDegraded() {
+		fmt.Printf("WARNING: list incomplete, buildrepo %s (domain %s) unavailable: %s\n", d.BuildRepo, d.Domain, d.Error)
+	}
 }
 func effectiveRights(a *oa.AccessRightList) string {
 	return "rwx"
@@ -90,6 +142,148 @@ func dofind() {
 	utils.Bail("failed to find", err)
 	show(l)
 }
+func doWatch() {
+	ctx := ar.ContextWithTimeout(time.Duration(24) * time.Hour)
+	req := &pb.WatchRequest{}
+	if *artefactid != 0 {
+		req.ArtefactIDs = []uint64{uint64(*artefactid)}
+	}
+	srv, err := echoClient.WatchArtefacts(ctx, req)
+	utils.Bail("failed to watch", err)
+	for {
+		ev, err := srv.Recv()
+		utils.Bail("failed to receive event", err)
+		fmt.Printf("%s #%d (%s) %s: build %d -> %d\n", utils.TimestampString(ev.Timestamp), ev.ArtefactID, ev.Name, ev.Branch, ev.OldBuildID, ev.NewBuildID)
+	}
+}
+func doFindFiles() {
+	ctx := ar.Context()
+	req := &pb.FindFilesRequest{Pattern: *findfiles, Regex: *regex, ArtefactID: uint64(*artefactid), Branch: *branch}
+	l, err := echoClient.FindFiles(ctx, req)
+	utils.Bail("failed to find files", err)
+	t := utils.Table{}
+	t.AddHeaders("ArtefactID", "name", "domain", "branch", "build", "path")
+	for _, m := range l.Matches {
+		t.AddUint64(m.ArtefactID).AddString(m.Name).AddString(m.Domain).AddString(m.Branch).AddUint64(m.Build).AddString(m.Path)
+		t.NewRow()
+	}
+	fmt.Printf("%s\n", t.ToPrettyString())
+	if l.Truncated {
+		fmt.Printf("WARNING: more files match, not all are shown\n")
+	}
+	if l.Pending != 0 {
+		fmt.Printf("WARNING: %d artefacts are not indexed yet\n", l.Pending)
+	}
+}
+func doPromote() {
+	ctx := ar.Context()
+	req := &pb.PromoteRequest{ArtefactID: uint64(*artefactid), BuildID: uint64(*browsebuild), Channel: *promote, Branch: *branch}
+	ch, err := echoClient.PromoteBuild(ctx, req)
+	utils.Bail("failed to promote build", err)
+	fmt.Printf("Channel %s of artefact #%d is now build %d (branch %s)\n", ch.Name, ch.ArtefactID, ch.BuildID, ch.Branch)
+}
+func listChannels() {
+	ctx := ar.Context()
+	cl, err := echoClient.ListChannels(ctx, &pb.ID{ID: uint64(*artefactid)})
+	utils.Bail("failed to list channels", err)
+	t := utils.Table{}
+	t.AddHeaders("channel", "branch", "build", "updated")
+	for _, ch := range cl.Channels {
+		t.AddString(ch.Name).AddString(ch.Branch).AddUint64(ch.BuildID).AddString(utils.TimestampString(ch.Updated))
+		t.NewRow()
+	}
+	fmt.Printf("%s\n", t.ToPrettyString())
+	ccl, err := echoClient.ChannelHistory(ctx, &pb.ID{ID: uint64(*artefactid)})
+	utils.Bail("failed to get channel history", err)
+	t = utils.Table{}
+	t.AddHeaders("when", "channel", "branch", "from", "to", "user")
+	for _, cc := range ccl.Changes {
+		t.AddString(utils.TimestampString(cc.Timestamp)).AddString(cc.Channel).AddString(cc.Branch).AddUint64(cc.OldBuildID).AddUint64(cc.NewBuildID).AddString(cc.UserID)
+		t.NewRow()
+	}
+	fmt.Printf("%s\n", t.ToPrettyString())
+}
+func doMark() {
+	ctx := ar.Context()
+	var state pb.BuildState
+	switch *mark {
+	case "available":
+		state = pb.BuildState_Available
+	case "deprecated":
+		state = pb.BuildState_Deprecated
+	case "yanked":
+		state = pb.BuildState_Yanked
+	default:
+		fmt.Printf("invalid -mark \"%s\" (must be available, deprecated or yanked)\n", *mark)
+		os.Exit(10)
+	}
+	req := &pb.MarkBuildRequest{ArtefactID: uint64(*artefactid), Branch: *branch, BuildID: uint64(*browsebuild), State: state, Reason: *reason}
+	_, err := echoClient.MarkBuild(ctx, req)
+	utils.Bail("failed to mark build", err)
+	fmt.Printf("Build %d of artefact #%d is now %s\n", req.BuildID, req.ArtefactID, state)
+}
+func planRetention() {
+	ctx := ar.Context()
+	plan, err := echoClient.PlanRetention(ctx, &pb.ID{ID: uint64(*artefactid)})
+	utils.Bail("failed to plan retention", err)
+	if plan.Rule == nil {
+		fmt.Printf("Artefact #%d has no retention rule, all %d builds are kept\n", plan.ArtefactID, plan.KeptBuilds)
+		return
+	}
+	t := utils.Table{}
+	t.AddHeaders("branch", "build", "bytes", "created", "last download")
+	for _, pd := range plan.Deletions {
+		t.AddString(pd.Branch).AddUint64(pd.BuildID).AddUint64(pd.Bytes).AddString(utils.TimestampString(pd.Created)).AddString(utils.TimestampString(pd.LastDownload))
+		t.NewRow()
+	}
+	fmt.Printf("%s\n", t.ToPrettyString())
+	if plan.CannotDelete {
+		fmt.Printf("%d builds would be deleted, %d kept, but the buildrepo cannot delete builds\n", len(plan.Deletions), plan.KeptBuilds)
+		return
+	}
+	fmt.Printf("%d builds would be deleted (%d bytes), %d kept\n", len(plan.Deletions), plan.BytesSaved, plan.KeptBuilds)
+}
+func diffBuilds() {
+	ctx := ar.Context()
+	bd, err := echoClient.DiffBuilds(ctx, &pb.DiffRequest{
+		ArtefactID: uint64(*artefactid),
+		Branch:     *branch,
+		BuildA:     uint64(*browsebuild),
+		BuildB:     uint64(*tobuild),
+		Dir:        *browsedir,
+	})
+	utils.Bail("failed to diff builds", err)
+	t := utils.Table{}
+	t.AddHeaders("change", "path", "size", "delta")
+	for _, fd := range bd.Files {
+		t.AddString(fmt.Sprintf("%v", fd.Change)).AddString(fd.Path).AddUint64(fd.SizeB).AddString(fmt.Sprintf("%+d", fd.SizeDelta))
+		t.NewRow()
+	}
+	fmt.Printf("%s\n", t.ToPrettyString())
+	for _, fd := range bd.Files {
+		if fd.UnifiedDiff != "" {
+			fmt.Printf("%s\n", fd.UnifiedDiff)
+		}
+	}
+	fmt.Printf("Build %d -> %d (branch %s): %d files changed, %d unchanged, %+d bytes\n", bd.BuildA, bd.BuildB, bd.Branch, len(bd.Files), bd.Unchanged, bd.SizeDelta)
+}
+func buildForCommit() {
+	ctx := ar.Context()
+	bi, err := echoClient.GetBuildForCommit(ctx, &pb.BuildForCommitRequest{ArtefactID: uint64(*artefactid), CommitID: *commit})
+	utils.Bail("failed to get build for commit", err)
+	fmt.Printf("Build:   %d (branch %s, %s)\n", bi.BuildID, bi.Branch, bi.State)
+	fmt.Printf("Commit:  %s\n", bi.CommitID)
+	fmt.Printf("Message: %s\n", bi.CommitMessage)
+	fmt.Printf("Built:   %s by %s on %s\n", utils.TimestampString(bi.Timestamp), bi.UserID, bi.BuildHost)
+}
+
+// the abbreviated form of a git commit hash
+func shortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
 func ResolveRepoID() {
 	ctx := ar.Context()
 	l, err := echoClient.GetArtefactForRepo(ctx, &pb.ID{ID: uint64(*repoid)})
@@ -23,6 +23,7 @@ func Browse() {
 		Build:      uint64(*browsebuild),
 		Dir:        *browsedir,
 		ArtefactID: uint64(*artefactid),
+		Branch:     *branch,
 	})
 	utils.Bail("failed to get dir listing", err)
 	for _, f := range dl.Files {
@@ -38,12 +39,37 @@ func browseBuilds() {
 	ctx := authremote.Context()
 	bl, err := ar.GetArtefactBuilds(ctx, &artefact.ArtefactID{ID: uint64(*artefactid)})
 	utils.Bail("failed to get builds", err)
-	for _, b := range bl.Builds {
-		fmt.Printf("Build #%d\n", b)
+	for i, b := range bl.Builds {
+		s := fmt.Sprintf("Build #%d", b)
+		if i >= len(bl.Infos) {
+			fmt.Printf("%s\n", s)
+			continue
+		}
+		bi := bl.Infos[i]
+		if bi.CommitID != "" {
+			s = s + fmt.Sprintf(" commit %s by %s on %s", shortCommit(bi.CommitID), bi.UserID, bi.BuildHost)
+		}
+		if bi.State != artefact.BuildState_Available {
+			s = s + fmt.Sprintf(" (%s: %s)", bi.State, bi.StateReason)
+		}
+		fmt.Printf("%s\n", s)
 	}
 
 }
-
-
-
-
+func listBranches() {
+	if *artefactid == 0 {
+		fmt.Printf("please specify artefactid\n")
+		os.Exit(10)
+	}
+	ar := artefact.GetArtefactServiceClient()
+	ctx := authremote.Context()
+	bl, err := ar.ListBranches(ctx, &artefact.ID{ID: uint64(*artefactid)})
+	utils.Bail("failed to get branches", err)
+	for _, b := range bl.Branches {
+		s := ""
+		if b == bl.DefaultBranch {
+			s = " (default)"
+		}
+		fmt.Printf("Branch %s%s\n", b, s)
+	}
+}
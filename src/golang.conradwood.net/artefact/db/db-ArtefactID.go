@@ -16,18 +16,19 @@ package db
 
 Main Table:
 
- CREATE TABLE artefactid (id integer primary key default nextval('artefactid_seq'),domain text not null  ,name text not null  ,url text not null  ,created integer not null  );
+ CREATE TABLE artefactid (id integer primary key default nextval('artefactid_seq'),domain text not null  ,name text not null  ,url text not null  ,created integer not null  ,organisationid text not null  );
 
 Alter statements:
 ALTER TABLE artefactid ADD COLUMN IF NOT EXISTS domain text not null default '';
 ALTER TABLE artefactid ADD COLUMN IF NOT EXISTS name text not null default '';
 ALTER TABLE artefactid ADD COLUMN IF NOT EXISTS url text not null default '';
 ALTER TABLE artefactid ADD COLUMN IF NOT EXISTS created integer not null default 0;
+ALTER TABLE artefactid ADD COLUMN IF NOT EXISTS organisationid text not null default '';
 
 
 Archive Table: (structs can be moved from main to archive using Archive() function)
 
- CREATE TABLE artefactid_archive (id integer unique not null,domain text not null,name text not null,url text not null,created integer not null);
+ CREATE TABLE artefactid_archive (id integer unique not null,domain text not null,name text not null,url text not null,created integer not null,organisationid text not null);
 */
 
 import (
@@ -108,7 +109,7 @@ func (a *DBArtefactID) Archive(ctx context.Context, id uint64) error {
 	}
 
 	// now save it to archive:
-	_, e := a.DB.ExecContext(ctx, "archive_DBArtefactID", "insert into "+a.SQLArchivetablename+" (id,domain, name, url, created) values ($1,$2, $3, $4, $5) ", p.ID, p.Domain, p.Name, p.URL, p.Created)
+	_, e := a.DB.ExecContext(ctx, "archive_DBArtefactID", "insert into "+a.SQLArchivetablename+" (id,domain, name, url, created, organisationid) values ($1,$2, $3, $4, $5, $6) ", p.ID, p.Domain, p.Name, p.URL, p.Created, p.OrganisationID)
 	if e != nil {
 		return e
 	}
@@ -130,6 +131,7 @@ func (a *DBArtefactID) buildSaveMap(ctx context.Context, p *savepb.ArtefactID) (
 	res["name"] = a.get_col_from_proto(p, "name")
 	res["url"] = a.get_col_from_proto(p, "url")
 	res["created"] = a.get_col_from_proto(p, "created")
+	res["organisationid"] = a.get_col_from_proto(p, "organisationid")
 	if extra != nil {
 		for k, v := range extra {
 			res[k] = v
@@ -206,7 +208,7 @@ func (a *DBArtefactID) SaveOrUpdate(ctx context.Context, p *savepb.ArtefactID) e
 }
 func (a *DBArtefactID) Update(ctx context.Context, p *savepb.ArtefactID) error {
 	qn := "DBArtefactID_Update"
-	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set domain=$1, name=$2, url=$3, created=$4 where id = $5", a.get_Domain(p), a.get_Name(p), a.get_URL(p), a.get_Created(p), p.ID)
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set domain=$1, name=$2, url=$3, created=$4, organisationid=$5 where id = $6", a.get_Domain(p), a.get_Name(p), a.get_URL(p), a.get_Created(p), a.get_OrganisationID(p), p.ID)
 
 	return a.Error(ctx, qn, e)
 }
@@ -394,6 +396,36 @@ func (a *DBArtefactID) ByLikeCreated(ctx context.Context, p uint32) ([]*savepb.A
 	return l, nil
 }
 
+// get all "DBArtefactID" rows with matching OrganisationID
+func (a *DBArtefactID) ByOrganisationID(ctx context.Context, p string) ([]*savepb.ArtefactID, error) {
+	qn := "DBArtefactID_ByOrganisationID"
+	l, e := a.fromQuery(ctx, qn, "organisationid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByOrganisationID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBArtefactID" rows with multiple matching OrganisationID
+func (a *DBArtefactID) ByMultiOrganisationID(ctx context.Context, p []string) ([]*savepb.ArtefactID, error) {
+	qn := "DBArtefactID_ByOrganisationID"
+	l, e := a.fromQuery(ctx, qn, "organisationid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByOrganisationID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBArtefactID) ByLikeOrganisationID(ctx context.Context, p string) ([]*savepb.ArtefactID, error) {
+	qn := "DBArtefactID_ByLikeOrganisationID"
+	l, e := a.fromQuery(ctx, qn, "organisationid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByOrganisationID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
 /**********************************************************************
 * The field getters
 **********************************************************************/
@@ -423,6 +455,11 @@ func (a *DBArtefactID) get_Created(p *savepb.ArtefactID) uint32 {
 	return uint32(p.Created)
 }
 
+// getter for field "OrganisationID" (OrganisationID) [string]
+func (a *DBArtefactID) get_OrganisationID(p *savepb.ArtefactID) string {
+	return string(p.OrganisationID)
+}
+
 /**********************************************************************
 * Helper to convert from an SQL Query
 **********************************************************************/
@@ -508,6 +545,8 @@ func (a *DBArtefactID) get_col_from_proto(p *savepb.ArtefactID, colname string)
 		return a.get_URL(p)
 	} else if colname == "created" {
 		return a.get_Created(p)
+	} else if colname == "organisationid" {
+		return a.get_OrganisationID(p)
 	}
 	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
 }
@@ -517,10 +556,10 @@ func (a *DBArtefactID) Tablename() string {
 }
 
 func (a *DBArtefactID) SelectCols() string {
-	return "id,domain, name, url, created"
+	return "id,domain, name, url, created, organisationid"
 }
 func (a *DBArtefactID) SelectColsQualified() string {
-	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".domain, " + a.SQLTablename + ".name, " + a.SQLTablename + ".url, " + a.SQLTablename + ".created"
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".domain, " + a.SQLTablename + ".name, " + a.SQLTablename + ".url, " + a.SQLTablename + ".created, " + a.SQLTablename + ".organisationid"
 }
 
 func (a *DBArtefactID) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.ArtefactID, error) {
@@ -535,7 +574,8 @@ func (a *DBArtefactID) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savep
 		scanTarget_2 := &foo.Name
 		scanTarget_3 := &foo.URL
 		scanTarget_4 := &foo.Created
-		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4)
+		scanTarget_5 := &foo.OrganisationID
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4, scanTarget_5)
 		// END SCANNER
 
 		if err != nil {
@@ -552,17 +592,19 @@ func (a *DBArtefactID) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savep
 func (a *DBArtefactID) CreateTable(ctx context.Context) error {
 	csql := []string{
 		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
-		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),domain text not null ,name text not null ,url text not null ,created integer not null );`,
-		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),domain text not null ,name text not null ,url text not null ,created integer not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),domain text not null ,name text not null ,url text not null ,created integer not null ,organisationid text not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),domain text not null ,name text not null ,url text not null ,created integer not null ,organisationid text not null );`,
 		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS domain text not null default '';`,
 		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS name text not null default '';`,
 		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS url text not null default '';`,
 		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS created integer not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS organisationid text not null default '';`,
 
 		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS domain text not null  default '';`,
 		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS name text not null  default '';`,
 		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS url text not null  default '';`,
 		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS created integer not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS organisationid text not null  default '';`,
 	}
 
 	for i, c := range csql {
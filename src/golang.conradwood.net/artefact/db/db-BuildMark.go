@@ -0,0 +1,723 @@
+package db
+
+/*
+ This file was created by mkdb-client.
+ The intention is not to modify this file, but you may extend the struct DBBuildMark
+ in a seperate file (so that you can regenerate this one from time to time)
+*/
+
+/*
+ PRIMARY KEY: ID
+*/
+
+/*
+ postgres:
+ create sequence buildmark_seq;
+
+Main Table:
+
+ CREATE TABLE buildmark (id integer primary key default nextval('buildmark_seq'),artefactid bigint not null  ,branch text not null  ,buildid bigint not null  ,yanked boolean not null  ,reason text not null  ,userid text not null  ,timestamp integer not null  );
+
+Alter statements:
+ALTER TABLE buildmark ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;
+ALTER TABLE buildmark ADD COLUMN IF NOT EXISTS branch text not null default '';
+ALTER TABLE buildmark ADD COLUMN IF NOT EXISTS buildid bigint not null default 0;
+ALTER TABLE buildmark ADD COLUMN IF NOT EXISTS yanked boolean not null default false;
+ALTER TABLE buildmark ADD COLUMN IF NOT EXISTS reason text not null default '';
+ALTER TABLE buildmark ADD COLUMN IF NOT EXISTS userid text not null default '';
+ALTER TABLE buildmark ADD COLUMN IF NOT EXISTS timestamp integer not null default 0;
+
+
+Archive Table: (structs can be moved from main to archive using Archive() function)
+
+ CREATE TABLE buildmark_archive (id integer unique not null,artefactid bigint not null,branch text not null,buildid bigint not null,yanked boolean not null,reason text not null,userid text not null,timestamp integer not null);
+*/
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	savepb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/sql"
+	"os"
+	"sync"
+)
+
+var (
+	default_def_DBBuildMark *DBBuildMark
+)
+
+type DBBuildMark struct {
+	DB                   *sql.DB
+	SQLTablename         string
+	SQLArchivetablename  string
+	customColumnHandlers []CustomColumnHandler
+	lock                 sync.Mutex
+}
+
+func init() {
+	RegisterDBHandlerFactory(func() Handler {
+		return DefaultDBBuildMark()
+	})
+}
+
+func DefaultDBBuildMark() *DBBuildMark {
+	if default_def_DBBuildMark != nil {
+		return default_def_DBBuildMark
+	}
+	psql, err := sql.Open()
+	if err != nil {
+		fmt.Printf("Failed to open database: %s\n", err)
+		os.Exit(10)
+	}
+	res := NewDBBuildMark(psql)
+	ctx := context.Background()
+	err = res.CreateTable(ctx)
+	if err != nil {
+		fmt.Printf("Failed to create table: %s\n", err)
+		os.Exit(10)
+	}
+	default_def_DBBuildMark = res
+	return res
+}
+func NewDBBuildMark(db *sql.DB) *DBBuildMark {
+	foo := DBBuildMark{DB: db}
+	foo.SQLTablename = "buildmark"
+	foo.SQLArchivetablename = "buildmark_archive"
+	return &foo
+}
+
+func (a *DBBuildMark) GetCustomColumnHandlers() []CustomColumnHandler {
+	return a.customColumnHandlers
+}
+func (a *DBBuildMark) AddCustomColumnHandler(w CustomColumnHandler) {
+	a.lock.Lock()
+	a.customColumnHandlers = append(a.customColumnHandlers, w)
+	a.lock.Unlock()
+}
+
+func (a *DBBuildMark) NewQuery() *Query {
+	return newQuery(a)
+}
+
+// archive. It is NOT transactionally save.
+func (a *DBBuildMark) Archive(ctx context.Context, id uint64) error {
+
+	// load it
+	p, err := a.ByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// now save it to archive:
+	_, e := a.DB.ExecContext(ctx, "archive_DBBuildMark", "insert into "+a.SQLArchivetablename+" (id,artefactid, branch, buildid, yanked, reason, userid, timestamp) values ($1,$2, $3, $4, $5, $6, $7, $8) ", p.ID, p.ArtefactID, p.Branch, p.BuildID, p.Yanked, p.Reason, p.UserID, p.Timestamp)
+	if e != nil {
+		return e
+	}
+
+	// now delete it.
+	a.DeleteByID(ctx, id)
+	return nil
+}
+
+// return a map with columnname -> value_from_proto
+func (a *DBBuildMark) buildSaveMap(ctx context.Context, p *savepb.BuildMark) (map[string]interface{}, error) {
+	extra, err := extraFieldsToStore(ctx, a, p)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	res["id"] = a.get_col_from_proto(p, "id")
+	res["artefactid"] = a.get_col_from_proto(p, "artefactid")
+	res["branch"] = a.get_col_from_proto(p, "branch")
+	res["buildid"] = a.get_col_from_proto(p, "buildid")
+	res["yanked"] = a.get_col_from_proto(p, "yanked")
+	res["reason"] = a.get_col_from_proto(p, "reason")
+	res["userid"] = a.get_col_from_proto(p, "userid")
+	res["timestamp"] = a.get_col_from_proto(p, "timestamp")
+	if extra != nil {
+		for k, v := range extra {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (a *DBBuildMark) Save(ctx context.Context, p *savepb.BuildMark) (uint64, error) {
+	qn := "save_DBBuildMark"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	delete(smap, "id") // save without id
+	return a.saveMap(ctx, qn, smap, p)
+}
+
+// Save using the ID specified
+func (a *DBBuildMark) SaveWithID(ctx context.Context, p *savepb.BuildMark) error {
+	qn := "insert_DBBuildMark"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, err = a.saveMap(ctx, qn, smap, p)
+	return err
+}
+
+// use a hashmap of columnname->values to store to database (see buildSaveMap())
+func (a *DBBuildMark) saveMap(ctx context.Context, queryname string, smap map[string]interface{}, p *savepb.BuildMark) (uint64, error) {
+	// Save (and use database default ID generation)
+
+	var rows *gosql.Rows
+	var e error
+
+	q_cols := ""
+	q_valnames := ""
+	q_vals := make([]interface{}, 0)
+	deli := ""
+	i := 0
+	// build the 2 parts of the query (column names and value names) as well as the values themselves
+	for colname, val := range smap {
+		q_cols = q_cols + deli + colname
+		i++
+		q_valnames = q_valnames + deli + fmt.Sprintf("$%d", i)
+		q_vals = append(q_vals, val)
+		deli = ","
+	}
+	rows, e = a.DB.QueryContext(ctx, queryname, "insert into "+a.SQLTablename+" ("+q_cols+") values ("+q_valnames+") returning id", q_vals...)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, e)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, a.Error(ctx, queryname, errors.Errorf("No rows after insert"))
+	}
+	var id uint64
+	e = rows.Scan(&id)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, errors.Errorf("failed to scan id after insert: %s", e))
+	}
+	p.ID = id
+	return id, nil
+}
+
+// if ID==0 save, otherwise update
+func (a *DBBuildMark) SaveOrUpdate(ctx context.Context, p *savepb.BuildMark) error {
+	if p.ID == 0 {
+		_, err := a.Save(ctx, p)
+		return err
+	}
+	return a.Update(ctx, p)
+}
+func (a *DBBuildMark) Update(ctx context.Context, p *savepb.BuildMark) error {
+	qn := "DBBuildMark_Update"
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set artefactid=$1, branch=$2, buildid=$3, yanked=$4, reason=$5, userid=$6, timestamp=$7 where id = $8", a.get_ArtefactID(p), a.get_Branch(p), a.get_BuildID(p), a.get_Yanked(p), a.get_Reason(p), a.get_UserID(p), a.get_Timestamp(p), p.ID)
+
+	return a.Error(ctx, qn, e)
+}
+
+// delete by id field
+func (a *DBBuildMark) DeleteByID(ctx context.Context, p uint64) error {
+	qn := "deleteDBBuildMark_ByID"
+	_, e := a.DB.ExecContext(ctx, qn, "delete from "+a.SQLTablename+" where id = $1", p)
+	return a.Error(ctx, qn, e)
+}
+
+// get it by primary id
+func (a *DBBuildMark) ByID(ctx context.Context, p uint64) (*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, a.Error(ctx, qn, errors.Errorf("No BuildMark with id %v", p))
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) BuildMark with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by primary id (nil if no such ID row, but no error either)
+func (a *DBBuildMark) TryByID(ctx context.Context, p uint64) (*savepb.BuildMark, error) {
+	qn := "DBBuildMark_TryByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, nil
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) BuildMark with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by multiple primary ids
+func (a *DBBuildMark) ByIDs(ctx context.Context, p []uint64) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByIDs"
+	l, e := a.fromQuery(ctx, qn, "id in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all rows
+func (a *DBBuildMark) All(ctx context.Context) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_all"
+	l, e := a.fromQuery(ctx, qn, "true")
+	if e != nil {
+		return nil, errors.Errorf("All: error scanning (%s)", e)
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* GetBy[FIELD] functions
+**********************************************************************/
+
+// get all "DBBuildMark" rows with matching ArtefactID
+func (a *DBBuildMark) ByArtefactID(ctx context.Context, p uint64) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with multiple matching ArtefactID
+func (a *DBBuildMark) ByMultiArtefactID(ctx context.Context, p []uint64) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildMark) ByLikeArtefactID(ctx context.Context, p uint64) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByLikeArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with matching Branch
+func (a *DBBuildMark) ByBranch(ctx context.Context, p string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with multiple matching Branch
+func (a *DBBuildMark) ByMultiBranch(ctx context.Context, p []string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildMark) ByLikeBranch(ctx context.Context, p string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByLikeBranch"
+	l, e := a.fromQuery(ctx, qn, "branch ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with matching BuildID
+func (a *DBBuildMark) ByBuildID(ctx context.Context, p uint64) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with multiple matching BuildID
+func (a *DBBuildMark) ByMultiBuildID(ctx context.Context, p []uint64) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildMark) ByLikeBuildID(ctx context.Context, p uint64) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByLikeBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with matching Yanked
+func (a *DBBuildMark) ByYanked(ctx context.Context, p bool) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByYanked"
+	l, e := a.fromQuery(ctx, qn, "yanked = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByYanked: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with multiple matching Yanked
+func (a *DBBuildMark) ByMultiYanked(ctx context.Context, p []bool) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByYanked"
+	l, e := a.fromQuery(ctx, qn, "yanked in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByYanked: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildMark) ByLikeYanked(ctx context.Context, p bool) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByLikeYanked"
+	l, e := a.fromQuery(ctx, qn, "yanked ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByYanked: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with matching Reason
+func (a *DBBuildMark) ByReason(ctx context.Context, p string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByReason"
+	l, e := a.fromQuery(ctx, qn, "reason = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByReason: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with multiple matching Reason
+func (a *DBBuildMark) ByMultiReason(ctx context.Context, p []string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByReason"
+	l, e := a.fromQuery(ctx, qn, "reason in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByReason: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildMark) ByLikeReason(ctx context.Context, p string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByLikeReason"
+	l, e := a.fromQuery(ctx, qn, "reason ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByReason: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with matching UserID
+func (a *DBBuildMark) ByUserID(ctx context.Context, p string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByUserID"
+	l, e := a.fromQuery(ctx, qn, "userid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with multiple matching UserID
+func (a *DBBuildMark) ByMultiUserID(ctx context.Context, p []string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByUserID"
+	l, e := a.fromQuery(ctx, qn, "userid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildMark) ByLikeUserID(ctx context.Context, p string) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByLikeUserID"
+	l, e := a.fromQuery(ctx, qn, "userid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with matching Timestamp
+func (a *DBBuildMark) ByTimestamp(ctx context.Context, p uint32) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByTimestamp"
+	l, e := a.fromQuery(ctx, qn, "timestamp = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByTimestamp: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildMark" rows with multiple matching Timestamp
+func (a *DBBuildMark) ByMultiTimestamp(ctx context.Context, p []uint32) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByTimestamp"
+	l, e := a.fromQuery(ctx, qn, "timestamp in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByTimestamp: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildMark) ByLikeTimestamp(ctx context.Context, p uint32) ([]*savepb.BuildMark, error) {
+	qn := "DBBuildMark_ByLikeTimestamp"
+	l, e := a.fromQuery(ctx, qn, "timestamp ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByTimestamp: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* The field getters
+**********************************************************************/
+
+// getter for field "ID" (ID) [uint64]
+func (a *DBBuildMark) get_ID(p *savepb.BuildMark) uint64 {
+	return uint64(p.ID)
+}
+
+// getter for field "ArtefactID" (ArtefactID) [uint64]
+func (a *DBBuildMark) get_ArtefactID(p *savepb.BuildMark) uint64 {
+	return uint64(p.ArtefactID)
+}
+
+// getter for field "Branch" (Branch) [string]
+func (a *DBBuildMark) get_Branch(p *savepb.BuildMark) string {
+	return string(p.Branch)
+}
+
+// getter for field "BuildID" (BuildID) [uint64]
+func (a *DBBuildMark) get_BuildID(p *savepb.BuildMark) uint64 {
+	return uint64(p.BuildID)
+}
+
+// getter for field "Yanked" (Yanked) [bool]
+func (a *DBBuildMark) get_Yanked(p *savepb.BuildMark) bool {
+	return bool(p.Yanked)
+}
+
+// getter for field "Reason" (Reason) [string]
+func (a *DBBuildMark) get_Reason(p *savepb.BuildMark) string {
+	return string(p.Reason)
+}
+
+// getter for field "UserID" (UserID) [string]
+func (a *DBBuildMark) get_UserID(p *savepb.BuildMark) string {
+	return string(p.UserID)
+}
+
+// getter for field "Timestamp" (Timestamp) [uint32]
+func (a *DBBuildMark) get_Timestamp(p *savepb.BuildMark) uint32 {
+	return uint32(p.Timestamp)
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Query
+**********************************************************************/
+
+// from a query snippet (the part after WHERE)
+func (a *DBBuildMark) ByDBQuery(ctx context.Context, query *Query) ([]*savepb.BuildMark, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for col_name, value := range extra_fields {
+		i++
+		/*
+		   efname:=fmt.Sprintf("EXTRA_FIELD_%d",i)
+		   query.Add(col_name+" = "+efname,QP{efname:value})
+		*/
+		query.AddEqual(col_name, value)
+	}
+
+	gw, paras := query.ToPostgres()
+	queryname := "custom_dbquery"
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where "+gw, paras...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+
+}
+
+func (a *DBBuildMark) FromQuery(ctx context.Context, query_where string, args ...interface{}) ([]*savepb.BuildMark, error) {
+	return a.fromQuery(ctx, "custom_query_"+a.Tablename(), query_where, args...)
+}
+
+// from a query snippet (the part after WHERE)
+func (a *DBBuildMark) fromQuery(ctx context.Context, queryname string, query_where string, args ...interface{}) ([]*savepb.BuildMark, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	eq := ""
+	if extra_fields != nil && len(extra_fields) > 0 {
+		eq = " AND ("
+		// build the extraquery "eq"
+		i := len(args)
+		deli := ""
+		for col_name, value := range extra_fields {
+			i++
+			eq = eq + deli + col_name + fmt.Sprintf(" = $%d", i)
+			deli = " AND "
+			args = append(args, value)
+		}
+		eq = eq + ")"
+	}
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where ( "+query_where+") "+eq, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Row to struct
+**********************************************************************/
+func (a *DBBuildMark) get_col_from_proto(p *savepb.BuildMark, colname string) interface{} {
+	if colname == "id" {
+		return a.get_ID(p)
+	} else if colname == "artefactid" {
+		return a.get_ArtefactID(p)
+	} else if colname == "branch" {
+		return a.get_Branch(p)
+	} else if colname == "buildid" {
+		return a.get_BuildID(p)
+	} else if colname == "yanked" {
+		return a.get_Yanked(p)
+	} else if colname == "reason" {
+		return a.get_Reason(p)
+	} else if colname == "userid" {
+		return a.get_UserID(p)
+	} else if colname == "timestamp" {
+		return a.get_Timestamp(p)
+	}
+	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
+}
+
+func (a *DBBuildMark) Tablename() string {
+	return a.SQLTablename
+}
+
+func (a *DBBuildMark) SelectCols() string {
+	return "id,artefactid, branch, buildid, yanked, reason, userid, timestamp"
+}
+func (a *DBBuildMark) SelectColsQualified() string {
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".artefactid, " + a.SQLTablename + ".branch, " + a.SQLTablename + ".buildid, " + a.SQLTablename + ".yanked, " + a.SQLTablename + ".reason, " + a.SQLTablename + ".userid, " + a.SQLTablename + ".timestamp"
+}
+
+func (a *DBBuildMark) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.BuildMark, error) {
+	var res []*savepb.BuildMark
+	for rows.Next() {
+		// SCANNER:
+		foo := &savepb.BuildMark{}
+		// create the non-nullable pointers
+		// create variables for scan results
+		scanTarget_0 := &foo.ID
+		scanTarget_1 := &foo.ArtefactID
+		scanTarget_2 := &foo.Branch
+		scanTarget_3 := &foo.BuildID
+		scanTarget_4 := &foo.Yanked
+		scanTarget_5 := &foo.Reason
+		scanTarget_6 := &foo.UserID
+		scanTarget_7 := &foo.Timestamp
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4, scanTarget_5, scanTarget_6, scanTarget_7)
+		// END SCANNER
+
+		if err != nil {
+			return nil, a.Error(ctx, "fromrow-scan", err)
+		}
+		res = append(res, foo)
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to create table and columns
+**********************************************************************/
+func (a *DBBuildMark) CreateTable(ctx context.Context) error {
+	csql := []string{
+		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,branch text not null ,buildid bigint not null ,yanked boolean not null ,reason text not null ,userid text not null ,timestamp integer not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,branch text not null ,buildid bigint not null ,yanked boolean not null ,reason text not null ,userid text not null ,timestamp integer not null );`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS branch text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS buildid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS yanked boolean not null default false;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS reason text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS userid text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS timestamp integer not null default 0;`,
+
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS artefactid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS branch text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS buildid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS yanked boolean not null  default false;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS reason text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS userid text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS timestamp integer not null  default 0;`,
+	}
+
+	for i, c := range csql {
+		_, e := a.DB.ExecContext(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+		if e != nil {
+			return e
+		}
+	}
+
+	// these are optional, expected to fail
+	csql = []string{
+		// Indices:
+
+		// Foreign keys:
+
+	}
+	for i, c := range csql {
+		a.DB.ExecContextQuiet(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+	}
+	return nil
+}
+
+/**********************************************************************
+* Helper to meaningful errors
+**********************************************************************/
+func (a *DBBuildMark) Error(ctx context.Context, q string, e error) error {
+	if e == nil {
+		return nil
+	}
+	return errors.Errorf("[table="+a.SQLTablename+", query=%s] Error: %s", q, e)
+}
+
@@ -0,0 +1,807 @@
+package db
+
+/*
+ This file was created by mkdb-client.
+ The intention is not to modify this file, but you may extend the struct DBBuildRecord
+ in a seperate file (so that you can regenerate this one from time to time)
+*/
+
+/*
+ PRIMARY KEY: ID
+*/
+
+/*
+ postgres:
+ create sequence buildrecord_seq;
+
+Main Table:
+
+ CREATE TABLE buildrecord (id integer primary key default nextval('buildrecord_seq'),artefactid bigint not null  ,branch text not null  ,buildid bigint not null  ,created integer not null  ,lastdownload integer not null  ,commitid text not null  ,commitmessage text not null  ,builduserid text not null  ,buildhost text not null  );
+
+Alter statements:
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS branch text not null default '';
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS buildid bigint not null default 0;
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS created integer not null default 0;
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS lastdownload integer not null default 0;
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS commitid text not null default '';
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS commitmessage text not null default '';
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS builduserid text not null default '';
+ALTER TABLE buildrecord ADD COLUMN IF NOT EXISTS buildhost text not null default '';
+
+
+Archive Table: (structs can be moved from main to archive using Archive() function)
+
+ CREATE TABLE buildrecord_archive (id integer unique not null,artefactid bigint not null,branch text not null,buildid bigint not null,created integer not null,lastdownload integer not null,commitid text not null,commitmessage text not null,builduserid text not null,buildhost text not null);
+*/
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	savepb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/sql"
+	"os"
+	"sync"
+)
+
+var (
+	default_def_DBBuildRecord *DBBuildRecord
+)
+
+type DBBuildRecord struct {
+	DB                   *sql.DB
+	SQLTablename         string
+	SQLArchivetablename  string
+	customColumnHandlers []CustomColumnHandler
+	lock                 sync.Mutex
+}
+
+func init() {
+	RegisterDBHandlerFactory(func() Handler {
+		return DefaultDBBuildRecord()
+	})
+}
+
+func DefaultDBBuildRecord() *DBBuildRecord {
+	if default_def_DBBuildRecord != nil {
+		return default_def_DBBuildRecord
+	}
+	psql, err := sql.Open()
+	if err != nil {
+		fmt.Printf("Failed to open database: %s\n", err)
+		os.Exit(10)
+	}
+	res := NewDBBuildRecord(psql)
+	ctx := context.Background()
+	err = res.CreateTable(ctx)
+	if err != nil {
+		fmt.Printf("Failed to create table: %s\n", err)
+		os.Exit(10)
+	}
+	default_def_DBBuildRecord = res
+	return res
+}
+func NewDBBuildRecord(db *sql.DB) *DBBuildRecord {
+	foo := DBBuildRecord{DB: db}
+	foo.SQLTablename = "buildrecord"
+	foo.SQLArchivetablename = "buildrecord_archive"
+	return &foo
+}
+
+func (a *DBBuildRecord) GetCustomColumnHandlers() []CustomColumnHandler {
+	return a.customColumnHandlers
+}
+func (a *DBBuildRecord) AddCustomColumnHandler(w CustomColumnHandler) {
+	a.lock.Lock()
+	a.customColumnHandlers = append(a.customColumnHandlers, w)
+	a.lock.Unlock()
+}
+
+func (a *DBBuildRecord) NewQuery() *Query {
+	return newQuery(a)
+}
+
+// archive. It is NOT transactionally save.
+func (a *DBBuildRecord) Archive(ctx context.Context, id uint64) error {
+
+	// load it
+	p, err := a.ByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// now save it to archive:
+	_, e := a.DB.ExecContext(ctx, "archive_DBBuildRecord", "insert into "+a.SQLArchivetablename+" (id,artefactid, branch, buildid, created, lastdownload, commitid, commitmessage, builduserid, buildhost) values ($1,$2, $3, $4, $5, $6, $7, $8, $9, $10) ", p.ID, p.ArtefactID, p.Branch, p.BuildID, p.Created, p.LastDownload, p.CommitID, p.CommitMessage, p.BuildUserID, p.BuildHost)
+	if e != nil {
+		return e
+	}
+
+	// now delete it.
+	a.DeleteByID(ctx, id)
+	return nil
+}
+
+// return a map with columnname -> value_from_proto
+func (a *DBBuildRecord) buildSaveMap(ctx context.Context, p *savepb.BuildRecord) (map[string]interface{}, error) {
+	extra, err := extraFieldsToStore(ctx, a, p)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	res["id"] = a.get_col_from_proto(p, "id")
+	res["artefactid"] = a.get_col_from_proto(p, "artefactid")
+	res["branch"] = a.get_col_from_proto(p, "branch")
+	res["buildid"] = a.get_col_from_proto(p, "buildid")
+	res["created"] = a.get_col_from_proto(p, "created")
+	res["lastdownload"] = a.get_col_from_proto(p, "lastdownload")
+	res["commitid"] = a.get_col_from_proto(p, "commitid")
+	res["commitmessage"] = a.get_col_from_proto(p, "commitmessage")
+	res["builduserid"] = a.get_col_from_proto(p, "builduserid")
+	res["buildhost"] = a.get_col_from_proto(p, "buildhost")
+	if extra != nil {
+		for k, v := range extra {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (a *DBBuildRecord) Save(ctx context.Context, p *savepb.BuildRecord) (uint64, error) {
+	qn := "save_DBBuildRecord"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	delete(smap, "id") // save without id
+	return a.saveMap(ctx, qn, smap, p)
+}
+
+// Save using the ID specified
+func (a *DBBuildRecord) SaveWithID(ctx context.Context, p *savepb.BuildRecord) error {
+	qn := "insert_DBBuildRecord"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, err = a.saveMap(ctx, qn, smap, p)
+	return err
+}
+
+// use a hashmap of columnname->values to store to database (see buildSaveMap())
+func (a *DBBuildRecord) saveMap(ctx context.Context, queryname string, smap map[string]interface{}, p *savepb.BuildRecord) (uint64, error) {
+	// Save (and use database default ID generation)
+
+	var rows *gosql.Rows
+	var e error
+
+	q_cols := ""
+	q_valnames := ""
+	q_vals := make([]interface{}, 0)
+	deli := ""
+	i := 0
+	// build the 2 parts of the query (column names and value names) as well as the values themselves
+	for colname, val := range smap {
+		q_cols = q_cols + deli + colname
+		i++
+		q_valnames = q_valnames + deli + fmt.Sprintf("$%d", i)
+		q_vals = append(q_vals, val)
+		deli = ","
+	}
+	rows, e = a.DB.QueryContext(ctx, queryname, "insert into "+a.SQLTablename+" ("+q_cols+") values ("+q_valnames+") returning id", q_vals...)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, e)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, a.Error(ctx, queryname, errors.Errorf("No rows after insert"))
+	}
+	var id uint64
+	e = rows.Scan(&id)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, errors.Errorf("failed to scan id after insert: %s", e))
+	}
+	p.ID = id
+	return id, nil
+}
+
+// if ID==0 save, otherwise update
+func (a *DBBuildRecord) SaveOrUpdate(ctx context.Context, p *savepb.BuildRecord) error {
+	if p.ID == 0 {
+		_, err := a.Save(ctx, p)
+		return err
+	}
+	return a.Update(ctx, p)
+}
+func (a *DBBuildRecord) Update(ctx context.Context, p *savepb.BuildRecord) error {
+	qn := "DBBuildRecord_Update"
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set artefactid=$1, branch=$2, buildid=$3, created=$4, lastdownload=$5, commitid=$6, commitmessage=$7, builduserid=$8, buildhost=$9 where id = $10", a.get_ArtefactID(p), a.get_Branch(p), a.get_BuildID(p), a.get_Created(p), a.get_LastDownload(p), a.get_CommitID(p), a.get_CommitMessage(p), a.get_BuildUserID(p), a.get_BuildHost(p), p.ID)
+
+	return a.Error(ctx, qn, e)
+}
+
+// delete by id field
+func (a *DBBuildRecord) DeleteByID(ctx context.Context, p uint64) error {
+	qn := "deleteDBBuildRecord_ByID"
+	_, e := a.DB.ExecContext(ctx, qn, "delete from "+a.SQLTablename+" where id = $1", p)
+	return a.Error(ctx, qn, e)
+}
+
+// get it by primary id
+func (a *DBBuildRecord) ByID(ctx context.Context, p uint64) (*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, a.Error(ctx, qn, errors.Errorf("No BuildRecord with id %v", p))
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) BuildRecord with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by primary id (nil if no such ID row, but no error either)
+func (a *DBBuildRecord) TryByID(ctx context.Context, p uint64) (*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_TryByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, nil
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) BuildRecord with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by multiple primary ids
+func (a *DBBuildRecord) ByIDs(ctx context.Context, p []uint64) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByIDs"
+	l, e := a.fromQuery(ctx, qn, "id in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all rows
+func (a *DBBuildRecord) All(ctx context.Context) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_all"
+	l, e := a.fromQuery(ctx, qn, "true")
+	if e != nil {
+		return nil, errors.Errorf("All: error scanning (%s)", e)
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* GetBy[FIELD] functions
+**********************************************************************/
+
+// get all "DBBuildRecord" rows with matching ArtefactID
+func (a *DBBuildRecord) ByArtefactID(ctx context.Context, p uint64) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching ArtefactID
+func (a *DBBuildRecord) ByMultiArtefactID(ctx context.Context, p []uint64) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeArtefactID(ctx context.Context, p uint64) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with matching Branch
+func (a *DBBuildRecord) ByBranch(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching Branch
+func (a *DBBuildRecord) ByMultiBranch(ctx context.Context, p []string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeBranch(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeBranch"
+	l, e := a.fromQuery(ctx, qn, "branch ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with matching BuildID
+func (a *DBBuildRecord) ByBuildID(ctx context.Context, p uint64) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching BuildID
+func (a *DBBuildRecord) ByMultiBuildID(ctx context.Context, p []uint64) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeBuildID(ctx context.Context, p uint64) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with matching Created
+func (a *DBBuildRecord) ByCreated(ctx context.Context, p uint32) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByCreated"
+	l, e := a.fromQuery(ctx, qn, "created = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching Created
+func (a *DBBuildRecord) ByMultiCreated(ctx context.Context, p []uint32) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByCreated"
+	l, e := a.fromQuery(ctx, qn, "created in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeCreated(ctx context.Context, p uint32) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeCreated"
+	l, e := a.fromQuery(ctx, qn, "created ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with matching LastDownload
+func (a *DBBuildRecord) ByLastDownload(ctx context.Context, p uint32) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLastDownload"
+	l, e := a.fromQuery(ctx, qn, "lastdownload = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByLastDownload: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching LastDownload
+func (a *DBBuildRecord) ByMultiLastDownload(ctx context.Context, p []uint32) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLastDownload"
+	l, e := a.fromQuery(ctx, qn, "lastdownload in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByLastDownload: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeLastDownload(ctx context.Context, p uint32) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeLastDownload"
+	l, e := a.fromQuery(ctx, qn, "lastdownload ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByLastDownload: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with matching CommitID
+func (a *DBBuildRecord) ByCommitID(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByCommitID"
+	l, e := a.fromQuery(ctx, qn, "commitid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCommitID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching CommitID
+func (a *DBBuildRecord) ByMultiCommitID(ctx context.Context, p []string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByCommitID"
+	l, e := a.fromQuery(ctx, qn, "commitid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCommitID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeCommitID(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeCommitID"
+	l, e := a.fromQuery(ctx, qn, "commitid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCommitID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with matching CommitMessage
+func (a *DBBuildRecord) ByCommitMessage(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByCommitMessage"
+	l, e := a.fromQuery(ctx, qn, "commitmessage = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCommitMessage: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching CommitMessage
+func (a *DBBuildRecord) ByMultiCommitMessage(ctx context.Context, p []string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByCommitMessage"
+	l, e := a.fromQuery(ctx, qn, "commitmessage in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCommitMessage: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeCommitMessage(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeCommitMessage"
+	l, e := a.fromQuery(ctx, qn, "commitmessage ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCommitMessage: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with matching BuildUserID
+func (a *DBBuildRecord) ByBuildUserID(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByBuildUserID"
+	l, e := a.fromQuery(ctx, qn, "builduserid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching BuildUserID
+func (a *DBBuildRecord) ByMultiBuildUserID(ctx context.Context, p []string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByBuildUserID"
+	l, e := a.fromQuery(ctx, qn, "builduserid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeBuildUserID(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeBuildUserID"
+	l, e := a.fromQuery(ctx, qn, "builduserid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with matching BuildHost
+func (a *DBBuildRecord) ByBuildHost(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByBuildHost"
+	l, e := a.fromQuery(ctx, qn, "buildhost = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildHost: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBBuildRecord" rows with multiple matching BuildHost
+func (a *DBBuildRecord) ByMultiBuildHost(ctx context.Context, p []string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByBuildHost"
+	l, e := a.fromQuery(ctx, qn, "buildhost in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildHost: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBBuildRecord) ByLikeBuildHost(ctx context.Context, p string) ([]*savepb.BuildRecord, error) {
+	qn := "DBBuildRecord_ByLikeBuildHost"
+	l, e := a.fromQuery(ctx, qn, "buildhost ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildHost: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* The field getters
+**********************************************************************/
+
+// getter for field "ID" (ID) [uint64]
+func (a *DBBuildRecord) get_ID(p *savepb.BuildRecord) uint64 {
+	return uint64(p.ID)
+}
+
+// getter for field "ArtefactID" (ArtefactID) [uint64]
+func (a *DBBuildRecord) get_ArtefactID(p *savepb.BuildRecord) uint64 {
+	return uint64(p.ArtefactID)
+}
+
+// getter for field "Branch" (Branch) [string]
+func (a *DBBuildRecord) get_Branch(p *savepb.BuildRecord) string {
+	return string(p.Branch)
+}
+
+// getter for field "BuildID" (BuildID) [uint64]
+func (a *DBBuildRecord) get_BuildID(p *savepb.BuildRecord) uint64 {
+	return uint64(p.BuildID)
+}
+
+// getter for field "Created" (Created) [uint32]
+func (a *DBBuildRecord) get_Created(p *savepb.BuildRecord) uint32 {
+	return uint32(p.Created)
+}
+
+// getter for field "LastDownload" (LastDownload) [uint32]
+func (a *DBBuildRecord) get_LastDownload(p *savepb.BuildRecord) uint32 {
+	return uint32(p.LastDownload)
+}
+
+// getter for field "CommitID" (CommitID) [string]
+func (a *DBBuildRecord) get_CommitID(p *savepb.BuildRecord) string {
+	return string(p.CommitID)
+}
+
+// getter for field "CommitMessage" (CommitMessage) [string]
+func (a *DBBuildRecord) get_CommitMessage(p *savepb.BuildRecord) string {
+	return string(p.CommitMessage)
+}
+
+// getter for field "BuildUserID" (BuildUserID) [string]
+func (a *DBBuildRecord) get_BuildUserID(p *savepb.BuildRecord) string {
+	return string(p.BuildUserID)
+}
+
+// getter for field "BuildHost" (BuildHost) [string]
+func (a *DBBuildRecord) get_BuildHost(p *savepb.BuildRecord) string {
+	return string(p.BuildHost)
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Query
+**********************************************************************/
+
+// from a query snippet (the part after WHERE)
+func (a *DBBuildRecord) ByDBQuery(ctx context.Context, query *Query) ([]*savepb.BuildRecord, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for col_name, value := range extra_fields {
+		i++
+		/*
+		   efname:=fmt.Sprintf("EXTRA_FIELD_%d",i)
+		   query.Add(col_name+" = "+efname,QP{efname:value})
+		*/
+		query.AddEqual(col_name, value)
+	}
+
+	gw, paras := query.ToPostgres()
+	queryname := "custom_dbquery"
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where "+gw, paras...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+
+}
+
+func (a *DBBuildRecord) FromQuery(ctx context.Context, query_where string, args ...interface{}) ([]*savepb.BuildRecord, error) {
+	return a.fromQuery(ctx, "custom_query_"+a.Tablename(), query_where, args...)
+}
+
+// from a query snippet (the part after WHERE)
+func (a *DBBuildRecord) fromQuery(ctx context.Context, queryname string, query_where string, args ...interface{}) ([]*savepb.BuildRecord, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	eq := ""
+	if extra_fields != nil && len(extra_fields) > 0 {
+		eq = " AND ("
+		// build the extraquery "eq"
+		i := len(args)
+		deli := ""
+		for col_name, value := range extra_fields {
+			i++
+			eq = eq + deli + col_name + fmt.Sprintf(" = $%d", i)
+			deli = " AND "
+			args = append(args, value)
+		}
+		eq = eq + ")"
+	}
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where ( "+query_where+") "+eq, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Row to struct
+**********************************************************************/
+func (a *DBBuildRecord) get_col_from_proto(p *savepb.BuildRecord, colname string) interface{} {
+	if colname == "id" {
+		return a.get_ID(p)
+	} else if colname == "artefactid" {
+		return a.get_ArtefactID(p)
+	} else if colname == "branch" {
+		return a.get_Branch(p)
+	} else if colname == "buildid" {
+		return a.get_BuildID(p)
+	} else if colname == "created" {
+		return a.get_Created(p)
+	} else if colname == "lastdownload" {
+		return a.get_LastDownload(p)
+	} else if colname == "commitid" {
+		return a.get_CommitID(p)
+	} else if colname == "commitmessage" {
+		return a.get_CommitMessage(p)
+	} else if colname == "builduserid" {
+		return a.get_BuildUserID(p)
+	} else if colname == "buildhost" {
+		return a.get_BuildHost(p)
+	}
+	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
+}
+
+func (a *DBBuildRecord) Tablename() string {
+	return a.SQLTablename
+}
+
+func (a *DBBuildRecord) SelectCols() string {
+	return "id,artefactid, branch, buildid, created, lastdownload, commitid, commitmessage, builduserid, buildhost"
+}
+func (a *DBBuildRecord) SelectColsQualified() string {
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".artefactid, " + a.SQLTablename + ".branch, " + a.SQLTablename + ".buildid, " + a.SQLTablename + ".created, " + a.SQLTablename + ".lastdownload, " + a.SQLTablename + ".commitid, " + a.SQLTablename + ".commitmessage, " + a.SQLTablename + ".builduserid, " + a.SQLTablename + ".buildhost"
+}
+
+func (a *DBBuildRecord) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.BuildRecord, error) {
+	var res []*savepb.BuildRecord
+	for rows.Next() {
+		// SCANNER:
+		foo := &savepb.BuildRecord{}
+		// create the non-nullable pointers
+		// create variables for scan results
+		scanTarget_0 := &foo.ID
+		scanTarget_1 := &foo.ArtefactID
+		scanTarget_2 := &foo.Branch
+		scanTarget_3 := &foo.BuildID
+		scanTarget_4 := &foo.Created
+		scanTarget_5 := &foo.LastDownload
+		scanTarget_6 := &foo.CommitID
+		scanTarget_7 := &foo.CommitMessage
+		scanTarget_8 := &foo.BuildUserID
+		scanTarget_9 := &foo.BuildHost
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4, scanTarget_5, scanTarget_6, scanTarget_7, scanTarget_8, scanTarget_9)
+		// END SCANNER
+
+		if err != nil {
+			return nil, a.Error(ctx, "fromrow-scan", err)
+		}
+		res = append(res, foo)
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to create table and columns
+**********************************************************************/
+func (a *DBBuildRecord) CreateTable(ctx context.Context) error {
+	csql := []string{
+		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,branch text not null ,buildid bigint not null ,created integer not null ,lastdownload integer not null ,commitid text not null ,commitmessage text not null ,builduserid text not null ,buildhost text not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,branch text not null ,buildid bigint not null ,created integer not null ,lastdownload integer not null ,commitid text not null ,commitmessage text not null ,builduserid text not null ,buildhost text not null );`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS branch text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS buildid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS created integer not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS lastdownload integer not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS commitid text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS commitmessage text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS builduserid text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS buildhost text not null default '';`,
+
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS artefactid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS branch text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS buildid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS created integer not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS lastdownload integer not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS commitid text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS commitmessage text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS builduserid text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS buildhost text not null  default '';`,
+	}
+
+	for i, c := range csql {
+		_, e := a.DB.ExecContext(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+		if e != nil {
+			return e
+		}
+	}
+
+	// these are optional, expected to fail
+	csql = []string{
+		// Indices:
+
+		// Foreign keys:
+
+	}
+	for i, c := range csql {
+		a.DB.ExecContextQuiet(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+	}
+	return nil
+}
+
+/**********************************************************************
+* Helper to meaningful errors
+**********************************************************************/
+func (a *DBBuildRecord) Error(ctx context.Context, q string, e error) error {
+	if e == nil {
+		return nil
+	}
+	return errors.Errorf("[table="+a.SQLTablename+", query=%s] Error: %s", q, e)
+}
+
@@ -0,0 +1,681 @@
+package db
+
+/*
+ This file was created by mkdb-client.
+ The intention is not to modify this file, but you may extend the struct DBChannel
+ in a seperate file (so that you can regenerate this one from time to time)
+*/
+
+/*
+ PRIMARY KEY: ID
+*/
+
+/*
+ postgres:
+ create sequence channel_seq;
+
+Main Table:
+
+ CREATE TABLE channel (id integer primary key default nextval('channel_seq'),artefactid bigint not null  ,name text not null  ,branch text not null  ,buildid bigint not null  ,updatedby text not null  ,updated integer not null  );
+
+Alter statements:
+ALTER TABLE channel ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;
+ALTER TABLE channel ADD COLUMN IF NOT EXISTS name text not null default '';
+ALTER TABLE channel ADD COLUMN IF NOT EXISTS branch text not null default '';
+ALTER TABLE channel ADD COLUMN IF NOT EXISTS buildid bigint not null default 0;
+ALTER TABLE channel ADD COLUMN IF NOT EXISTS updatedby text not null default '';
+ALTER TABLE channel ADD COLUMN IF NOT EXISTS updated integer not null default 0;
+
+
+Archive Table: (structs can be moved from main to archive using Archive() function)
+
+ CREATE TABLE channel_archive (id integer unique not null,artefactid bigint not null,name text not null,branch text not null,buildid bigint not null,updatedby text not null,updated integer not null);
+*/
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	savepb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/sql"
+	"os"
+	"sync"
+)
+
+var (
+	default_def_DBChannel *DBChannel
+)
+
+type DBChannel struct {
+	DB                   *sql.DB
+	SQLTablename         string
+	SQLArchivetablename  string
+	customColumnHandlers []CustomColumnHandler
+	lock                 sync.Mutex
+}
+
+func init() {
+	RegisterDBHandlerFactory(func() Handler {
+		return DefaultDBChannel()
+	})
+}
+
+func DefaultDBChannel() *DBChannel {
+	if default_def_DBChannel != nil {
+		return default_def_DBChannel
+	}
+	psql, err := sql.Open()
+	if err != nil {
+		fmt.Printf("Failed to open database: %s\n", err)
+		os.Exit(10)
+	}
+	res := NewDBChannel(psql)
+	ctx := context.Background()
+	err = res.CreateTable(ctx)
+	if err != nil {
+		fmt.Printf("Failed to create table: %s\n", err)
+		os.Exit(10)
+	}
+	default_def_DBChannel = res
+	return res
+}
+func NewDBChannel(db *sql.DB) *DBChannel {
+	foo := DBChannel{DB: db}
+	foo.SQLTablename = "channel"
+	foo.SQLArchivetablename = "channel_archive"
+	return &foo
+}
+
+func (a *DBChannel) GetCustomColumnHandlers() []CustomColumnHandler {
+	return a.customColumnHandlers
+}
+func (a *DBChannel) AddCustomColumnHandler(w CustomColumnHandler) {
+	a.lock.Lock()
+	a.customColumnHandlers = append(a.customColumnHandlers, w)
+	a.lock.Unlock()
+}
+
+func (a *DBChannel) NewQuery() *Query {
+	return newQuery(a)
+}
+
+// archive. It is NOT transactionally save.
+func (a *DBChannel) Archive(ctx context.Context, id uint64) error {
+
+	// load it
+	p, err := a.ByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// now save it to archive:
+	_, e := a.DB.ExecContext(ctx, "archive_DBChannel", "insert into "+a.SQLArchivetablename+" (id,artefactid, name, branch, buildid, updatedby, updated) values ($1,$2, $3, $4, $5, $6, $7) ", p.ID, p.ArtefactID, p.Name, p.Branch, p.BuildID, p.UpdatedBy, p.Updated)
+	if e != nil {
+		return e
+	}
+
+	// now delete it.
+	a.DeleteByID(ctx, id)
+	return nil
+}
+
+// return a map with columnname -> value_from_proto
+func (a *DBChannel) buildSaveMap(ctx context.Context, p *savepb.Channel) (map[string]interface{}, error) {
+	extra, err := extraFieldsToStore(ctx, a, p)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	res["id"] = a.get_col_from_proto(p, "id")
+	res["artefactid"] = a.get_col_from_proto(p, "artefactid")
+	res["name"] = a.get_col_from_proto(p, "name")
+	res["branch"] = a.get_col_from_proto(p, "branch")
+	res["buildid"] = a.get_col_from_proto(p, "buildid")
+	res["updatedby"] = a.get_col_from_proto(p, "updatedby")
+	res["updated"] = a.get_col_from_proto(p, "updated")
+	if extra != nil {
+		for k, v := range extra {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (a *DBChannel) Save(ctx context.Context, p *savepb.Channel) (uint64, error) {
+	qn := "save_DBChannel"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	delete(smap, "id") // save without id
+	return a.saveMap(ctx, qn, smap, p)
+}
+
+// Save using the ID specified
+func (a *DBChannel) SaveWithID(ctx context.Context, p *savepb.Channel) error {
+	qn := "insert_DBChannel"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, err = a.saveMap(ctx, qn, smap, p)
+	return err
+}
+
+// use a hashmap of columnname->values to store to database (see buildSaveMap())
+func (a *DBChannel) saveMap(ctx context.Context, queryname string, smap map[string]interface{}, p *savepb.Channel) (uint64, error) {
+	// Save (and use database default ID generation)
+
+	var rows *gosql.Rows
+	var e error
+
+	q_cols := ""
+	q_valnames := ""
+	q_vals := make([]interface{}, 0)
+	deli := ""
+	i := 0
+	// build the 2 parts of the query (column names and value names) as well as the values themselves
+	for colname, val := range smap {
+		q_cols = q_cols + deli + colname
+		i++
+		q_valnames = q_valnames + deli + fmt.Sprintf("$%d", i)
+		q_vals = append(q_vals, val)
+		deli = ","
+	}
+	rows, e = a.DB.QueryContext(ctx, queryname, "insert into "+a.SQLTablename+" ("+q_cols+") values ("+q_valnames+") returning id", q_vals...)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, e)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, a.Error(ctx, queryname, errors.Errorf("No rows after insert"))
+	}
+	var id uint64
+	e = rows.Scan(&id)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, errors.Errorf("failed to scan id after insert: %s", e))
+	}
+	p.ID = id
+	return id, nil
+}
+
+// if ID==0 save, otherwise update
+func (a *DBChannel) SaveOrUpdate(ctx context.Context, p *savepb.Channel) error {
+	if p.ID == 0 {
+		_, err := a.Save(ctx, p)
+		return err
+	}
+	return a.Update(ctx, p)
+}
+func (a *DBChannel) Update(ctx context.Context, p *savepb.Channel) error {
+	qn := "DBChannel_Update"
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set artefactid=$1, name=$2, branch=$3, buildid=$4, updatedby=$5, updated=$6 where id = $7", a.get_ArtefactID(p), a.get_Name(p), a.get_Branch(p), a.get_BuildID(p), a.get_UpdatedBy(p), a.get_Updated(p), p.ID)
+
+	return a.Error(ctx, qn, e)
+}
+
+// delete by id field
+func (a *DBChannel) DeleteByID(ctx context.Context, p uint64) error {
+	qn := "deleteDBChannel_ByID"
+	_, e := a.DB.ExecContext(ctx, qn, "delete from "+a.SQLTablename+" where id = $1", p)
+	return a.Error(ctx, qn, e)
+}
+
+// get it by primary id
+func (a *DBChannel) ByID(ctx context.Context, p uint64) (*savepb.Channel, error) {
+	qn := "DBChannel_ByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, a.Error(ctx, qn, errors.Errorf("No Channel with id %v", p))
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) Channel with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by primary id (nil if no such ID row, but no error either)
+func (a *DBChannel) TryByID(ctx context.Context, p uint64) (*savepb.Channel, error) {
+	qn := "DBChannel_TryByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, nil
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) Channel with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by multiple primary ids
+func (a *DBChannel) ByIDs(ctx context.Context, p []uint64) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByIDs"
+	l, e := a.fromQuery(ctx, qn, "id in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all rows
+func (a *DBChannel) All(ctx context.Context) ([]*savepb.Channel, error) {
+	qn := "DBChannel_all"
+	l, e := a.fromQuery(ctx, qn, "true")
+	if e != nil {
+		return nil, errors.Errorf("All: error scanning (%s)", e)
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* GetBy[FIELD] functions
+**********************************************************************/
+
+// get all "DBChannel" rows with matching ArtefactID
+func (a *DBChannel) ByArtefactID(ctx context.Context, p uint64) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with multiple matching ArtefactID
+func (a *DBChannel) ByMultiArtefactID(ctx context.Context, p []uint64) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannel) ByLikeArtefactID(ctx context.Context, p uint64) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByLikeArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with matching Name
+func (a *DBChannel) ByName(ctx context.Context, p string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByName"
+	l, e := a.fromQuery(ctx, qn, "name = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByName: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with multiple matching Name
+func (a *DBChannel) ByMultiName(ctx context.Context, p []string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByName"
+	l, e := a.fromQuery(ctx, qn, "name in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByName: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannel) ByLikeName(ctx context.Context, p string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByLikeName"
+	l, e := a.fromQuery(ctx, qn, "name ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByName: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with matching Branch
+func (a *DBChannel) ByBranch(ctx context.Context, p string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with multiple matching Branch
+func (a *DBChannel) ByMultiBranch(ctx context.Context, p []string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannel) ByLikeBranch(ctx context.Context, p string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByLikeBranch"
+	l, e := a.fromQuery(ctx, qn, "branch ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with matching BuildID
+func (a *DBChannel) ByBuildID(ctx context.Context, p uint64) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with multiple matching BuildID
+func (a *DBChannel) ByMultiBuildID(ctx context.Context, p []uint64) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannel) ByLikeBuildID(ctx context.Context, p uint64) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByLikeBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with matching UpdatedBy
+func (a *DBChannel) ByUpdatedBy(ctx context.Context, p string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByUpdatedBy"
+	l, e := a.fromQuery(ctx, qn, "updatedby = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with multiple matching UpdatedBy
+func (a *DBChannel) ByMultiUpdatedBy(ctx context.Context, p []string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByUpdatedBy"
+	l, e := a.fromQuery(ctx, qn, "updatedby in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannel) ByLikeUpdatedBy(ctx context.Context, p string) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByLikeUpdatedBy"
+	l, e := a.fromQuery(ctx, qn, "updatedby ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with matching Updated
+func (a *DBChannel) ByUpdated(ctx context.Context, p uint32) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannel" rows with multiple matching Updated
+func (a *DBChannel) ByMultiUpdated(ctx context.Context, p []uint32) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannel) ByLikeUpdated(ctx context.Context, p uint32) ([]*savepb.Channel, error) {
+	qn := "DBChannel_ByLikeUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* The field getters
+**********************************************************************/
+
+// getter for field "ID" (ID) [uint64]
+func (a *DBChannel) get_ID(p *savepb.Channel) uint64 {
+	return uint64(p.ID)
+}
+
+// getter for field "ArtefactID" (ArtefactID) [uint64]
+func (a *DBChannel) get_ArtefactID(p *savepb.Channel) uint64 {
+	return uint64(p.ArtefactID)
+}
+
+// getter for field "Name" (Name) [string]
+func (a *DBChannel) get_Name(p *savepb.Channel) string {
+	return string(p.Name)
+}
+
+// getter for field "Branch" (Branch) [string]
+func (a *DBChannel) get_Branch(p *savepb.Channel) string {
+	return string(p.Branch)
+}
+
+// getter for field "BuildID" (BuildID) [uint64]
+func (a *DBChannel) get_BuildID(p *savepb.Channel) uint64 {
+	return uint64(p.BuildID)
+}
+
+// getter for field "UpdatedBy" (UpdatedBy) [string]
+func (a *DBChannel) get_UpdatedBy(p *savepb.Channel) string {
+	return string(p.UpdatedBy)
+}
+
+// getter for field "Updated" (Updated) [uint32]
+func (a *DBChannel) get_Updated(p *savepb.Channel) uint32 {
+	return uint32(p.Updated)
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Query
+**********************************************************************/
+
+// from a query snippet (the part after WHERE)
+func (a *DBChannel) ByDBQuery(ctx context.Context, query *Query) ([]*savepb.Channel, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for col_name, value := range extra_fields {
+		i++
+		/*
+		   efname:=fmt.Sprintf("EXTRA_FIELD_%d",i)
+		   query.Add(col_name+" = "+efname,QP{efname:value})
+		*/
+		query.AddEqual(col_name, value)
+	}
+
+	gw, paras := query.ToPostgres()
+	queryname := "custom_dbquery"
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where "+gw, paras...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+
+}
+
+func (a *DBChannel) FromQuery(ctx context.Context, query_where string, args ...interface{}) ([]*savepb.Channel, error) {
+	return a.fromQuery(ctx, "custom_query_"+a.Tablename(), query_where, args...)
+}
+
+// from a query snippet (the part after WHERE)
+func (a *DBChannel) fromQuery(ctx context.Context, queryname string, query_where string, args ...interface{}) ([]*savepb.Channel, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	eq := ""
+	if extra_fields != nil && len(extra_fields) > 0 {
+		eq = " AND ("
+		// build the extraquery "eq"
+		i := len(args)
+		deli := ""
+		for col_name, value := range extra_fields {
+			i++
+			eq = eq + deli + col_name + fmt.Sprintf(" = $%d", i)
+			deli = " AND "
+			args = append(args, value)
+		}
+		eq = eq + ")"
+	}
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where ( "+query_where+") "+eq, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Row to struct
+**********************************************************************/
+func (a *DBChannel) get_col_from_proto(p *savepb.Channel, colname string) interface{} {
+	if colname == "id" {
+		return a.get_ID(p)
+	} else if colname == "artefactid" {
+		return a.get_ArtefactID(p)
+	} else if colname == "name" {
+		return a.get_Name(p)
+	} else if colname == "branch" {
+		return a.get_Branch(p)
+	} else if colname == "buildid" {
+		return a.get_BuildID(p)
+	} else if colname == "updatedby" {
+		return a.get_UpdatedBy(p)
+	} else if colname == "updated" {
+		return a.get_Updated(p)
+	}
+	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
+}
+
+func (a *DBChannel) Tablename() string {
+	return a.SQLTablename
+}
+
+func (a *DBChannel) SelectCols() string {
+	return "id,artefactid, name, branch, buildid, updatedby, updated"
+}
+func (a *DBChannel) SelectColsQualified() string {
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".artefactid, " + a.SQLTablename + ".name, " + a.SQLTablename + ".branch, " + a.SQLTablename + ".buildid, " + a.SQLTablename + ".updatedby, " + a.SQLTablename + ".updated"
+}
+
+func (a *DBChannel) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.Channel, error) {
+	var res []*savepb.Channel
+	for rows.Next() {
+		// SCANNER:
+		foo := &savepb.Channel{}
+		// create the non-nullable pointers
+		// create variables for scan results
+		scanTarget_0 := &foo.ID
+		scanTarget_1 := &foo.ArtefactID
+		scanTarget_2 := &foo.Name
+		scanTarget_3 := &foo.Branch
+		scanTarget_4 := &foo.BuildID
+		scanTarget_5 := &foo.UpdatedBy
+		scanTarget_6 := &foo.Updated
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4, scanTarget_5, scanTarget_6)
+		// END SCANNER
+
+		if err != nil {
+			return nil, a.Error(ctx, "fromrow-scan", err)
+		}
+		res = append(res, foo)
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to create table and columns
+**********************************************************************/
+func (a *DBChannel) CreateTable(ctx context.Context) error {
+	csql := []string{
+		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,name text not null ,branch text not null ,buildid bigint not null ,updatedby text not null ,updated integer not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,name text not null ,branch text not null ,buildid bigint not null ,updatedby text not null ,updated integer not null );`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS name text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS branch text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS buildid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS updatedby text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS updated integer not null default 0;`,
+
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS artefactid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS name text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS branch text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS buildid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS updatedby text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS updated integer not null  default 0;`,
+	}
+
+	for i, c := range csql {
+		_, e := a.DB.ExecContext(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+		if e != nil {
+			return e
+		}
+	}
+
+	// these are optional, expected to fail
+	csql = []string{
+		// Indices:
+
+		// Foreign keys:
+
+	}
+	for i, c := range csql {
+		a.DB.ExecContextQuiet(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+	}
+	return nil
+}
+
+/**********************************************************************
+* Helper to meaningful errors
+**********************************************************************/
+func (a *DBChannel) Error(ctx context.Context, q string, e error) error {
+	if e == nil {
+		return nil
+	}
+	return errors.Errorf("[table="+a.SQLTablename+", query=%s] Error: %s", q, e)
+}
+
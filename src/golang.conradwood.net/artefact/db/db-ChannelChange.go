@@ -0,0 +1,723 @@
+package db
+
+/*
+ This file was created by mkdb-client.
+ The intention is not to modify this file, but you may extend the struct DBChannelChange
+ in a seperate file (so that you can regenerate this one from time to time)
+*/
+
+/*
+ PRIMARY KEY: ID
+*/
+
+/*
+ postgres:
+ create sequence channelchange_seq;
+
+Main Table:
+
+ CREATE TABLE channelchange (id integer primary key default nextval('channelchange_seq'),artefactid bigint not null  ,channel text not null  ,branch text not null  ,oldbuildid bigint not null  ,newbuildid bigint not null  ,userid text not null  ,timestamp integer not null  );
+
+Alter statements:
+ALTER TABLE channelchange ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;
+ALTER TABLE channelchange ADD COLUMN IF NOT EXISTS channel text not null default '';
+ALTER TABLE channelchange ADD COLUMN IF NOT EXISTS branch text not null default '';
+ALTER TABLE channelchange ADD COLUMN IF NOT EXISTS oldbuildid bigint not null default 0;
+ALTER TABLE channelchange ADD COLUMN IF NOT EXISTS newbuildid bigint not null default 0;
+ALTER TABLE channelchange ADD COLUMN IF NOT EXISTS userid text not null default '';
+ALTER TABLE channelchange ADD COLUMN IF NOT EXISTS timestamp integer not null default 0;
+
+
+Archive Table: (structs can be moved from main to archive using Archive() function)
+
+ CREATE TABLE channelchange_archive (id integer unique not null,artefactid bigint not null,channel text not null,branch text not null,oldbuildid bigint not null,newbuildid bigint not null,userid text not null,timestamp integer not null);
+*/
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	savepb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/sql"
+	"os"
+	"sync"
+)
+
+var (
+	default_def_DBChannelChange *DBChannelChange
+)
+
+type DBChannelChange struct {
+	DB                   *sql.DB
+	SQLTablename         string
+	SQLArchivetablename  string
+	customColumnHandlers []CustomColumnHandler
+	lock                 sync.Mutex
+}
+
+func init() {
+	RegisterDBHandlerFactory(func() Handler {
+		return DefaultDBChannelChange()
+	})
+}
+
+func DefaultDBChannelChange() *DBChannelChange {
+	if default_def_DBChannelChange != nil {
+		return default_def_DBChannelChange
+	}
+	psql, err := sql.Open()
+	if err != nil {
+		fmt.Printf("Failed to open database: %s\n", err)
+		os.Exit(10)
+	}
+	res := NewDBChannelChange(psql)
+	ctx := context.Background()
+	err = res.CreateTable(ctx)
+	if err != nil {
+		fmt.Printf("Failed to create table: %s\n", err)
+		os.Exit(10)
+	}
+	default_def_DBChannelChange = res
+	return res
+}
+func NewDBChannelChange(db *sql.DB) *DBChannelChange {
+	foo := DBChannelChange{DB: db}
+	foo.SQLTablename = "channelchange"
+	foo.SQLArchivetablename = "channelchange_archive"
+	return &foo
+}
+
+func (a *DBChannelChange) GetCustomColumnHandlers() []CustomColumnHandler {
+	return a.customColumnHandlers
+}
+func (a *DBChannelChange) AddCustomColumnHandler(w CustomColumnHandler) {
+	a.lock.Lock()
+	a.customColumnHandlers = append(a.customColumnHandlers, w)
+	a.lock.Unlock()
+}
+
+func (a *DBChannelChange) NewQuery() *Query {
+	return newQuery(a)
+}
+
+// archive. It is NOT transactionally save.
+func (a *DBChannelChange) Archive(ctx context.Context, id uint64) error {
+
+	// load it
+	p, err := a.ByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// now save it to archive:
+	_, e := a.DB.ExecContext(ctx, "archive_DBChannelChange", "insert into "+a.SQLArchivetablename+" (id,artefactid, channel, branch, oldbuildid, newbuildid, userid, timestamp) values ($1,$2, $3, $4, $5, $6, $7, $8) ", p.ID, p.ArtefactID, p.Channel, p.Branch, p.OldBuildID, p.NewBuildID, p.UserID, p.Timestamp)
+	if e != nil {
+		return e
+	}
+
+	// now delete it.
+	a.DeleteByID(ctx, id)
+	return nil
+}
+
+// return a map with columnname -> value_from_proto
+func (a *DBChannelChange) buildSaveMap(ctx context.Context, p *savepb.ChannelChange) (map[string]interface{}, error) {
+	extra, err := extraFieldsToStore(ctx, a, p)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	res["id"] = a.get_col_from_proto(p, "id")
+	res["artefactid"] = a.get_col_from_proto(p, "artefactid")
+	res["channel"] = a.get_col_from_proto(p, "channel")
+	res["branch"] = a.get_col_from_proto(p, "branch")
+	res["oldbuildid"] = a.get_col_from_proto(p, "oldbuildid")
+	res["newbuildid"] = a.get_col_from_proto(p, "newbuildid")
+	res["userid"] = a.get_col_from_proto(p, "userid")
+	res["timestamp"] = a.get_col_from_proto(p, "timestamp")
+	if extra != nil {
+		for k, v := range extra {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (a *DBChannelChange) Save(ctx context.Context, p *savepb.ChannelChange) (uint64, error) {
+	qn := "save_DBChannelChange"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	delete(smap, "id") // save without id
+	return a.saveMap(ctx, qn, smap, p)
+}
+
+// Save using the ID specified
+func (a *DBChannelChange) SaveWithID(ctx context.Context, p *savepb.ChannelChange) error {
+	qn := "insert_DBChannelChange"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, err = a.saveMap(ctx, qn, smap, p)
+	return err
+}
+
+// use a hashmap of columnname->values to store to database (see buildSaveMap())
+func (a *DBChannelChange) saveMap(ctx context.Context, queryname string, smap map[string]interface{}, p *savepb.ChannelChange) (uint64, error) {
+	// Save (and use database default ID generation)
+
+	var rows *gosql.Rows
+	var e error
+
+	q_cols := ""
+	q_valnames := ""
+	q_vals := make([]interface{}, 0)
+	deli := ""
+	i := 0
+	// build the 2 parts of the query (column names and value names) as well as the values themselves
+	for colname, val := range smap {
+		q_cols = q_cols + deli + colname
+		i++
+		q_valnames = q_valnames + deli + fmt.Sprintf("$%d", i)
+		q_vals = append(q_vals, val)
+		deli = ","
+	}
+	rows, e = a.DB.QueryContext(ctx, queryname, "insert into "+a.SQLTablename+" ("+q_cols+") values ("+q_valnames+") returning id", q_vals...)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, e)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, a.Error(ctx, queryname, errors.Errorf("No rows after insert"))
+	}
+	var id uint64
+	e = rows.Scan(&id)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, errors.Errorf("failed to scan id after insert: %s", e))
+	}
+	p.ID = id
+	return id, nil
+}
+
+// if ID==0 save, otherwise update
+func (a *DBChannelChange) SaveOrUpdate(ctx context.Context, p *savepb.ChannelChange) error {
+	if p.ID == 0 {
+		_, err := a.Save(ctx, p)
+		return err
+	}
+	return a.Update(ctx, p)
+}
+func (a *DBChannelChange) Update(ctx context.Context, p *savepb.ChannelChange) error {
+	qn := "DBChannelChange_Update"
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set artefactid=$1, channel=$2, branch=$3, oldbuildid=$4, newbuildid=$5, userid=$6, timestamp=$7 where id = $8", a.get_ArtefactID(p), a.get_Channel(p), a.get_Branch(p), a.get_OldBuildID(p), a.get_NewBuildID(p), a.get_UserID(p), a.get_Timestamp(p), p.ID)
+
+	return a.Error(ctx, qn, e)
+}
+
+// delete by id field
+func (a *DBChannelChange) DeleteByID(ctx context.Context, p uint64) error {
+	qn := "deleteDBChannelChange_ByID"
+	_, e := a.DB.ExecContext(ctx, qn, "delete from "+a.SQLTablename+" where id = $1", p)
+	return a.Error(ctx, qn, e)
+}
+
+// get it by primary id
+func (a *DBChannelChange) ByID(ctx context.Context, p uint64) (*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, a.Error(ctx, qn, errors.Errorf("No ChannelChange with id %v", p))
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) ChannelChange with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by primary id (nil if no such ID row, but no error either)
+func (a *DBChannelChange) TryByID(ctx context.Context, p uint64) (*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_TryByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, nil
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) ChannelChange with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by multiple primary ids
+func (a *DBChannelChange) ByIDs(ctx context.Context, p []uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByIDs"
+	l, e := a.fromQuery(ctx, qn, "id in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all rows
+func (a *DBChannelChange) All(ctx context.Context) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_all"
+	l, e := a.fromQuery(ctx, qn, "true")
+	if e != nil {
+		return nil, errors.Errorf("All: error scanning (%s)", e)
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* GetBy[FIELD] functions
+**********************************************************************/
+
+// get all "DBChannelChange" rows with matching ArtefactID
+func (a *DBChannelChange) ByArtefactID(ctx context.Context, p uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with multiple matching ArtefactID
+func (a *DBChannelChange) ByMultiArtefactID(ctx context.Context, p []uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannelChange) ByLikeArtefactID(ctx context.Context, p uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByLikeArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with matching Channel
+func (a *DBChannelChange) ByChannel(ctx context.Context, p string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByChannel"
+	l, e := a.fromQuery(ctx, qn, "channel = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByChannel: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with multiple matching Channel
+func (a *DBChannelChange) ByMultiChannel(ctx context.Context, p []string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByChannel"
+	l, e := a.fromQuery(ctx, qn, "channel in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByChannel: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannelChange) ByLikeChannel(ctx context.Context, p string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByLikeChannel"
+	l, e := a.fromQuery(ctx, qn, "channel ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByChannel: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with matching Branch
+func (a *DBChannelChange) ByBranch(ctx context.Context, p string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with multiple matching Branch
+func (a *DBChannelChange) ByMultiBranch(ctx context.Context, p []string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannelChange) ByLikeBranch(ctx context.Context, p string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByLikeBranch"
+	l, e := a.fromQuery(ctx, qn, "branch ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with matching OldBuildID
+func (a *DBChannelChange) ByOldBuildID(ctx context.Context, p uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByOldBuildID"
+	l, e := a.fromQuery(ctx, qn, "oldbuildid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByOldBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with multiple matching OldBuildID
+func (a *DBChannelChange) ByMultiOldBuildID(ctx context.Context, p []uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByOldBuildID"
+	l, e := a.fromQuery(ctx, qn, "oldbuildid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByOldBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannelChange) ByLikeOldBuildID(ctx context.Context, p uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByLikeOldBuildID"
+	l, e := a.fromQuery(ctx, qn, "oldbuildid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByOldBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with matching NewBuildID
+func (a *DBChannelChange) ByNewBuildID(ctx context.Context, p uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByNewBuildID"
+	l, e := a.fromQuery(ctx, qn, "newbuildid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByNewBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with multiple matching NewBuildID
+func (a *DBChannelChange) ByMultiNewBuildID(ctx context.Context, p []uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByNewBuildID"
+	l, e := a.fromQuery(ctx, qn, "newbuildid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByNewBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannelChange) ByLikeNewBuildID(ctx context.Context, p uint64) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByLikeNewBuildID"
+	l, e := a.fromQuery(ctx, qn, "newbuildid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByNewBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with matching UserID
+func (a *DBChannelChange) ByUserID(ctx context.Context, p string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByUserID"
+	l, e := a.fromQuery(ctx, qn, "userid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with multiple matching UserID
+func (a *DBChannelChange) ByMultiUserID(ctx context.Context, p []string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByUserID"
+	l, e := a.fromQuery(ctx, qn, "userid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannelChange) ByLikeUserID(ctx context.Context, p string) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByLikeUserID"
+	l, e := a.fromQuery(ctx, qn, "userid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUserID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with matching Timestamp
+func (a *DBChannelChange) ByTimestamp(ctx context.Context, p uint32) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByTimestamp"
+	l, e := a.fromQuery(ctx, qn, "timestamp = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByTimestamp: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBChannelChange" rows with multiple matching Timestamp
+func (a *DBChannelChange) ByMultiTimestamp(ctx context.Context, p []uint32) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByTimestamp"
+	l, e := a.fromQuery(ctx, qn, "timestamp in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByTimestamp: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBChannelChange) ByLikeTimestamp(ctx context.Context, p uint32) ([]*savepb.ChannelChange, error) {
+	qn := "DBChannelChange_ByLikeTimestamp"
+	l, e := a.fromQuery(ctx, qn, "timestamp ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByTimestamp: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* The field getters
+**********************************************************************/
+
+// getter for field "ID" (ID) [uint64]
+func (a *DBChannelChange) get_ID(p *savepb.ChannelChange) uint64 {
+	return uint64(p.ID)
+}
+
+// getter for field "ArtefactID" (ArtefactID) [uint64]
+func (a *DBChannelChange) get_ArtefactID(p *savepb.ChannelChange) uint64 {
+	return uint64(p.ArtefactID)
+}
+
+// getter for field "Channel" (Channel) [string]
+func (a *DBChannelChange) get_Channel(p *savepb.ChannelChange) string {
+	return string(p.Channel)
+}
+
+// getter for field "Branch" (Branch) [string]
+func (a *DBChannelChange) get_Branch(p *savepb.ChannelChange) string {
+	return string(p.Branch)
+}
+
+// getter for field "OldBuildID" (OldBuildID) [uint64]
+func (a *DBChannelChange) get_OldBuildID(p *savepb.ChannelChange) uint64 {
+	return uint64(p.OldBuildID)
+}
+
+// getter for field "NewBuildID" (NewBuildID) [uint64]
+func (a *DBChannelChange) get_NewBuildID(p *savepb.ChannelChange) uint64 {
+	return uint64(p.NewBuildID)
+}
+
+// getter for field "UserID" (UserID) [string]
+func (a *DBChannelChange) get_UserID(p *savepb.ChannelChange) string {
+	return string(p.UserID)
+}
+
+// getter for field "Timestamp" (Timestamp) [uint32]
+func (a *DBChannelChange) get_Timestamp(p *savepb.ChannelChange) uint32 {
+	return uint32(p.Timestamp)
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Query
+**********************************************************************/
+
+// from a query snippet (the part after WHERE)
+func (a *DBChannelChange) ByDBQuery(ctx context.Context, query *Query) ([]*savepb.ChannelChange, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for col_name, value := range extra_fields {
+		i++
+		/*
+		   efname:=fmt.Sprintf("EXTRA_FIELD_%d",i)
+		   query.Add(col_name+" = "+efname,QP{efname:value})
+		*/
+		query.AddEqual(col_name, value)
+	}
+
+	gw, paras := query.ToPostgres()
+	queryname := "custom_dbquery"
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where "+gw, paras...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+
+}
+
+func (a *DBChannelChange) FromQuery(ctx context.Context, query_where string, args ...interface{}) ([]*savepb.ChannelChange, error) {
+	return a.fromQuery(ctx, "custom_query_"+a.Tablename(), query_where, args...)
+}
+
+// from a query snippet (the part after WHERE)
+func (a *DBChannelChange) fromQuery(ctx context.Context, queryname string, query_where string, args ...interface{}) ([]*savepb.ChannelChange, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	eq := ""
+	if extra_fields != nil && len(extra_fields) > 0 {
+		eq = " AND ("
+		// build the extraquery "eq"
+		i := len(args)
+		deli := ""
+		for col_name, value := range extra_fields {
+			i++
+			eq = eq + deli + col_name + fmt.Sprintf(" = $%d", i)
+			deli = " AND "
+			args = append(args, value)
+		}
+		eq = eq + ")"
+	}
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where ( "+query_where+") "+eq, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Row to struct
+**********************************************************************/
+func (a *DBChannelChange) get_col_from_proto(p *savepb.ChannelChange, colname string) interface{} {
+	if colname == "id" {
+		return a.get_ID(p)
+	} else if colname == "artefactid" {
+		return a.get_ArtefactID(p)
+	} else if colname == "channel" {
+		return a.get_Channel(p)
+	} else if colname == "branch" {
+		return a.get_Branch(p)
+	} else if colname == "oldbuildid" {
+		return a.get_OldBuildID(p)
+	} else if colname == "newbuildid" {
+		return a.get_NewBuildID(p)
+	} else if colname == "userid" {
+		return a.get_UserID(p)
+	} else if colname == "timestamp" {
+		return a.get_Timestamp(p)
+	}
+	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
+}
+
+func (a *DBChannelChange) Tablename() string {
+	return a.SQLTablename
+}
+
+func (a *DBChannelChange) SelectCols() string {
+	return "id,artefactid, channel, branch, oldbuildid, newbuildid, userid, timestamp"
+}
+func (a *DBChannelChange) SelectColsQualified() string {
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".artefactid, " + a.SQLTablename + ".channel, " + a.SQLTablename + ".branch, " + a.SQLTablename + ".oldbuildid, " + a.SQLTablename + ".newbuildid, " + a.SQLTablename + ".userid, " + a.SQLTablename + ".timestamp"
+}
+
+func (a *DBChannelChange) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.ChannelChange, error) {
+	var res []*savepb.ChannelChange
+	for rows.Next() {
+		// SCANNER:
+		foo := &savepb.ChannelChange{}
+		// create the non-nullable pointers
+		// create variables for scan results
+		scanTarget_0 := &foo.ID
+		scanTarget_1 := &foo.ArtefactID
+		scanTarget_2 := &foo.Channel
+		scanTarget_3 := &foo.Branch
+		scanTarget_4 := &foo.OldBuildID
+		scanTarget_5 := &foo.NewBuildID
+		scanTarget_6 := &foo.UserID
+		scanTarget_7 := &foo.Timestamp
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4, scanTarget_5, scanTarget_6, scanTarget_7)
+		// END SCANNER
+
+		if err != nil {
+			return nil, a.Error(ctx, "fromrow-scan", err)
+		}
+		res = append(res, foo)
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to create table and columns
+**********************************************************************/
+func (a *DBChannelChange) CreateTable(ctx context.Context) error {
+	csql := []string{
+		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,channel text not null ,branch text not null ,oldbuildid bigint not null ,newbuildid bigint not null ,userid text not null ,timestamp integer not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,channel text not null ,branch text not null ,oldbuildid bigint not null ,newbuildid bigint not null ,userid text not null ,timestamp integer not null );`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS channel text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS branch text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS oldbuildid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS newbuildid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS userid text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS timestamp integer not null default 0;`,
+
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS artefactid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS channel text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS branch text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS oldbuildid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS newbuildid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS userid text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS timestamp integer not null  default 0;`,
+	}
+
+	for i, c := range csql {
+		_, e := a.DB.ExecContext(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+		if e != nil {
+			return e
+		}
+	}
+
+	// these are optional, expected to fail
+	csql = []string{
+		// Indices:
+
+		// Foreign keys:
+
+	}
+	for i, c := range csql {
+		a.DB.ExecContextQuiet(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+	}
+	return nil
+}
+
+/**********************************************************************
+* Helper to meaningful errors
+**********************************************************************/
+func (a *DBChannelChange) Error(ctx context.Context, q string, e error) error {
+	if e == nil {
+		return nil
+	}
+	return errors.Errorf("[table="+a.SQLTablename+", query=%s] Error: %s", q, e)
+}
+
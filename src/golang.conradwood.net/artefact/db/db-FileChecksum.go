@@ -0,0 +1,723 @@
+package db
+
+/*
+ This file was created by mkdb-client.
+ The intention is not to modify this file, but you may extend the struct DBFileChecksum
+ in a seperate file (so that you can regenerate this one from time to time)
+*/
+
+/*
+ PRIMARY KEY: ID
+*/
+
+/*
+ postgres:
+ create sequence filechecksum_seq;
+
+Main Table:
+
+ CREATE TABLE filechecksum (id integer primary key default nextval('filechecksum_seq'),artefactid bigint not null  ,branch text not null  ,buildid bigint not null  ,path text not null  ,sha256 text not null  ,size bigint not null  ,created integer not null  );
+
+Alter statements:
+ALTER TABLE filechecksum ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;
+ALTER TABLE filechecksum ADD COLUMN IF NOT EXISTS branch text not null default '';
+ALTER TABLE filechecksum ADD COLUMN IF NOT EXISTS buildid bigint not null default 0;
+ALTER TABLE filechecksum ADD COLUMN IF NOT EXISTS path text not null default '';
+ALTER TABLE filechecksum ADD COLUMN IF NOT EXISTS sha256 text not null default '';
+ALTER TABLE filechecksum ADD COLUMN IF NOT EXISTS size bigint not null default 0;
+ALTER TABLE filechecksum ADD COLUMN IF NOT EXISTS created integer not null default 0;
+
+
+Archive Table: (structs can be moved from main to archive using Archive() function)
+
+ CREATE TABLE filechecksum_archive (id integer unique not null,artefactid bigint not null,branch text not null,buildid bigint not null,path text not null,sha256 text not null,size bigint not null,created integer not null);
+*/
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	savepb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/sql"
+	"os"
+	"sync"
+)
+
+var (
+	default_def_DBFileChecksum *DBFileChecksum
+)
+
+type DBFileChecksum struct {
+	DB                   *sql.DB
+	SQLTablename         string
+	SQLArchivetablename  string
+	customColumnHandlers []CustomColumnHandler
+	lock                 sync.Mutex
+}
+
+func init() {
+	RegisterDBHandlerFactory(func() Handler {
+		return DefaultDBFileChecksum()
+	})
+}
+
+func DefaultDBFileChecksum() *DBFileChecksum {
+	if default_def_DBFileChecksum != nil {
+		return default_def_DBFileChecksum
+	}
+	psql, err := sql.Open()
+	if err != nil {
+		fmt.Printf("Failed to open database: %s\n", err)
+		os.Exit(10)
+	}
+	res := NewDBFileChecksum(psql)
+	ctx := context.Background()
+	err = res.CreateTable(ctx)
+	if err != nil {
+		fmt.Printf("Failed to create table: %s\n", err)
+		os.Exit(10)
+	}
+	default_def_DBFileChecksum = res
+	return res
+}
+func NewDBFileChecksum(db *sql.DB) *DBFileChecksum {
+	foo := DBFileChecksum{DB: db}
+	foo.SQLTablename = "filechecksum"
+	foo.SQLArchivetablename = "filechecksum_archive"
+	return &foo
+}
+
+func (a *DBFileChecksum) GetCustomColumnHandlers() []CustomColumnHandler {
+	return a.customColumnHandlers
+}
+func (a *DBFileChecksum) AddCustomColumnHandler(w CustomColumnHandler) {
+	a.lock.Lock()
+	a.customColumnHandlers = append(a.customColumnHandlers, w)
+	a.lock.Unlock()
+}
+
+func (a *DBFileChecksum) NewQuery() *Query {
+	return newQuery(a)
+}
+
+// archive. It is NOT transactionally save.
+func (a *DBFileChecksum) Archive(ctx context.Context, id uint64) error {
+
+	// load it
+	p, err := a.ByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// now save it to archive:
+	_, e := a.DB.ExecContext(ctx, "archive_DBFileChecksum", "insert into "+a.SQLArchivetablename+" (id,artefactid, branch, buildid, path, sha256, size, created) values ($1,$2, $3, $4, $5, $6, $7, $8) ", p.ID, p.ArtefactID, p.Branch, p.BuildID, p.Path, p.SHA256, p.Size, p.Created)
+	if e != nil {
+		return e
+	}
+
+	// now delete it.
+	a.DeleteByID(ctx, id)
+	return nil
+}
+
+// return a map with columnname -> value_from_proto
+func (a *DBFileChecksum) buildSaveMap(ctx context.Context, p *savepb.FileChecksum) (map[string]interface{}, error) {
+	extra, err := extraFieldsToStore(ctx, a, p)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	res["id"] = a.get_col_from_proto(p, "id")
+	res["artefactid"] = a.get_col_from_proto(p, "artefactid")
+	res["branch"] = a.get_col_from_proto(p, "branch")
+	res["buildid"] = a.get_col_from_proto(p, "buildid")
+	res["path"] = a.get_col_from_proto(p, "path")
+	res["sha256"] = a.get_col_from_proto(p, "sha256")
+	res["size"] = a.get_col_from_proto(p, "size")
+	res["created"] = a.get_col_from_proto(p, "created")
+	if extra != nil {
+		for k, v := range extra {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (a *DBFileChecksum) Save(ctx context.Context, p *savepb.FileChecksum) (uint64, error) {
+	qn := "save_DBFileChecksum"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	delete(smap, "id") // save without id
+	return a.saveMap(ctx, qn, smap, p)
+}
+
+// Save using the ID specified
+func (a *DBFileChecksum) SaveWithID(ctx context.Context, p *savepb.FileChecksum) error {
+	qn := "insert_DBFileChecksum"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, err = a.saveMap(ctx, qn, smap, p)
+	return err
+}
+
+// use a hashmap of columnname->values to store to database (see buildSaveMap())
+func (a *DBFileChecksum) saveMap(ctx context.Context, queryname string, smap map[string]interface{}, p *savepb.FileChecksum) (uint64, error) {
+	// Save (and use database default ID generation)
+
+	var rows *gosql.Rows
+	var e error
+
+	q_cols := ""
+	q_valnames := ""
+	q_vals := make([]interface{}, 0)
+	deli := ""
+	i := 0
+	// build the 2 parts of the query (column names and value names) as well as the values themselves
+	for colname, val := range smap {
+		q_cols = q_cols + deli + colname
+		i++
+		q_valnames = q_valnames + deli + fmt.Sprintf("$%d", i)
+		q_vals = append(q_vals, val)
+		deli = ","
+	}
+	rows, e = a.DB.QueryContext(ctx, queryname, "insert into "+a.SQLTablename+" ("+q_cols+") values ("+q_valnames+") returning id", q_vals...)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, e)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, a.Error(ctx, queryname, errors.Errorf("No rows after insert"))
+	}
+	var id uint64
+	e = rows.Scan(&id)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, errors.Errorf("failed to scan id after insert: %s", e))
+	}
+	p.ID = id
+	return id, nil
+}
+
+// if ID==0 save, otherwise update
+func (a *DBFileChecksum) SaveOrUpdate(ctx context.Context, p *savepb.FileChecksum) error {
+	if p.ID == 0 {
+		_, err := a.Save(ctx, p)
+		return err
+	}
+	return a.Update(ctx, p)
+}
+func (a *DBFileChecksum) Update(ctx context.Context, p *savepb.FileChecksum) error {
+	qn := "DBFileChecksum_Update"
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set artefactid=$1, branch=$2, buildid=$3, path=$4, sha256=$5, size=$6, created=$7 where id = $8", a.get_ArtefactID(p), a.get_Branch(p), a.get_BuildID(p), a.get_Path(p), a.get_SHA256(p), a.get_Size(p), a.get_Created(p), p.ID)
+
+	return a.Error(ctx, qn, e)
+}
+
+// delete by id field
+func (a *DBFileChecksum) DeleteByID(ctx context.Context, p uint64) error {
+	qn := "deleteDBFileChecksum_ByID"
+	_, e := a.DB.ExecContext(ctx, qn, "delete from "+a.SQLTablename+" where id = $1", p)
+	return a.Error(ctx, qn, e)
+}
+
+// get it by primary id
+func (a *DBFileChecksum) ByID(ctx context.Context, p uint64) (*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, a.Error(ctx, qn, errors.Errorf("No FileChecksum with id %v", p))
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) FileChecksum with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by primary id (nil if no such ID row, but no error either)
+func (a *DBFileChecksum) TryByID(ctx context.Context, p uint64) (*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_TryByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, nil
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) FileChecksum with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by multiple primary ids
+func (a *DBFileChecksum) ByIDs(ctx context.Context, p []uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByIDs"
+	l, e := a.fromQuery(ctx, qn, "id in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all rows
+func (a *DBFileChecksum) All(ctx context.Context) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_all"
+	l, e := a.fromQuery(ctx, qn, "true")
+	if e != nil {
+		return nil, errors.Errorf("All: error scanning (%s)", e)
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* GetBy[FIELD] functions
+**********************************************************************/
+
+// get all "DBFileChecksum" rows with matching ArtefactID
+func (a *DBFileChecksum) ByArtefactID(ctx context.Context, p uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with multiple matching ArtefactID
+func (a *DBFileChecksum) ByMultiArtefactID(ctx context.Context, p []uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBFileChecksum) ByLikeArtefactID(ctx context.Context, p uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByLikeArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with matching Branch
+func (a *DBFileChecksum) ByBranch(ctx context.Context, p string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with multiple matching Branch
+func (a *DBFileChecksum) ByMultiBranch(ctx context.Context, p []string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByBranch"
+	l, e := a.fromQuery(ctx, qn, "branch in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBFileChecksum) ByLikeBranch(ctx context.Context, p string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByLikeBranch"
+	l, e := a.fromQuery(ctx, qn, "branch ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBranch: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with matching BuildID
+func (a *DBFileChecksum) ByBuildID(ctx context.Context, p uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with multiple matching BuildID
+func (a *DBFileChecksum) ByMultiBuildID(ctx context.Context, p []uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBFileChecksum) ByLikeBuildID(ctx context.Context, p uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByLikeBuildID"
+	l, e := a.fromQuery(ctx, qn, "buildid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByBuildID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with matching Path
+func (a *DBFileChecksum) ByPath(ctx context.Context, p string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByPath"
+	l, e := a.fromQuery(ctx, qn, "path = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByPath: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with multiple matching Path
+func (a *DBFileChecksum) ByMultiPath(ctx context.Context, p []string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByPath"
+	l, e := a.fromQuery(ctx, qn, "path in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByPath: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBFileChecksum) ByLikePath(ctx context.Context, p string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByLikePath"
+	l, e := a.fromQuery(ctx, qn, "path ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByPath: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with matching SHA256
+func (a *DBFileChecksum) BySHA256(ctx context.Context, p string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_BySHA256"
+	l, e := a.fromQuery(ctx, qn, "sha256 = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySHA256: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with multiple matching SHA256
+func (a *DBFileChecksum) ByMultiSHA256(ctx context.Context, p []string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_BySHA256"
+	l, e := a.fromQuery(ctx, qn, "sha256 in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySHA256: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBFileChecksum) ByLikeSHA256(ctx context.Context, p string) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByLikeSHA256"
+	l, e := a.fromQuery(ctx, qn, "sha256 ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySHA256: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with matching Size
+func (a *DBFileChecksum) BySize(ctx context.Context, p uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_BySize"
+	l, e := a.fromQuery(ctx, qn, "size = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySize: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with multiple matching Size
+func (a *DBFileChecksum) ByMultiSize(ctx context.Context, p []uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_BySize"
+	l, e := a.fromQuery(ctx, qn, "size in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySize: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBFileChecksum) ByLikeSize(ctx context.Context, p uint64) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByLikeSize"
+	l, e := a.fromQuery(ctx, qn, "size ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySize: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with matching Created
+func (a *DBFileChecksum) ByCreated(ctx context.Context, p uint32) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByCreated"
+	l, e := a.fromQuery(ctx, qn, "created = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBFileChecksum" rows with multiple matching Created
+func (a *DBFileChecksum) ByMultiCreated(ctx context.Context, p []uint32) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByCreated"
+	l, e := a.fromQuery(ctx, qn, "created in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBFileChecksum) ByLikeCreated(ctx context.Context, p uint32) ([]*savepb.FileChecksum, error) {
+	qn := "DBFileChecksum_ByLikeCreated"
+	l, e := a.fromQuery(ctx, qn, "created ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* The field getters
+**********************************************************************/
+
+// getter for field "ID" (ID) [uint64]
+func (a *DBFileChecksum) get_ID(p *savepb.FileChecksum) uint64 {
+	return uint64(p.ID)
+}
+
+// getter for field "ArtefactID" (ArtefactID) [uint64]
+func (a *DBFileChecksum) get_ArtefactID(p *savepb.FileChecksum) uint64 {
+	return uint64(p.ArtefactID)
+}
+
+// getter for field "Branch" (Branch) [string]
+func (a *DBFileChecksum) get_Branch(p *savepb.FileChecksum) string {
+	return string(p.Branch)
+}
+
+// getter for field "BuildID" (BuildID) [uint64]
+func (a *DBFileChecksum) get_BuildID(p *savepb.FileChecksum) uint64 {
+	return uint64(p.BuildID)
+}
+
+// getter for field "Path" (Path) [string]
+func (a *DBFileChecksum) get_Path(p *savepb.FileChecksum) string {
+	return string(p.Path)
+}
+
+// getter for field "SHA256" (SHA256) [string]
+func (a *DBFileChecksum) get_SHA256(p *savepb.FileChecksum) string {
+	return string(p.SHA256)
+}
+
+// getter for field "Size" (Size) [uint64]
+func (a *DBFileChecksum) get_Size(p *savepb.FileChecksum) uint64 {
+	return uint64(p.Size)
+}
+
+// getter for field "Created" (Created) [uint32]
+func (a *DBFileChecksum) get_Created(p *savepb.FileChecksum) uint32 {
+	return uint32(p.Created)
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Query
+**********************************************************************/
+
+// from a query snippet (the part after WHERE)
+func (a *DBFileChecksum) ByDBQuery(ctx context.Context, query *Query) ([]*savepb.FileChecksum, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for col_name, value := range extra_fields {
+		i++
+		/*
+		   efname:=fmt.Sprintf("EXTRA_FIELD_%d",i)
+		   query.Add(col_name+" = "+efname,QP{efname:value})
+		*/
+		query.AddEqual(col_name, value)
+	}
+
+	gw, paras := query.ToPostgres()
+	queryname := "custom_dbquery"
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where "+gw, paras...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+
+}
+
+func (a *DBFileChecksum) FromQuery(ctx context.Context, query_where string, args ...interface{}) ([]*savepb.FileChecksum, error) {
+	return a.fromQuery(ctx, "custom_query_"+a.Tablename(), query_where, args...)
+}
+
+// from a query snippet (the part after WHERE)
+func (a *DBFileChecksum) fromQuery(ctx context.Context, queryname string, query_where string, args ...interface{}) ([]*savepb.FileChecksum, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	eq := ""
+	if extra_fields != nil && len(extra_fields) > 0 {
+		eq = " AND ("
+		// build the extraquery "eq"
+		i := len(args)
+		deli := ""
+		for col_name, value := range extra_fields {
+			i++
+			eq = eq + deli + col_name + fmt.Sprintf(" = $%d", i)
+			deli = " AND "
+			args = append(args, value)
+		}
+		eq = eq + ")"
+	}
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where ( "+query_where+") "+eq, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Row to struct
+**********************************************************************/
+func (a *DBFileChecksum) get_col_from_proto(p *savepb.FileChecksum, colname string) interface{} {
+	if colname == "id" {
+		return a.get_ID(p)
+	} else if colname == "artefactid" {
+		return a.get_ArtefactID(p)
+	} else if colname == "branch" {
+		return a.get_Branch(p)
+	} else if colname == "buildid" {
+		return a.get_BuildID(p)
+	} else if colname == "path" {
+		return a.get_Path(p)
+	} else if colname == "sha256" {
+		return a.get_SHA256(p)
+	} else if colname == "size" {
+		return a.get_Size(p)
+	} else if colname == "created" {
+		return a.get_Created(p)
+	}
+	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
+}
+
+func (a *DBFileChecksum) Tablename() string {
+	return a.SQLTablename
+}
+
+func (a *DBFileChecksum) SelectCols() string {
+	return "id,artefactid, branch, buildid, path, sha256, size, created"
+}
+func (a *DBFileChecksum) SelectColsQualified() string {
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".artefactid, " + a.SQLTablename + ".branch, " + a.SQLTablename + ".buildid, " + a.SQLTablename + ".path, " + a.SQLTablename + ".sha256, " + a.SQLTablename + ".size, " + a.SQLTablename + ".created"
+}
+
+func (a *DBFileChecksum) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.FileChecksum, error) {
+	var res []*savepb.FileChecksum
+	for rows.Next() {
+		// SCANNER:
+		foo := &savepb.FileChecksum{}
+		// create the non-nullable pointers
+		// create variables for scan results
+		scanTarget_0 := &foo.ID
+		scanTarget_1 := &foo.ArtefactID
+		scanTarget_2 := &foo.Branch
+		scanTarget_3 := &foo.BuildID
+		scanTarget_4 := &foo.Path
+		scanTarget_5 := &foo.SHA256
+		scanTarget_6 := &foo.Size
+		scanTarget_7 := &foo.Created
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4, scanTarget_5, scanTarget_6, scanTarget_7)
+		// END SCANNER
+
+		if err != nil {
+			return nil, a.Error(ctx, "fromrow-scan", err)
+		}
+		res = append(res, foo)
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to create table and columns
+**********************************************************************/
+func (a *DBFileChecksum) CreateTable(ctx context.Context) error {
+	csql := []string{
+		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,branch text not null ,buildid bigint not null ,path text not null ,sha256 text not null ,size bigint not null ,created integer not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,branch text not null ,buildid bigint not null ,path text not null ,sha256 text not null ,size bigint not null ,created integer not null );`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS branch text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS buildid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS path text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS sha256 text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS size bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS created integer not null default 0;`,
+
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS artefactid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS branch text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS buildid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS path text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS sha256 text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS size bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS created integer not null  default 0;`,
+	}
+
+	for i, c := range csql {
+		_, e := a.DB.ExecContext(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+		if e != nil {
+			return e
+		}
+	}
+
+	// these are optional, expected to fail
+	csql = []string{
+		// Indices:
+
+		// Foreign keys:
+
+	}
+	for i, c := range csql {
+		a.DB.ExecContextQuiet(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+	}
+	return nil
+}
+
+/**********************************************************************
+* Helper to meaningful errors
+**********************************************************************/
+func (a *DBFileChecksum) Error(ctx context.Context, q string, e error) error {
+	if e == nil {
+		return nil
+	}
+	return errors.Errorf("[table="+a.SQLTablename+", query=%s] Error: %s", q, e)
+}
+
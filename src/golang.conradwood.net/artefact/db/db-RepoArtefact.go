@@ -0,0 +1,556 @@
+package db
+
+/*
+ This file was created by mkdb-client.
+ The intention is not to modify this file, but you may extend the struct DBRepoArtefact
+ in a seperate file (so that you can regenerate this one from time to time)
+*/
+
+/*
+ PRIMARY KEY: ID
+*/
+
+/*
+ postgres:
+ create sequence repoartefact_seq;
+
+Main Table:
+
+ CREATE TABLE repoartefact (id integer primary key default nextval('repoartefact_seq'),repositoryid bigint not null  ,artefactid bigint not null  ,updated integer not null  );
+
+Alter statements:
+ALTER TABLE repoartefact ADD COLUMN IF NOT EXISTS repositoryid bigint not null default 0;
+ALTER TABLE repoartefact ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;
+ALTER TABLE repoartefact ADD COLUMN IF NOT EXISTS updated integer not null default 0;
+
+
+Archive Table: (structs can be moved from main to archive using Archive() function)
+
+ CREATE TABLE repoartefact_archive (id integer unique not null,repositoryid bigint not null,artefactid bigint not null,updated integer not null);
+*/
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	savepb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/sql"
+	"os"
+	"sync"
+)
+
+var (
+	default_def_DBRepoArtefact *DBRepoArtefact
+)
+
+type DBRepoArtefact struct {
+	DB                   *sql.DB
+	SQLTablename         string
+	SQLArchivetablename  string
+	customColumnHandlers []CustomColumnHandler
+	lock                 sync.Mutex
+}
+
+func init() {
+	RegisterDBHandlerFactory(func() Handler {
+		return DefaultDBRepoArtefact()
+	})
+}
+
+func DefaultDBRepoArtefact() *DBRepoArtefact {
+	if default_def_DBRepoArtefact != nil {
+		return default_def_DBRepoArtefact
+	}
+	psql, err := sql.Open()
+	if err != nil {
+		fmt.Printf("Failed to open database: %s\n", err)
+		os.Exit(10)
+	}
+	res := NewDBRepoArtefact(psql)
+	ctx := context.Background()
+	err = res.CreateTable(ctx)
+	if err != nil {
+		fmt.Printf("Failed to create table: %s\n", err)
+		os.Exit(10)
+	}
+	default_def_DBRepoArtefact = res
+	return res
+}
+func NewDBRepoArtefact(db *sql.DB) *DBRepoArtefact {
+	foo := DBRepoArtefact{DB: db}
+	foo.SQLTablename = "repoartefact"
+	foo.SQLArchivetablename = "repoartefact_archive"
+	return &foo
+}
+
+func (a *DBRepoArtefact) GetCustomColumnHandlers() []CustomColumnHandler {
+	return a.customColumnHandlers
+}
+func (a *DBRepoArtefact) AddCustomColumnHandler(w CustomColumnHandler) {
+	a.lock.Lock()
+	a.customColumnHandlers = append(a.customColumnHandlers, w)
+	a.lock.Unlock()
+}
+
+func (a *DBRepoArtefact) NewQuery() *Query {
+	return newQuery(a)
+}
+
+// archive. It is NOT transactionally save.
+func (a *DBRepoArtefact) Archive(ctx context.Context, id uint64) error {
+
+	// load it
+	p, err := a.ByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// now save it to archive:
+	_, e := a.DB.ExecContext(ctx, "archive_DBRepoArtefact", "insert into "+a.SQLArchivetablename+" (id,repositoryid, artefactid, updated) values ($1,$2, $3, $4) ", p.ID, p.RepositoryID, p.ArtefactID, p.Updated)
+	if e != nil {
+		return e
+	}
+
+	// now delete it.
+	a.DeleteByID(ctx, id)
+	return nil
+}
+
+// return a map with columnname -> value_from_proto
+func (a *DBRepoArtefact) buildSaveMap(ctx context.Context, p *savepb.RepoArtefact) (map[string]interface{}, error) {
+	extra, err := extraFieldsToStore(ctx, a, p)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	res["id"] = a.get_col_from_proto(p, "id")
+	res["repositoryid"] = a.get_col_from_proto(p, "repositoryid")
+	res["artefactid"] = a.get_col_from_proto(p, "artefactid")
+	res["updated"] = a.get_col_from_proto(p, "updated")
+	if extra != nil {
+		for k, v := range extra {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (a *DBRepoArtefact) Save(ctx context.Context, p *savepb.RepoArtefact) (uint64, error) {
+	qn := "save_DBRepoArtefact"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	delete(smap, "id") // save without id
+	return a.saveMap(ctx, qn, smap, p)
+}
+
+// Save using the ID specified
+func (a *DBRepoArtefact) SaveWithID(ctx context.Context, p *savepb.RepoArtefact) error {
+	qn := "insert_DBRepoArtefact"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, err = a.saveMap(ctx, qn, smap, p)
+	return err
+}
+
+// use a hashmap of columnname->values to store to database (see buildSaveMap())
+func (a *DBRepoArtefact) saveMap(ctx context.Context, queryname string, smap map[string]interface{}, p *savepb.RepoArtefact) (uint64, error) {
+	// Save (and use database default ID generation)
+
+	var rows *gosql.Rows
+	var e error
+
+	q_cols := ""
+	q_valnames := ""
+	q_vals := make([]interface{}, 0)
+	deli := ""
+	i := 0
+	// build the 2 parts of the query (column names and value names) as well as the values themselves
+	for colname, val := range smap {
+		q_cols = q_cols + deli + colname
+		i++
+		q_valnames = q_valnames + deli + fmt.Sprintf("$%d", i)
+		q_vals = append(q_vals, val)
+		deli = ","
+	}
+	rows, e = a.DB.QueryContext(ctx, queryname, "insert into "+a.SQLTablename+" ("+q_cols+") values ("+q_valnames+") returning id", q_vals...)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, e)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, a.Error(ctx, queryname, errors.Errorf("No rows after insert"))
+	}
+	var id uint64
+	e = rows.Scan(&id)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, errors.Errorf("failed to scan id after insert: %s", e))
+	}
+	p.ID = id
+	return id, nil
+}
+
+// if ID==0 save, otherwise update
+func (a *DBRepoArtefact) SaveOrUpdate(ctx context.Context, p *savepb.RepoArtefact) error {
+	if p.ID == 0 {
+		_, err := a.Save(ctx, p)
+		return err
+	}
+	return a.Update(ctx, p)
+}
+func (a *DBRepoArtefact) Update(ctx context.Context, p *savepb.RepoArtefact) error {
+	qn := "DBRepoArtefact_Update"
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set repositoryid=$1, artefactid=$2, updated=$3 where id = $4", a.get_RepositoryID(p), a.get_ArtefactID(p), a.get_Updated(p), p.ID)
+
+	return a.Error(ctx, qn, e)
+}
+
+// delete by id field
+func (a *DBRepoArtefact) DeleteByID(ctx context.Context, p uint64) error {
+	qn := "deleteDBRepoArtefact_ByID"
+	_, e := a.DB.ExecContext(ctx, qn, "delete from "+a.SQLTablename+" where id = $1", p)
+	return a.Error(ctx, qn, e)
+}
+
+// get it by primary id
+func (a *DBRepoArtefact) ByID(ctx context.Context, p uint64) (*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, a.Error(ctx, qn, errors.Errorf("No RepoArtefact with id %v", p))
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) RepoArtefact with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by primary id (nil if no such ID row, but no error either)
+func (a *DBRepoArtefact) TryByID(ctx context.Context, p uint64) (*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_TryByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, nil
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) RepoArtefact with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by multiple primary ids
+func (a *DBRepoArtefact) ByIDs(ctx context.Context, p []uint64) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByIDs"
+	l, e := a.fromQuery(ctx, qn, "id in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all rows
+func (a *DBRepoArtefact) All(ctx context.Context) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_all"
+	l, e := a.fromQuery(ctx, qn, "true")
+	if e != nil {
+		return nil, errors.Errorf("All: error scanning (%s)", e)
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* GetBy[FIELD] functions
+**********************************************************************/
+
+// get all "DBRepoArtefact" rows with matching RepositoryID
+func (a *DBRepoArtefact) ByRepositoryID(ctx context.Context, p uint64) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByRepositoryID"
+	l, e := a.fromQuery(ctx, qn, "repositoryid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByRepositoryID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRepoArtefact" rows with multiple matching RepositoryID
+func (a *DBRepoArtefact) ByMultiRepositoryID(ctx context.Context, p []uint64) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByRepositoryID"
+	l, e := a.fromQuery(ctx, qn, "repositoryid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByRepositoryID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRepoArtefact) ByLikeRepositoryID(ctx context.Context, p uint64) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByLikeRepositoryID"
+	l, e := a.fromQuery(ctx, qn, "repositoryid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByRepositoryID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRepoArtefact" rows with matching ArtefactID
+func (a *DBRepoArtefact) ByArtefactID(ctx context.Context, p uint64) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRepoArtefact" rows with multiple matching ArtefactID
+func (a *DBRepoArtefact) ByMultiArtefactID(ctx context.Context, p []uint64) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRepoArtefact) ByLikeArtefactID(ctx context.Context, p uint64) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByLikeArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRepoArtefact" rows with matching Updated
+func (a *DBRepoArtefact) ByUpdated(ctx context.Context, p uint32) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRepoArtefact" rows with multiple matching Updated
+func (a *DBRepoArtefact) ByMultiUpdated(ctx context.Context, p []uint32) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRepoArtefact) ByLikeUpdated(ctx context.Context, p uint32) ([]*savepb.RepoArtefact, error) {
+	qn := "DBRepoArtefact_ByLikeUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* The field getters
+**********************************************************************/
+
+// getter for field "ID" (ID) [uint64]
+func (a *DBRepoArtefact) get_ID(p *savepb.RepoArtefact) uint64 {
+	return uint64(p.ID)
+}
+
+// getter for field "RepositoryID" (RepositoryID) [uint64]
+func (a *DBRepoArtefact) get_RepositoryID(p *savepb.RepoArtefact) uint64 {
+	return uint64(p.RepositoryID)
+}
+
+// getter for field "ArtefactID" (ArtefactID) [uint64]
+func (a *DBRepoArtefact) get_ArtefactID(p *savepb.RepoArtefact) uint64 {
+	return uint64(p.ArtefactID)
+}
+
+// getter for field "Updated" (Updated) [uint32]
+func (a *DBRepoArtefact) get_Updated(p *savepb.RepoArtefact) uint32 {
+	return uint32(p.Updated)
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Query
+**********************************************************************/
+
+// from a query snippet (the part after WHERE)
+func (a *DBRepoArtefact) ByDBQuery(ctx context.Context, query *Query) ([]*savepb.RepoArtefact, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for col_name, value := range extra_fields {
+		i++
+		/*
+		   efname:=fmt.Sprintf("EXTRA_FIELD_%d",i)
+		   query.Add(col_name+" = "+efname,QP{efname:value})
+		*/
+		query.AddEqual(col_name, value)
+	}
+
+	gw, paras := query.ToPostgres()
+	queryname := "custom_dbquery"
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where "+gw, paras...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+
+}
+
+func (a *DBRepoArtefact) FromQuery(ctx context.Context, query_where string, args ...interface{}) ([]*savepb.RepoArtefact, error) {
+	return a.fromQuery(ctx, "custom_query_"+a.Tablename(), query_where, args...)
+}
+
+// from a query snippet (the part after WHERE)
+func (a *DBRepoArtefact) fromQuery(ctx context.Context, queryname string, query_where string, args ...interface{}) ([]*savepb.RepoArtefact, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	eq := ""
+	if extra_fields != nil && len(extra_fields) > 0 {
+		eq = " AND ("
+		// build the extraquery "eq"
+		i := len(args)
+		deli := ""
+		for col_name, value := range extra_fields {
+			i++
+			eq = eq + deli + col_name + fmt.Sprintf(" = $%d", i)
+			deli = " AND "
+			args = append(args, value)
+		}
+		eq = eq + ")"
+	}
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where ( "+query_where+") "+eq, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Row to struct
+**********************************************************************/
+func (a *DBRepoArtefact) get_col_from_proto(p *savepb.RepoArtefact, colname string) interface{} {
+	if colname == "id" {
+		return a.get_ID(p)
+	} else if colname == "repositoryid" {
+		return a.get_RepositoryID(p)
+	} else if colname == "artefactid" {
+		return a.get_ArtefactID(p)
+	} else if colname == "updated" {
+		return a.get_Updated(p)
+	}
+	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
+}
+
+func (a *DBRepoArtefact) Tablename() string {
+	return a.SQLTablename
+}
+
+func (a *DBRepoArtefact) SelectCols() string {
+	return "id,repositoryid, artefactid, updated"
+}
+func (a *DBRepoArtefact) SelectColsQualified() string {
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".repositoryid, " + a.SQLTablename + ".artefactid, " + a.SQLTablename + ".updated"
+}
+
+func (a *DBRepoArtefact) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.RepoArtefact, error) {
+	var res []*savepb.RepoArtefact
+	for rows.Next() {
+		// SCANNER:
+		foo := &savepb.RepoArtefact{}
+		// create the non-nullable pointers
+		// create variables for scan results
+		scanTarget_0 := &foo.ID
+		scanTarget_1 := &foo.RepositoryID
+		scanTarget_2 := &foo.ArtefactID
+		scanTarget_3 := &foo.Updated
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3)
+		// END SCANNER
+
+		if err != nil {
+			return nil, a.Error(ctx, "fromrow-scan", err)
+		}
+		res = append(res, foo)
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to create table and columns
+**********************************************************************/
+func (a *DBRepoArtefact) CreateTable(ctx context.Context) error {
+	csql := []string{
+		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),repositoryid bigint not null ,artefactid bigint not null ,updated integer not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),repositoryid bigint not null ,artefactid bigint not null ,updated integer not null );`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS repositoryid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS updated integer not null default 0;`,
+
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS repositoryid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS artefactid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS updated integer not null  default 0;`,
+	}
+
+	for i, c := range csql {
+		_, e := a.DB.ExecContext(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+		if e != nil {
+			return e
+		}
+	}
+
+	// these are optional, expected to fail
+	csql = []string{
+		// Indices:
+		`CREATE UNIQUE INDEX IF NOT EXISTS uniq_` + a.SQLTablename + `_repositoryid on ` + a.SQLTablename + `(repositoryid);`,
+
+		// Foreign keys:
+
+	}
+	for i, c := range csql {
+		a.DB.ExecContextQuiet(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+	}
+	return nil
+}
+
+/**********************************************************************
+* Helper to meaningful errors
+**********************************************************************/
+func (a *DBRepoArtefact) Error(ctx context.Context, q string, e error) error {
+	if e == nil {
+		return nil
+	}
+	return errors.Errorf("[table="+a.SQLTablename+", query=%s] Error: %s", q, e)
+}
+
@@ -0,0 +1,681 @@
+package db
+
+/*
+ This file was created by mkdb-client.
+ The intention is not to modify this file, but you may extend the struct DBRetentionRule
+ in a seperate file (so that you can regenerate this one from time to time)
+*/
+
+/*
+ PRIMARY KEY: ID
+*/
+
+/*
+ postgres:
+ create sequence retentionrule_seq;
+
+Main Table:
+
+ CREATE TABLE retentionrule (id integer primary key default nextval('retentionrule_seq'),artefactid bigint not null  ,keeplast integer not null  ,keepdays integer not null  ,keepdownloadeddays integer not null  ,updatedby text not null  ,updated integer not null  );
+
+Alter statements:
+ALTER TABLE retentionrule ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;
+ALTER TABLE retentionrule ADD COLUMN IF NOT EXISTS keeplast integer not null default 0;
+ALTER TABLE retentionrule ADD COLUMN IF NOT EXISTS keepdays integer not null default 0;
+ALTER TABLE retentionrule ADD COLUMN IF NOT EXISTS keepdownloadeddays integer not null default 0;
+ALTER TABLE retentionrule ADD COLUMN IF NOT EXISTS updatedby text not null default '';
+ALTER TABLE retentionrule ADD COLUMN IF NOT EXISTS updated integer not null default 0;
+
+
+Archive Table: (structs can be moved from main to archive using Archive() function)
+
+ CREATE TABLE retentionrule_archive (id integer unique not null,artefactid bigint not null,keeplast integer not null,keepdays integer not null,keepdownloadeddays integer not null,updatedby text not null,updated integer not null);
+*/
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	savepb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/sql"
+	"os"
+	"sync"
+)
+
+var (
+	default_def_DBRetentionRule *DBRetentionRule
+)
+
+type DBRetentionRule struct {
+	DB                   *sql.DB
+	SQLTablename         string
+	SQLArchivetablename  string
+	customColumnHandlers []CustomColumnHandler
+	lock                 sync.Mutex
+}
+
+func init() {
+	RegisterDBHandlerFactory(func() Handler {
+		return DefaultDBRetentionRule()
+	})
+}
+
+func DefaultDBRetentionRule() *DBRetentionRule {
+	if default_def_DBRetentionRule != nil {
+		return default_def_DBRetentionRule
+	}
+	psql, err := sql.Open()
+	if err != nil {
+		fmt.Printf("Failed to open database: %s\n", err)
+		os.Exit(10)
+	}
+	res := NewDBRetentionRule(psql)
+	ctx := context.Background()
+	err = res.CreateTable(ctx)
+	if err != nil {
+		fmt.Printf("Failed to create table: %s\n", err)
+		os.Exit(10)
+	}
+	default_def_DBRetentionRule = res
+	return res
+}
+func NewDBRetentionRule(db *sql.DB) *DBRetentionRule {
+	foo := DBRetentionRule{DB: db}
+	foo.SQLTablename = "retentionrule"
+	foo.SQLArchivetablename = "retentionrule_archive"
+	return &foo
+}
+
+func (a *DBRetentionRule) GetCustomColumnHandlers() []CustomColumnHandler {
+	return a.customColumnHandlers
+}
+func (a *DBRetentionRule) AddCustomColumnHandler(w CustomColumnHandler) {
+	a.lock.Lock()
+	a.customColumnHandlers = append(a.customColumnHandlers, w)
+	a.lock.Unlock()
+}
+
+func (a *DBRetentionRule) NewQuery() *Query {
+	return newQuery(a)
+}
+
+// archive. It is NOT transactionally save.
+func (a *DBRetentionRule) Archive(ctx context.Context, id uint64) error {
+
+	// load it
+	p, err := a.ByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// now save it to archive:
+	_, e := a.DB.ExecContext(ctx, "archive_DBRetentionRule", "insert into "+a.SQLArchivetablename+" (id,artefactid, keeplast, keepdays, keepdownloadeddays, updatedby, updated) values ($1,$2, $3, $4, $5, $6, $7) ", p.ID, p.ArtefactID, p.KeepLast, p.KeepDays, p.KeepDownloadedDays, p.UpdatedBy, p.Updated)
+	if e != nil {
+		return e
+	}
+
+	// now delete it.
+	a.DeleteByID(ctx, id)
+	return nil
+}
+
+// return a map with columnname -> value_from_proto
+func (a *DBRetentionRule) buildSaveMap(ctx context.Context, p *savepb.RetentionRule) (map[string]interface{}, error) {
+	extra, err := extraFieldsToStore(ctx, a, p)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	res["id"] = a.get_col_from_proto(p, "id")
+	res["artefactid"] = a.get_col_from_proto(p, "artefactid")
+	res["keeplast"] = a.get_col_from_proto(p, "keeplast")
+	res["keepdays"] = a.get_col_from_proto(p, "keepdays")
+	res["keepdownloadeddays"] = a.get_col_from_proto(p, "keepdownloadeddays")
+	res["updatedby"] = a.get_col_from_proto(p, "updatedby")
+	res["updated"] = a.get_col_from_proto(p, "updated")
+	if extra != nil {
+		for k, v := range extra {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (a *DBRetentionRule) Save(ctx context.Context, p *savepb.RetentionRule) (uint64, error) {
+	qn := "save_DBRetentionRule"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	delete(smap, "id") // save without id
+	return a.saveMap(ctx, qn, smap, p)
+}
+
+// Save using the ID specified
+func (a *DBRetentionRule) SaveWithID(ctx context.Context, p *savepb.RetentionRule) error {
+	qn := "insert_DBRetentionRule"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, err = a.saveMap(ctx, qn, smap, p)
+	return err
+}
+
+// use a hashmap of columnname->values to store to database (see buildSaveMap())
+func (a *DBRetentionRule) saveMap(ctx context.Context, queryname string, smap map[string]interface{}, p *savepb.RetentionRule) (uint64, error) {
+	// Save (and use database default ID generation)
+
+	var rows *gosql.Rows
+	var e error
+
+	q_cols := ""
+	q_valnames := ""
+	q_vals := make([]interface{}, 0)
+	deli := ""
+	i := 0
+	// build the 2 parts of the query (column names and value names) as well as the values themselves
+	for colname, val := range smap {
+		q_cols = q_cols + deli + colname
+		i++
+		q_valnames = q_valnames + deli + fmt.Sprintf("$%d", i)
+		q_vals = append(q_vals, val)
+		deli = ","
+	}
+	rows, e = a.DB.QueryContext(ctx, queryname, "insert into "+a.SQLTablename+" ("+q_cols+") values ("+q_valnames+") returning id", q_vals...)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, e)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, a.Error(ctx, queryname, errors.Errorf("No rows after insert"))
+	}
+	var id uint64
+	e = rows.Scan(&id)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, errors.Errorf("failed to scan id after insert: %s", e))
+	}
+	p.ID = id
+	return id, nil
+}
+
+// if ID==0 save, otherwise update
+func (a *DBRetentionRule) SaveOrUpdate(ctx context.Context, p *savepb.RetentionRule) error {
+	if p.ID == 0 {
+		_, err := a.Save(ctx, p)
+		return err
+	}
+	return a.Update(ctx, p)
+}
+func (a *DBRetentionRule) Update(ctx context.Context, p *savepb.RetentionRule) error {
+	qn := "DBRetentionRule_Update"
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set artefactid=$1, keeplast=$2, keepdays=$3, keepdownloadeddays=$4, updatedby=$5, updated=$6 where id = $7", a.get_ArtefactID(p), a.get_KeepLast(p), a.get_KeepDays(p), a.get_KeepDownloadedDays(p), a.get_UpdatedBy(p), a.get_Updated(p), p.ID)
+
+	return a.Error(ctx, qn, e)
+}
+
+// delete by id field
+func (a *DBRetentionRule) DeleteByID(ctx context.Context, p uint64) error {
+	qn := "deleteDBRetentionRule_ByID"
+	_, e := a.DB.ExecContext(ctx, qn, "delete from "+a.SQLTablename+" where id = $1", p)
+	return a.Error(ctx, qn, e)
+}
+
+// get it by primary id
+func (a *DBRetentionRule) ByID(ctx context.Context, p uint64) (*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, a.Error(ctx, qn, errors.Errorf("No RetentionRule with id %v", p))
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) RetentionRule with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by primary id (nil if no such ID row, but no error either)
+func (a *DBRetentionRule) TryByID(ctx context.Context, p uint64) (*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_TryByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, nil
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) RetentionRule with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by multiple primary ids
+func (a *DBRetentionRule) ByIDs(ctx context.Context, p []uint64) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByIDs"
+	l, e := a.fromQuery(ctx, qn, "id in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all rows
+func (a *DBRetentionRule) All(ctx context.Context) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_all"
+	l, e := a.fromQuery(ctx, qn, "true")
+	if e != nil {
+		return nil, errors.Errorf("All: error scanning (%s)", e)
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* GetBy[FIELD] functions
+**********************************************************************/
+
+// get all "DBRetentionRule" rows with matching ArtefactID
+func (a *DBRetentionRule) ByArtefactID(ctx context.Context, p uint64) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with multiple matching ArtefactID
+func (a *DBRetentionRule) ByMultiArtefactID(ctx context.Context, p []uint64) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRetentionRule) ByLikeArtefactID(ctx context.Context, p uint64) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByLikeArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with matching KeepLast
+func (a *DBRetentionRule) ByKeepLast(ctx context.Context, p uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByKeepLast"
+	l, e := a.fromQuery(ctx, qn, "keeplast = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepLast: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with multiple matching KeepLast
+func (a *DBRetentionRule) ByMultiKeepLast(ctx context.Context, p []uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByKeepLast"
+	l, e := a.fromQuery(ctx, qn, "keeplast in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepLast: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRetentionRule) ByLikeKeepLast(ctx context.Context, p uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByLikeKeepLast"
+	l, e := a.fromQuery(ctx, qn, "keeplast ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepLast: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with matching KeepDays
+func (a *DBRetentionRule) ByKeepDays(ctx context.Context, p uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByKeepDays"
+	l, e := a.fromQuery(ctx, qn, "keepdays = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepDays: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with multiple matching KeepDays
+func (a *DBRetentionRule) ByMultiKeepDays(ctx context.Context, p []uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByKeepDays"
+	l, e := a.fromQuery(ctx, qn, "keepdays in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepDays: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRetentionRule) ByLikeKeepDays(ctx context.Context, p uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByLikeKeepDays"
+	l, e := a.fromQuery(ctx, qn, "keepdays ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepDays: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with matching KeepDownloadedDays
+func (a *DBRetentionRule) ByKeepDownloadedDays(ctx context.Context, p uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByKeepDownloadedDays"
+	l, e := a.fromQuery(ctx, qn, "keepdownloadeddays = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepDownloadedDays: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with multiple matching KeepDownloadedDays
+func (a *DBRetentionRule) ByMultiKeepDownloadedDays(ctx context.Context, p []uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByKeepDownloadedDays"
+	l, e := a.fromQuery(ctx, qn, "keepdownloadeddays in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepDownloadedDays: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRetentionRule) ByLikeKeepDownloadedDays(ctx context.Context, p uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByLikeKeepDownloadedDays"
+	l, e := a.fromQuery(ctx, qn, "keepdownloadeddays ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByKeepDownloadedDays: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with matching UpdatedBy
+func (a *DBRetentionRule) ByUpdatedBy(ctx context.Context, p string) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByUpdatedBy"
+	l, e := a.fromQuery(ctx, qn, "updatedby = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with multiple matching UpdatedBy
+func (a *DBRetentionRule) ByMultiUpdatedBy(ctx context.Context, p []string) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByUpdatedBy"
+	l, e := a.fromQuery(ctx, qn, "updatedby in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRetentionRule) ByLikeUpdatedBy(ctx context.Context, p string) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByLikeUpdatedBy"
+	l, e := a.fromQuery(ctx, qn, "updatedby ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with matching Updated
+func (a *DBRetentionRule) ByUpdated(ctx context.Context, p uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBRetentionRule" rows with multiple matching Updated
+func (a *DBRetentionRule) ByMultiUpdated(ctx context.Context, p []uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBRetentionRule) ByLikeUpdated(ctx context.Context, p uint32) ([]*savepb.RetentionRule, error) {
+	qn := "DBRetentionRule_ByLikeUpdated"
+	l, e := a.fromQuery(ctx, qn, "updated ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByUpdated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* The field getters
+**********************************************************************/
+
+// getter for field "ID" (ID) [uint64]
+func (a *DBRetentionRule) get_ID(p *savepb.RetentionRule) uint64 {
+	return uint64(p.ID)
+}
+
+// getter for field "ArtefactID" (ArtefactID) [uint64]
+func (a *DBRetentionRule) get_ArtefactID(p *savepb.RetentionRule) uint64 {
+	return uint64(p.ArtefactID)
+}
+
+// getter for field "KeepLast" (KeepLast) [uint32]
+func (a *DBRetentionRule) get_KeepLast(p *savepb.RetentionRule) uint32 {
+	return uint32(p.KeepLast)
+}
+
+// getter for field "KeepDays" (KeepDays) [uint32]
+func (a *DBRetentionRule) get_KeepDays(p *savepb.RetentionRule) uint32 {
+	return uint32(p.KeepDays)
+}
+
+// getter for field "KeepDownloadedDays" (KeepDownloadedDays) [uint32]
+func (a *DBRetentionRule) get_KeepDownloadedDays(p *savepb.RetentionRule) uint32 {
+	return uint32(p.KeepDownloadedDays)
+}
+
+// getter for field "UpdatedBy" (UpdatedBy) [string]
+func (a *DBRetentionRule) get_UpdatedBy(p *savepb.RetentionRule) string {
+	return string(p.UpdatedBy)
+}
+
+// getter for field "Updated" (Updated) [uint32]
+func (a *DBRetentionRule) get_Updated(p *savepb.RetentionRule) uint32 {
+	return uint32(p.Updated)
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Query
+**********************************************************************/
+
+// from a query snippet (the part after WHERE)
+func (a *DBRetentionRule) ByDBQuery(ctx context.Context, query *Query) ([]*savepb.RetentionRule, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for col_name, value := range extra_fields {
+		i++
+		/*
+		   efname:=fmt.Sprintf("EXTRA_FIELD_%d",i)
+		   query.Add(col_name+" = "+efname,QP{efname:value})
+		*/
+		query.AddEqual(col_name, value)
+	}
+
+	gw, paras := query.ToPostgres()
+	queryname := "custom_dbquery"
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where "+gw, paras...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+
+}
+
+func (a *DBRetentionRule) FromQuery(ctx context.Context, query_where string, args ...interface{}) ([]*savepb.RetentionRule, error) {
+	return a.fromQuery(ctx, "custom_query_"+a.Tablename(), query_where, args...)
+}
+
+// from a query snippet (the part after WHERE)
+func (a *DBRetentionRule) fromQuery(ctx context.Context, queryname string, query_where string, args ...interface{}) ([]*savepb.RetentionRule, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	eq := ""
+	if extra_fields != nil && len(extra_fields) > 0 {
+		eq = " AND ("
+		// build the extraquery "eq"
+		i := len(args)
+		deli := ""
+		for col_name, value := range extra_fields {
+			i++
+			eq = eq + deli + col_name + fmt.Sprintf(" = $%d", i)
+			deli = " AND "
+			args = append(args, value)
+		}
+		eq = eq + ")"
+	}
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where ( "+query_where+") "+eq, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Row to struct
+**********************************************************************/
+func (a *DBRetentionRule) get_col_from_proto(p *savepb.RetentionRule, colname string) interface{} {
+	if colname == "id" {
+		return a.get_ID(p)
+	} else if colname == "artefactid" {
+		return a.get_ArtefactID(p)
+	} else if colname == "keeplast" {
+		return a.get_KeepLast(p)
+	} else if colname == "keepdays" {
+		return a.get_KeepDays(p)
+	} else if colname == "keepdownloadeddays" {
+		return a.get_KeepDownloadedDays(p)
+	} else if colname == "updatedby" {
+		return a.get_UpdatedBy(p)
+	} else if colname == "updated" {
+		return a.get_Updated(p)
+	}
+	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
+}
+
+func (a *DBRetentionRule) Tablename() string {
+	return a.SQLTablename
+}
+
+func (a *DBRetentionRule) SelectCols() string {
+	return "id,artefactid, keeplast, keepdays, keepdownloadeddays, updatedby, updated"
+}
+func (a *DBRetentionRule) SelectColsQualified() string {
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".artefactid, " + a.SQLTablename + ".keeplast, " + a.SQLTablename + ".keepdays, " + a.SQLTablename + ".keepdownloadeddays, " + a.SQLTablename + ".updatedby, " + a.SQLTablename + ".updated"
+}
+
+func (a *DBRetentionRule) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.RetentionRule, error) {
+	var res []*savepb.RetentionRule
+	for rows.Next() {
+		// SCANNER:
+		foo := &savepb.RetentionRule{}
+		// create the non-nullable pointers
+		// create variables for scan results
+		scanTarget_0 := &foo.ID
+		scanTarget_1 := &foo.ArtefactID
+		scanTarget_2 := &foo.KeepLast
+		scanTarget_3 := &foo.KeepDays
+		scanTarget_4 := &foo.KeepDownloadedDays
+		scanTarget_5 := &foo.UpdatedBy
+		scanTarget_6 := &foo.Updated
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4, scanTarget_5, scanTarget_6)
+		// END SCANNER
+
+		if err != nil {
+			return nil, a.Error(ctx, "fromrow-scan", err)
+		}
+		res = append(res, foo)
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to create table and columns
+**********************************************************************/
+func (a *DBRetentionRule) CreateTable(ctx context.Context) error {
+	csql := []string{
+		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,keeplast integer not null ,keepdays integer not null ,keepdownloadeddays integer not null ,updatedby text not null ,updated integer not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,keeplast integer not null ,keepdays integer not null ,keepdownloadeddays integer not null ,updatedby text not null ,updated integer not null );`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS keeplast integer not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS keepdays integer not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS keepdownloadeddays integer not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS updatedby text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS updated integer not null default 0;`,
+
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS artefactid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS keeplast integer not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS keepdays integer not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS keepdownloadeddays integer not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS updatedby text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS updated integer not null  default 0;`,
+	}
+
+	for i, c := range csql {
+		_, e := a.DB.ExecContext(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+		if e != nil {
+			return e
+		}
+	}
+
+	// these are optional, expected to fail
+	csql = []string{
+		// Indices:
+
+		// Foreign keys:
+
+	}
+	for i, c := range csql {
+		a.DB.ExecContextQuiet(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+	}
+	return nil
+}
+
+/**********************************************************************
+* Helper to meaningful errors
+**********************************************************************/
+func (a *DBRetentionRule) Error(ctx context.Context, q string, e error) error {
+	if e == nil {
+		return nil
+	}
+	return errors.Errorf("[table="+a.SQLTablename+", query=%s] Error: %s", q, e)
+}
+
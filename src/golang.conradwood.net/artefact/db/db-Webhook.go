@@ -0,0 +1,681 @@
+package db
+
+/*
+ This file was created by mkdb-client.
+ The intention is not to modify this file, but you may extend the struct DBWebhook
+ in a seperate file (so that you can regenerate this one from time to time)
+*/
+
+/*
+ PRIMARY KEY: ID
+*/
+
+/*
+ postgres:
+ create sequence webhook_seq;
+
+Main Table:
+
+ CREATE TABLE webhook (id integer primary key default nextval('webhook_seq'),artefactid bigint not null  ,domain text not null  ,url text not null  ,secret text not null  ,createdby text not null  ,created integer not null  );
+
+Alter statements:
+ALTER TABLE webhook ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;
+ALTER TABLE webhook ADD COLUMN IF NOT EXISTS domain text not null default '';
+ALTER TABLE webhook ADD COLUMN IF NOT EXISTS url text not null default '';
+ALTER TABLE webhook ADD COLUMN IF NOT EXISTS secret text not null default '';
+ALTER TABLE webhook ADD COLUMN IF NOT EXISTS createdby text not null default '';
+ALTER TABLE webhook ADD COLUMN IF NOT EXISTS created integer not null default 0;
+
+
+Archive Table: (structs can be moved from main to archive using Archive() function)
+
+ CREATE TABLE webhook_archive (id integer unique not null,artefactid bigint not null,domain text not null,url text not null,secret text not null,createdby text not null,created integer not null);
+*/
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	savepb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/sql"
+	"os"
+	"sync"
+)
+
+var (
+	default_def_DBWebhook *DBWebhook
+)
+
+type DBWebhook struct {
+	DB                   *sql.DB
+	SQLTablename         string
+	SQLArchivetablename  string
+	customColumnHandlers []CustomColumnHandler
+	lock                 sync.Mutex
+}
+
+func init() {
+	RegisterDBHandlerFactory(func() Handler {
+		return DefaultDBWebhook()
+	})
+}
+
+func DefaultDBWebhook() *DBWebhook {
+	if default_def_DBWebhook != nil {
+		return default_def_DBWebhook
+	}
+	psql, err := sql.Open()
+	if err != nil {
+		fmt.Printf("Failed to open database: %s\n", err)
+		os.Exit(10)
+	}
+	res := NewDBWebhook(psql)
+	ctx := context.Background()
+	err = res.CreateTable(ctx)
+	if err != nil {
+		fmt.Printf("Failed to create table: %s\n", err)
+		os.Exit(10)
+	}
+	default_def_DBWebhook = res
+	return res
+}
+func NewDBWebhook(db *sql.DB) *DBWebhook {
+	foo := DBWebhook{DB: db}
+	foo.SQLTablename = "webhook"
+	foo.SQLArchivetablename = "webhook_archive"
+	return &foo
+}
+
+func (a *DBWebhook) GetCustomColumnHandlers() []CustomColumnHandler {
+	return a.customColumnHandlers
+}
+func (a *DBWebhook) AddCustomColumnHandler(w CustomColumnHandler) {
+	a.lock.Lock()
+	a.customColumnHandlers = append(a.customColumnHandlers, w)
+	a.lock.Unlock()
+}
+
+func (a *DBWebhook) NewQuery() *Query {
+	return newQuery(a)
+}
+
+// archive. It is NOT transactionally save.
+func (a *DBWebhook) Archive(ctx context.Context, id uint64) error {
+
+	// load it
+	p, err := a.ByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// now save it to archive:
+	_, e := a.DB.ExecContext(ctx, "archive_DBWebhook", "insert into "+a.SQLArchivetablename+" (id,artefactid, domain, url, secret, createdby, created) values ($1,$2, $3, $4, $5, $6, $7) ", p.ID, p.ArtefactID, p.Domain, p.URL, p.Secret, p.CreatedBy, p.Created)
+	if e != nil {
+		return e
+	}
+
+	// now delete it.
+	a.DeleteByID(ctx, id)
+	return nil
+}
+
+// return a map with columnname -> value_from_proto
+func (a *DBWebhook) buildSaveMap(ctx context.Context, p *savepb.Webhook) (map[string]interface{}, error) {
+	extra, err := extraFieldsToStore(ctx, a, p)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{})
+	res["id"] = a.get_col_from_proto(p, "id")
+	res["artefactid"] = a.get_col_from_proto(p, "artefactid")
+	res["domain"] = a.get_col_from_proto(p, "domain")
+	res["url"] = a.get_col_from_proto(p, "url")
+	res["secret"] = a.get_col_from_proto(p, "secret")
+	res["createdby"] = a.get_col_from_proto(p, "createdby")
+	res["created"] = a.get_col_from_proto(p, "created")
+	if extra != nil {
+		for k, v := range extra {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (a *DBWebhook) Save(ctx context.Context, p *savepb.Webhook) (uint64, error) {
+	qn := "save_DBWebhook"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	delete(smap, "id") // save without id
+	return a.saveMap(ctx, qn, smap, p)
+}
+
+// Save using the ID specified
+func (a *DBWebhook) SaveWithID(ctx context.Context, p *savepb.Webhook) error {
+	qn := "insert_DBWebhook"
+	smap, err := a.buildSaveMap(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, err = a.saveMap(ctx, qn, smap, p)
+	return err
+}
+
+// use a hashmap of columnname->values to store to database (see buildSaveMap())
+func (a *DBWebhook) saveMap(ctx context.Context, queryname string, smap map[string]interface{}, p *savepb.Webhook) (uint64, error) {
+	// Save (and use database default ID generation)
+
+	var rows *gosql.Rows
+	var e error
+
+	q_cols := ""
+	q_valnames := ""
+	q_vals := make([]interface{}, 0)
+	deli := ""
+	i := 0
+	// build the 2 parts of the query (column names and value names) as well as the values themselves
+	for colname, val := range smap {
+		q_cols = q_cols + deli + colname
+		i++
+		q_valnames = q_valnames + deli + fmt.Sprintf("$%d", i)
+		q_vals = append(q_vals, val)
+		deli = ","
+	}
+	rows, e = a.DB.QueryContext(ctx, queryname, "insert into "+a.SQLTablename+" ("+q_cols+") values ("+q_valnames+") returning id", q_vals...)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, e)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, a.Error(ctx, queryname, errors.Errorf("No rows after insert"))
+	}
+	var id uint64
+	e = rows.Scan(&id)
+	if e != nil {
+		return 0, a.Error(ctx, queryname, errors.Errorf("failed to scan id after insert: %s", e))
+	}
+	p.ID = id
+	return id, nil
+}
+
+// if ID==0 save, otherwise update
+func (a *DBWebhook) SaveOrUpdate(ctx context.Context, p *savepb.Webhook) error {
+	if p.ID == 0 {
+		_, err := a.Save(ctx, p)
+		return err
+	}
+	return a.Update(ctx, p)
+}
+func (a *DBWebhook) Update(ctx context.Context, p *savepb.Webhook) error {
+	qn := "DBWebhook_Update"
+	_, e := a.DB.ExecContext(ctx, qn, "update "+a.SQLTablename+" set artefactid=$1, domain=$2, url=$3, secret=$4, createdby=$5, created=$6 where id = $7", a.get_ArtefactID(p), a.get_Domain(p), a.get_URL(p), a.get_Secret(p), a.get_CreatedBy(p), a.get_Created(p), p.ID)
+
+	return a.Error(ctx, qn, e)
+}
+
+// delete by id field
+func (a *DBWebhook) DeleteByID(ctx context.Context, p uint64) error {
+	qn := "deleteDBWebhook_ByID"
+	_, e := a.DB.ExecContext(ctx, qn, "delete from "+a.SQLTablename+" where id = $1", p)
+	return a.Error(ctx, qn, e)
+}
+
+// get it by primary id
+func (a *DBWebhook) ByID(ctx context.Context, p uint64) (*savepb.Webhook, error) {
+	qn := "DBWebhook_ByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, a.Error(ctx, qn, errors.Errorf("No Webhook with id %v", p))
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) Webhook with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by primary id (nil if no such ID row, but no error either)
+func (a *DBWebhook) TryByID(ctx context.Context, p uint64) (*savepb.Webhook, error) {
+	qn := "DBWebhook_TryByID"
+	l, e := a.fromQuery(ctx, qn, "id = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	if len(l) == 0 {
+		return nil, nil
+	}
+	if len(l) != 1 {
+		return nil, a.Error(ctx, qn, errors.Errorf("Multiple (%d) Webhook with id %v", len(l), p))
+	}
+	return l[0], nil
+}
+
+// get it by multiple primary ids
+func (a *DBWebhook) ByIDs(ctx context.Context, p []uint64) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByIDs"
+	l, e := a.fromQuery(ctx, qn, "id in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("TryByID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all rows
+func (a *DBWebhook) All(ctx context.Context) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_all"
+	l, e := a.fromQuery(ctx, qn, "true")
+	if e != nil {
+		return nil, errors.Errorf("All: error scanning (%s)", e)
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* GetBy[FIELD] functions
+**********************************************************************/
+
+// get all "DBWebhook" rows with matching ArtefactID
+func (a *DBWebhook) ByArtefactID(ctx context.Context, p uint64) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with multiple matching ArtefactID
+func (a *DBWebhook) ByMultiArtefactID(ctx context.Context, p []uint64) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBWebhook) ByLikeArtefactID(ctx context.Context, p uint64) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByLikeArtefactID"
+	l, e := a.fromQuery(ctx, qn, "artefactid ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByArtefactID: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with matching Domain
+func (a *DBWebhook) ByDomain(ctx context.Context, p string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByDomain"
+	l, e := a.fromQuery(ctx, qn, "domain = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByDomain: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with multiple matching Domain
+func (a *DBWebhook) ByMultiDomain(ctx context.Context, p []string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByDomain"
+	l, e := a.fromQuery(ctx, qn, "domain in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByDomain: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBWebhook) ByLikeDomain(ctx context.Context, p string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByLikeDomain"
+	l, e := a.fromQuery(ctx, qn, "domain ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByDomain: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with matching URL
+func (a *DBWebhook) ByURL(ctx context.Context, p string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByURL"
+	l, e := a.fromQuery(ctx, qn, "url = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByURL: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with multiple matching URL
+func (a *DBWebhook) ByMultiURL(ctx context.Context, p []string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByURL"
+	l, e := a.fromQuery(ctx, qn, "url in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByURL: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBWebhook) ByLikeURL(ctx context.Context, p string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByLikeURL"
+	l, e := a.fromQuery(ctx, qn, "url ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByURL: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with matching Secret
+func (a *DBWebhook) BySecret(ctx context.Context, p string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_BySecret"
+	l, e := a.fromQuery(ctx, qn, "secret = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySecret: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with multiple matching Secret
+func (a *DBWebhook) ByMultiSecret(ctx context.Context, p []string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_BySecret"
+	l, e := a.fromQuery(ctx, qn, "secret in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySecret: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBWebhook) ByLikeSecret(ctx context.Context, p string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByLikeSecret"
+	l, e := a.fromQuery(ctx, qn, "secret ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("BySecret: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with matching CreatedBy
+func (a *DBWebhook) ByCreatedBy(ctx context.Context, p string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByCreatedBy"
+	l, e := a.fromQuery(ctx, qn, "createdby = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with multiple matching CreatedBy
+func (a *DBWebhook) ByMultiCreatedBy(ctx context.Context, p []string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByCreatedBy"
+	l, e := a.fromQuery(ctx, qn, "createdby in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBWebhook) ByLikeCreatedBy(ctx context.Context, p string) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByLikeCreatedBy"
+	l, e := a.fromQuery(ctx, qn, "createdby ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreatedBy: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with matching Created
+func (a *DBWebhook) ByCreated(ctx context.Context, p uint32) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByCreated"
+	l, e := a.fromQuery(ctx, qn, "created = $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// get all "DBWebhook" rows with multiple matching Created
+func (a *DBWebhook) ByMultiCreated(ctx context.Context, p []uint32) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByCreated"
+	l, e := a.fromQuery(ctx, qn, "created in $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+// the 'like' lookup
+func (a *DBWebhook) ByLikeCreated(ctx context.Context, p uint32) ([]*savepb.Webhook, error) {
+	qn := "DBWebhook_ByLikeCreated"
+	l, e := a.fromQuery(ctx, qn, "created ilike $1", p)
+	if e != nil {
+		return nil, a.Error(ctx, qn, errors.Errorf("ByCreated: error scanning (%s)", e))
+	}
+	return l, nil
+}
+
+/**********************************************************************
+* The field getters
+**********************************************************************/
+
+// getter for field "ID" (ID) [uint64]
+func (a *DBWebhook) get_ID(p *savepb.Webhook) uint64 {
+	return uint64(p.ID)
+}
+
+// getter for field "ArtefactID" (ArtefactID) [uint64]
+func (a *DBWebhook) get_ArtefactID(p *savepb.Webhook) uint64 {
+	return uint64(p.ArtefactID)
+}
+
+// getter for field "Domain" (Domain) [string]
+func (a *DBWebhook) get_Domain(p *savepb.Webhook) string {
+	return string(p.Domain)
+}
+
+// getter for field "URL" (URL) [string]
+func (a *DBWebhook) get_URL(p *savepb.Webhook) string {
+	return string(p.URL)
+}
+
+// getter for field "Secret" (Secret) [string]
+func (a *DBWebhook) get_Secret(p *savepb.Webhook) string {
+	return string(p.Secret)
+}
+
+// getter for field "CreatedBy" (CreatedBy) [string]
+func (a *DBWebhook) get_CreatedBy(p *savepb.Webhook) string {
+	return string(p.CreatedBy)
+}
+
+// getter for field "Created" (Created) [uint32]
+func (a *DBWebhook) get_Created(p *savepb.Webhook) uint32 {
+	return uint32(p.Created)
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Query
+**********************************************************************/
+
+// from a query snippet (the part after WHERE)
+func (a *DBWebhook) ByDBQuery(ctx context.Context, query *Query) ([]*savepb.Webhook, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for col_name, value := range extra_fields {
+		i++
+		/*
+		   efname:=fmt.Sprintf("EXTRA_FIELD_%d",i)
+		   query.Add(col_name+" = "+efname,QP{efname:value})
+		*/
+		query.AddEqual(col_name, value)
+	}
+
+	gw, paras := query.ToPostgres()
+	queryname := "custom_dbquery"
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where "+gw, paras...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+
+}
+
+func (a *DBWebhook) FromQuery(ctx context.Context, query_where string, args ...interface{}) ([]*savepb.Webhook, error) {
+	return a.fromQuery(ctx, "custom_query_"+a.Tablename(), query_where, args...)
+}
+
+// from a query snippet (the part after WHERE)
+func (a *DBWebhook) fromQuery(ctx context.Context, queryname string, query_where string, args ...interface{}) ([]*savepb.Webhook, error) {
+	extra_fields, err := extraFieldsToQuery(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	eq := ""
+	if extra_fields != nil && len(extra_fields) > 0 {
+		eq = " AND ("
+		// build the extraquery "eq"
+		i := len(args)
+		deli := ""
+		for col_name, value := range extra_fields {
+			i++
+			eq = eq + deli + col_name + fmt.Sprintf(" = $%d", i)
+			deli = " AND "
+			args = append(args, value)
+		}
+		eq = eq + ")"
+	}
+	rows, err := a.DB.QueryContext(ctx, queryname, "select "+a.SelectCols()+" from "+a.Tablename()+" where ( "+query_where+") "+eq, args...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.FromRows(ctx, rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to convert from an SQL Row to struct
+**********************************************************************/
+func (a *DBWebhook) get_col_from_proto(p *savepb.Webhook, colname string) interface{} {
+	if colname == "id" {
+		return a.get_ID(p)
+	} else if colname == "artefactid" {
+		return a.get_ArtefactID(p)
+	} else if colname == "domain" {
+		return a.get_Domain(p)
+	} else if colname == "url" {
+		return a.get_URL(p)
+	} else if colname == "secret" {
+		return a.get_Secret(p)
+	} else if colname == "createdby" {
+		return a.get_CreatedBy(p)
+	} else if colname == "created" {
+		return a.get_Created(p)
+	}
+	panic(fmt.Sprintf("in table \"%s\", column \"%s\" cannot be resolved to proto field name", a.Tablename(), colname))
+}
+
+func (a *DBWebhook) Tablename() string {
+	return a.SQLTablename
+}
+
+func (a *DBWebhook) SelectCols() string {
+	return "id,artefactid, domain, url, secret, createdby, created"
+}
+func (a *DBWebhook) SelectColsQualified() string {
+	return "" + a.SQLTablename + ".id," + a.SQLTablename + ".artefactid, " + a.SQLTablename + ".domain, " + a.SQLTablename + ".url, " + a.SQLTablename + ".secret, " + a.SQLTablename + ".createdby, " + a.SQLTablename + ".created"
+}
+
+func (a *DBWebhook) FromRows(ctx context.Context, rows *gosql.Rows) ([]*savepb.Webhook, error) {
+	var res []*savepb.Webhook
+	for rows.Next() {
+		// SCANNER:
+		foo := &savepb.Webhook{}
+		// create the non-nullable pointers
+		// create variables for scan results
+		scanTarget_0 := &foo.ID
+		scanTarget_1 := &foo.ArtefactID
+		scanTarget_2 := &foo.Domain
+		scanTarget_3 := &foo.URL
+		scanTarget_4 := &foo.Secret
+		scanTarget_5 := &foo.CreatedBy
+		scanTarget_6 := &foo.Created
+		err := rows.Scan(scanTarget_0, scanTarget_1, scanTarget_2, scanTarget_3, scanTarget_4, scanTarget_5, scanTarget_6)
+		// END SCANNER
+
+		if err != nil {
+			return nil, a.Error(ctx, "fromrow-scan", err)
+		}
+		res = append(res, foo)
+	}
+	return res, nil
+}
+
+/**********************************************************************
+* Helper to create table and columns
+**********************************************************************/
+func (a *DBWebhook) CreateTable(ctx context.Context) error {
+	csql := []string{
+		`create sequence if not exists ` + a.SQLTablename + `_seq;`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + ` (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,domain text not null ,url text not null ,secret text not null ,createdby text not null ,created integer not null );`,
+		`CREATE TABLE if not exists ` + a.SQLTablename + `_archive (id integer primary key default nextval('` + a.SQLTablename + `_seq'),artefactid bigint not null ,domain text not null ,url text not null ,secret text not null ,createdby text not null ,created integer not null );`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS artefactid bigint not null default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS domain text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS url text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS secret text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS createdby text not null default '';`,
+		`ALTER TABLE ` + a.SQLTablename + ` ADD COLUMN IF NOT EXISTS created integer not null default 0;`,
+
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS artefactid bigint not null  default 0;`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS domain text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS url text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS secret text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS createdby text not null  default '';`,
+		`ALTER TABLE ` + a.SQLTablename + `_archive  ADD COLUMN IF NOT EXISTS created integer not null  default 0;`,
+	}
+
+	for i, c := range csql {
+		_, e := a.DB.ExecContext(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+		if e != nil {
+			return e
+		}
+	}
+
+	// these are optional, expected to fail
+	csql = []string{
+		// Indices:
+
+		// Foreign keys:
+
+	}
+	for i, c := range csql {
+		a.DB.ExecContextQuiet(ctx, fmt.Sprintf("create_"+a.SQLTablename+"_%d", i), c)
+	}
+	return nil
+}
+
+/**********************************************************************
+* Helper to meaningful errors
+**********************************************************************/
+func (a *DBWebhook) Error(ctx context.Context, q string, e error) error {
+	if e == nil {
+		return nil
+	}
+	return errors.Errorf("[table="+a.SQLTablename+", query=%s] Error: %s", q, e)
+}
+
@@ -14,6 +14,7 @@ import (
 
 var (
 	perm_cache        = cache.New("perm_cache", time.Duration(120)*time.Second, 1000)
+	readable_cache    = cache.New("readable_cache", time.Duration(120)*time.Second, 1000)
 	always_allow_root = flag.Bool("always_allow_root", true, "if true root gets access to every artefact")
 )
 
@@ -22,6 +23,16 @@ type perm_cache_entry struct {
 	allowed    bool
 }
 
+// the artefacts a caller may read
+type readable_set struct {
+	all bool // e.g. root
+	ids map[uint64]bool
+}
+
+func (rs *readable_set) allowed(artefactid uint64) bool {
+	return rs.all || rs.ids[artefactid]
+}
+
 func requestAccessLinkReference(ctx context.Context, lr *LinkReference) error {
 	_, err := requestAccess(ctx, lr.ArtefactName(), lr.Domain())
 	return err
@@ -84,35 +95,118 @@ func requestAccess(ctx context.Context, artefactName string, domain string) (uin
 	if svc != nil && svc.ID == auth.GetServiceIDByName("repobuilder.RepoBuilder") {
 		return rid, nil
 	}
-	if *debug {
-		fmt.Printf("getting user access right\n")
+	allowed, err := userMayRead(ctx, u.ID, rid)
+	if err != nil {
+		return 0, err
+	}
+	if !allowed {
+		return 0, errors.AccessDenied(ctx, "(2) access to artefact %s (#%d) denied", artefactName, rid)
 	}
-	key := fmt.Sprintf("%s_%d", u.ID, rid)
+	return rid, nil
+}
+
+// true if the user has view and read access to the artefact (cached). This is the only check of
+// user permissions, requestAccess() and readableArtefacts() both use it
+func userMayRead(ctx context.Context, userid string, artefactid uint64) (bool, error) {
+	key := perm_cache_key(userid, artefactid)
 	perm_cache_object := perm_cache.Get(key)
 	if perm_cache_object != nil {
-		pce := perm_cache_object.(*perm_cache_entry)
-		if !pce.allowed {
-			return 0, errors.AccessDenied(ctx, "(1) access to artefact #%d (%s) denied", rid, artefactName)
-		}
-		return rid, nil
+		return perm_cache_object.(*perm_cache_entry).allowed, nil
 	}
-
-	oa := &objectauth.AuthRequest{ObjectType: objectauth.OBJECTTYPE_Artefact, ObjectID: rid}
+	if *debug {
+		fmt.Printf("getting user access right\n")
+	}
+	oa := &objectauth.AuthRequest{ObjectType: objectauth.OBJECTTYPE_Artefact, ObjectID: artefactid}
 	ar, err := objectauth.GetObjectAuthServiceClient().AskObjectAccess(ctx, oa)
 	if err != nil {
-		return 0, err
+		return false, err
 	}
-	if *debug {
-		fmt.Printf("user access right, view=%v, read=%v\n", ar.Permissions.View, ar.Permissions.Read)
+	allowed := ar.Permissions.View && ar.Permissions.Read
+	if *debug && !allowed {
+		fmt.Printf("Access by user %s for artefact #%d DENIED (permissions=%v)\n", userid, artefactid, ar.Permissions)
 	}
+	perm_cache.Put(key, &perm_cache_entry{artefactid: artefactid, allowed: allowed})
+	return allowed, nil
+}
 
-	if ar.Permissions.View && ar.Permissions.Read {
-		perm_cache.Put(key, &perm_cache_entry{artefactid: rid, allowed: true})
-		return rid, nil
+func perm_cache_key(userid string, artefactid uint64) string {
+	return fmt.Sprintf("%s_%d", userid, artefactid)
+}
+
+// remove cached permissions, for example after they changed
+func invalidate_perm_cache(userid string, artefactid uint64) {
+	perm_cache.Evict(perm_cache_key(userid, artefactid))
+	readable_cache.Evict(userid)
+}
+
+// all artefacts the caller may read (cached per user). use this instead of requestAccess() when checking many artefacts.
+// AvailableObjects lists the artefacts the user has any permission on, each of them is then checked with
+// userMayRead(), so that listings show exactly the artefacts requestAccess() allows
+func readableArtefacts(ctx context.Context) (*readable_set, error) {
+	svc := auth.GetService(ctx)
+	if svc != nil {
+		aar := &objectauth.AllAccessRequest{ObjectType: objectauth.OBJECTTYPE_Artefact, ServiceID: svc.ID}
+		ar, err := objectauth.GetObjectAuthClient().AllowAllServiceAccess(ctx, aar)
+		if err == nil && ar.ReadAccess {
+			return &readable_set{all: true}, nil
+		}
+	}
+	if is_privileged_service(ctx) {
+		return &readable_set{all: true}, nil
+	}
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return nil, errors.Unauthenticated(ctx, "(6) access to artefacts denied")
+	}
+	if *always_allow_root && auth.IsRoot(ctx) {
+		return &readable_set{all: true}, nil
+	}
+	if svc != nil && svc.ID == auth.GetServiceIDByName("repobuilder.RepoBuilder") {
+		return &readable_set{all: true}, nil
+	}
+	o := readable_cache.Get(u.ID)
+	if o != nil {
+		return o.(*readable_set), nil
+	}
+	started := time.Now()
+	ol, err := objectauth.GetObjectAuthServiceClient().AvailableObjects(ctx, &objectauth.ObjectType{ObjectType: objectauth.OBJECTTYPE_Artefact})
+	if err != nil {
+		return nil, err
+	}
+	rs := &readable_set{ids: make(map[uint64]bool)}
+	for _, id := range ol.ObjectIDs {
+		allowed, err := userMayRead(ctx, u.ID, id)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			rs.ids[id] = true
+		}
 	}
 	if *debug {
-		fmt.Printf("Access by %s [%s] for %s in %s DENIED (permissions=%v)\n", auth.Description(u), u.ID, artefactName, domain, ar.Permissions)
+		fmt.Printf("User %s may read %d artefacts (took %0.2fs)\n", auth.Description(u), len(rs.ids), time.Since(started).Seconds())
+	}
+	readable_cache.Put(u.ID, rs)
+	return rs, nil
+}
+
+// returns nil if the caller may change access rights to the artefact
+func requestAdminAccess(ctx context.Context, artefactid uint64) error {
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return errors.Unauthenticated(ctx, "(4) admin access to artefact #%d denied", artefactid)
+	}
+	if auth.IsRoot(ctx) {
+		return nil
+	}
+	oa := &objectauth.AuthRequest{ObjectType: objectauth.OBJECTTYPE_Artefact, ObjectID: artefactid}
+	ar, err := objectauth.GetObjectAuthServiceClient().AskObjectAccess(ctx, oa)
+	if err != nil {
+		return err
+	}
+	if ar.Permissions.View && ar.Permissions.Read && ar.Permissions.Write {
+		return nil
 	}
-	perm_cache.Put(key, &perm_cache_entry{artefactid: rid, allowed: false})
-	return 0, errors.AccessDenied(ctx, "(2) access to artefact %s (#%d) denied", artefactName, rid)
+	fmt.Printf("Admin access by %s [%s] for artefact #%d DENIED (permissions=%v)\n", auth.Description(u), u.ID, artefactid, ar.Permissions)
+	return errors.AccessDenied(ctx, "(5) admin access to artefact #%d denied", artefactid)
 }
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	h2g "golang.conradwood.net/apis/h2gproxy"
+	"golang.conradwood.net/go-easyops/errors"
+)
+
+/*
+ download a directory of a build (recursively) as zip or tar.gz archive.
+ the archive is created on the fly whilst files are streamed from the buildrepo.
+ all files in the archive are prefixed with <artefactname>-<build>/
+*/
+
+type archive_request struct {
+	artefact *pb.ArtefactID
+	branch   string
+	build    uint64
+	dir      string
+	format   pb.ArchiveFormat
+}
+
+func (e *artefactServer) GetDirArchive(req *pb.ArchiveRequest, srv pb.ArtefactService_GetDirArchiveServer) error {
+	ctx := srv.Context()
+	af, err := idstore.ByID(ctx, req.ArtefactID)
+	if err != nil {
+		return err
+	}
+	_, xerr := requestAccess(ctx, af.Name, af.Domain)
+	if xerr != nil {
+		return xerr
+	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return err
+	}
+	ar := &archive_request{
+		artefact: af,
+		branch:   branch,
+		build:    req.Build,
+		dir:      req.Dir,
+		format:   req.Format,
+	}
+	if ar.build == 0 {
+		glv, err := brepo.GetLatestVersion(ctx, af.Domain, &br.GetLatestVersionRequest{Repository: af.Name, Branch: ar.branch})
+		if err != nil {
+			return err
+		}
+		ar.build, err = latestUsableBuild(ctx, af, ar.branch, glv.BuildID)
+		if err != nil {
+			return err
+		}
+	}
+	// the archive stream has no way to carry a warning, deprecated (or warned about yanked) builds are just logged
+	warning, err := checkDownload(ctx, af.ID, ar.branch, ar.build)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		fmt.Printf("archive of artefact #%d: %s\n", af.ID, warning)
+	}
+	recordDownload(ctx, af.ID, ar.branch, ar.build)
+	return writeArchive(ctx, ar, &serverwriter{srv: srv})
+}
+
+// the "format" parameter of an http request. returns false if it is not an archive request
+func archiveFormatFromHTTP(req *h2g.StreamRequest) (pb.ArchiveFormat, bool) {
+	for _, p := range req.Parameters {
+		if p.Name != "format" {
+			continue
+		}
+		switch strings.ToLower(p.Value) {
+		case "zip":
+			return pb.ArchiveFormat_ZIP, true
+		case "tar.gz", "tgz", "targz":
+			return pb.ArchiveFormat_TARGZ, true
+		}
+	}
+	return pb.ArchiveFormat_ZIP, false
+}
+
+// send an archive via http
+func sendHTTPArchive(ctx context.Context, srv pb.ArtefactService_StreamHTTPServer, ar *archive_request) error {
+	fname := ar.Filename()
+	mimetype := "application/zip"
+	if ar.format == pb.ArchiveFormat_TARGZ {
+		mimetype = "application/gzip"
+	}
+	err := srv.Send(&h2g.StreamDataResponse{Response: &h2g.StreamResponse{
+		Filename: fname,
+		MimeType: mimetype,
+	}})
+	if err != nil {
+		return err
+	}
+	return writeArchive(ctx, ar, &httpwriter{srv: srv})
+}
+
+// e.g. foo-123-dist.zip
+func (ar *archive_request) Filename() string {
+	res := ar.prefix()
+	d := strings.Trim(ar.dir, "/")
+	if d != "" {
+		res = res + "-" + strings.ReplaceAll(d, "/", "_")
+	}
+	if ar.format == pb.ArchiveFormat_TARGZ {
+		return res + ".tar.gz"
+	}
+	return res + ".zip"
+}
+func (ar *archive_request) prefix() string {
+	return fmt.Sprintf("%s-%d", ar.artefact.Name, ar.build)
+}
+
+func writeArchive(ctx context.Context, ar *archive_request, w io.Writer) error {
+	dir := strings.Trim(ar.dir, "/")
+	lfr, _, err := brepo.ListFiles(ctx, ar.artefact.Domain, &br.ListFilesRequest{
+		Repository: ar.artefact.Name,
+		Branch:     ar.branch,
+		BuildID:    ar.build,
+		Recursive:  true,
+		Dir:        dir,
+	})
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, entry := range lfr.Entries {
+		if entry.Type != 1 {
+			continue
+		}
+		fname := strings.TrimPrefix(entry.Dir+"/"+entry.Name, "/")
+		if dir != "" && !strings.HasPrefix(fname, dir+"/") {
+			continue
+		}
+		files = append(files, fname)
+	}
+	if len(files) == 0 {
+		return errors.NotFound(ctx, "no files in \"%s\"", ar.dir)
+	}
+	debugf("archiving %d files from %s, build %d, dir \"%s\"\n", len(files), ar.artefact.Name, ar.build, dir)
+	if ar.format == pb.ArchiveFormat_TARGZ {
+		return writeTarGz(ctx, ar, dir, files, w)
+	}
+	return writeZip(ctx, ar, dir, files, w)
+}
+
+func writeZip(ctx context.Context, ar *archive_request, dir string, files []string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, fname := range files {
+		fh := &zip.FileHeader{
+			Name:     archiveName(ar, dir, fname),
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		}
+		fw, err := zw.CreateHeader(fh)
+		if err != nil {
+			return err
+		}
+		err = brepo.GetFile(ctx, ar.artefact.Domain, &br.GetFileRequest{File: ar.file(fname), Blocksize: 8192}, fw)
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// tar needs the size before the contents, so we ask the buildrepo for each file
+func writeTarGz(ctx context.Context, ar *archive_request, dir string, files []string, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, fname := range files {
+		file := ar.file(fname)
+		glv, err := brepo.GetFileMetaData(ctx, ar.artefact.Domain, &br.GetMetaRequest{File: file})
+		if err != nil {
+			return err
+		}
+		err = tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     archiveName(ar, dir, fname),
+			Size:     int64(glv.Size),
+			Mode:     0644,
+			ModTime:  time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		err = brepo.GetFile(ctx, ar.artefact.Domain, &br.GetFileRequest{File: file, Blocksize: 8192}, tw)
+		if err != nil {
+			return err
+		}
+	}
+	err := tw.Close()
+	if err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func (ar *archive_request) file(fname string) *br.File {
+	return &br.File{
+		Repository: ar.artefact.Name,
+		Branch:     ar.branch,
+		BuildID:    ar.build,
+		Filename:   fname,
+	}
+}
+
+// name of a file within the archive
+func archiveName(ar *archive_request, dir string, fname string) string {
+	rel := strings.TrimPrefix(strings.TrimPrefix(fname, dir), "/")
+	return filepath.Join(ar.prefix(), rel)
+}
@@ -63,6 +63,11 @@ sd.SetOnStartupCallback(startup)
 }
 func startup() {
 	server.SetHealth(common.Health_READY)
+	go catalogue_refresher()
+	go repo_indexer()
+	go file_indexer()
+	go retention_executor()
+	startBuildPoller() // for webhooks
 }
 
 /************************************
@@ -75,9 +80,13 @@ func (e *artefactServer) GetRepoVersion(ctx context.Context, req *pb.GetVersionR
 		return nil, xerr
 	}
 
+	branch, err := resolveBranch(ctx, req.Domain, req.Name, req.Branch)
+	if err != nil {
+		return nil, err
+	}
 	lfr, t, err := brepo.ListFiles(ctx, req.Domain, &br.ListFilesRequest{
 		Repository: req.Name,
-		Branch:     "master",
+		Branch:     branch,
 		BuildID:    req.Version,
 		Recursive:  false,
 		Dir:        "",
@@ -96,6 +105,12 @@ func (e *artefactServer) GetRepoVersion(ctx context.Context, req *pb.GetVersionR
 		AdminAccess: adminAccess,
 		Domain:      req.Domain,
 		BuildRepo:   t,
+		Branch:      branch,
+	}
+	af, err := artefactByName(ctx, req.Domain, req.Name)
+	if err == nil {
+		ct.Channels = buildChannels(ctx, af.ID, branch, req.Version)
+		ct.Build = buildInfo(ctx, af.ID, branch, req.Version, knownBuildRecords(ctx, af.ID))
 	}
 	createArtefactReference(ct)
 
@@ -107,40 +122,31 @@ func (e *artefactServer) Find(ctx context.Context, req *pb.FindRequest) (*pb.Art
 	if u == nil {
 		return nil, errors.Unauthenticated(ctx, "need user account to find stuff")
 	}
-	repos, err := brepo.ListRepos(ctx)
+	c, err := getCatalogue()
 	if err != nil {
 		return nil, err
 	}
-	all := &pb.ArtefactList{}
-	nm := strings.ToLower(charsOnly(req.NameMatch))
-	for _, be := range repos.Entries {
-		an := strings.ToLower(charsOnly(be.Name))
-		//fmt.Printf("Checking %s\n", an)
-		if strings.Contains(an, nm) {
-			a := &pb.Contents{
-				Name:        be.Name,
-				AdminAccess: false,
-				Type:        pb.ContentType_Artefact,
-				Domain:      be.Domain,
-				BuildRepo:   be.Server,
-			}
-			all.Artefacts = append(all.Artefacts, a)
-		}
+	q := &artefact_query{
+		filter:     req.Filter,
+		order:      req.Order,
+		pagesize:   req.PageSize,
+		pagetoken:  req.PageToken,
+		maxresults: req.MaxResults,
 	}
-	fmt.Printf("Found %d artefacts matching \"%s\"\n", len(all.Artefacts), nm)
-	cf := ContentFiller{ctx: ctx, warningOnAccessDenied: true}
-	for _, a := range all.Artefacts {
-		cf.fillContent(a)
+	if strings.TrimSpace(req.NameMatch) != "" {
+		q.score = func(ce *catalogue_entry) float64 {
+			return searchScore(req.NameMatch, ce)
+		}
 	}
-	res := &pb.ArtefactList{Artefacts: cf.withRead}
-	p := fmt.Sprintf("[%s %s] ", auth.Description(u), u.Email)
-	fmt.Printf("%sFound %d matches for findrequest by name \"%s\"\n", p, len(res.Artefacts), req.NameMatch)
+	res, err := c.artefactList(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	sort.Slice(res.Artefacts, func(i, j int) bool {
-		return res.Artefacts[i].Name < res.Artefacts[j].Name
-	})
+	for _, af := range res.Artefacts {
+		createArtefactReference(af)
+	}
+	p := fmt.Sprintf("[%s %s] ", auth.Description(u), u.Email)
+	fmt.Printf("%sFound %d matches for findrequest by name \"%s\"\n", p, len(res.Artefacts), req.NameMatch)
 	return res, nil
 }
 
@@ -152,59 +158,17 @@ func (e *artefactServer) List(ctx context.Context, req *common.Void) (*pb.Artefa
 	if u == nil {
 		return nil, errors.Unauthenticated(ctx, "no user for List()")
 	}
-
-	var err error
-	resp := &pb.ArtefactList{}
-	repos, err := brepo.ListRepos(ctx)
+	c, err := getCatalogue()
 	if err != nil {
 		return nil, err
 	}
-	adminAccess := auth.IsRoot(ctx)
-	var wg sync.WaitGroup
-	for _, entry := range repos.Entries {
-		wg.Add(1)
-		go func(e *buildrepo.RepoEntry) {
-			defer wg.Done()
-
-			rid, xerr := requestAccess(ctx, e.Name, e.Domain)
-			if xerr != nil {
-				return
-			}
-
-			af := &pb.Contents{
-				Name:        e.Name,
-				AdminAccess: adminAccess,
-				Type:        pb.ContentType_Artefact,
-				Domain:      e.Domain,
-				ArtefactID:  &pb.ArtefactID{ID: rid},
-				BuildRepo:   e.Server,
-			}
-			resp.Artefacts = append(resp.Artefacts, af)
-			glv, lerr := brepo.GetLatestVersion(ctx, af.Domain, &br.GetLatestVersionRequest{
-				Repository: af.Name,
-				Branch:     "master",
-			})
-			if lerr != nil {
-				err = lerr
-			} else {
-				af.Version = glv.BuildID
-				if glv.BuildMeta != nil {
-					af.RepositoryID = glv.BuildMeta.RepositoryID
-				}
-			}
-			createArtefactReference(af)
-			if *debug {
-				fmt.Printf("Entry: %#v\n", e)
-			}
-		}(entry)
-	}
-	wg.Wait()
+	resp, err := c.artefactList(ctx, &artefact_query{})
 	if err != nil {
 		return nil, err
 	}
-	sort.Slice(resp.Artefacts, func(i, j int) bool {
-		return resp.Artefacts[i].Name < resp.Artefacts[j].Name
-	})
+	for _, af := range resp.Artefacts {
+		createArtefactReference(af)
+	}
 	return resp, nil
 }
 
@@ -276,6 +240,7 @@ func (e *artefactServer) GetContents(ctx context.Context, req *pb.Reference) (*p
 		BuildRepo:    ref.buildrepo,
 		ArtefactID:   af,
 		RepositoryID: glv.BuildMeta.RepositoryID,
+		Branch:       ref.Branch(),
 	}
 	createArtefactReference(res)
 	backref := &pb.ArtefactRef{Name: res.Name, Version: res.Version}
@@ -294,6 +259,7 @@ func (e *artefactServer) GetContents(ctx context.Context, req *pb.Reference) (*p
 			Domain:           ref.domain,
 			BuildRepo:        ref.buildrepo,
 			RepositoryID:     glv.BuildMeta.RepositoryID,
+			Branch:           ref.Branch(),
 		}
 		if entry.Type == 1 {
 			c.Type = pb.ContentType_File
@@ -334,6 +300,7 @@ func (e *artefactServer) GetDirContent(ctx context.Context, req *pb.Reference, r
 		Type:      pb.ContentType_Artefact,
 		Path:      dir,
 		BuildRepo: ref.buildrepo,
+		Branch:    ref.Branch(),
 	}
 	backref := &pb.ArtefactRef{Name: res.Name, Version: res.Version}
 	aa := false
@@ -360,6 +327,7 @@ func (e *artefactServer) GetDirContent(ctx context.Context, req *pb.Reference, r
 			Artefact:         backref,
 			Domain:           ref.domain,
 			BuildRepo:        ref.buildrepo,
+			Branch:           ref.Branch(),
 		}
 		if entry.Type == 1 {
 			c.Type = pb.ContentType_File
@@ -444,47 +412,3 @@ func charsOnly(in string) string {
 }
 
 // use to asynchronously get details
-type ContentFiller struct {
-	// fill this in:
-	ctx                   context.Context
-	warningOnAccessDenied bool
-	// in response you'll get:
-	err      error
-	withRead []*pb.Contents
-}
-
-func (cf *ContentFiller) fillContent(af *pb.Contents) {
-	u := auth.GetUser(cf.ctx)
-	adminAccess := auth.IsRoot(cf.ctx)
-	if af.ArtefactID == nil {
-		rid, _ := artefactToID(af.Name, af.Domain)
-		af.ArtefactID = &pb.ArtefactID{ID: rid, Domain: af.Domain, Name: af.Name}
-	}
-	_, xerr := requestAccess(cf.ctx, af.Name, af.Domain)
-	if xerr != nil {
-		if cf.warningOnAccessDenied {
-			afid := af.ArtefactID.ID
-			fmt.Printf("WARNING: Access denied for user %s to artefact %d(%s): %s", auth.Description(u), afid, af.Name, xerr)
-		}
-		return
-	}
-	af.AdminAccess = adminAccess
-	cf.withRead = append(cf.withRead, af)
-
-	glv, e := brepo.GetLatestVersion(cf.ctx, af.Domain, &br.GetLatestVersionRequest{
-		Repository: af.Name,
-		Branch:     "master",
-	})
-	if e != nil {
-		cf.err = e
-	} else {
-		af.Version = glv.BuildID
-		if glv.BuildMeta != nil {
-			af.RepositoryID = glv.BuildMeta.RepositoryID
-		}
-	}
-	createArtefactReference(af)
-}
-
-
-
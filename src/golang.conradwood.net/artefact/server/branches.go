@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/go-easyops/cache"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+const (
+	FALLBACK_BRANCH = "master"
+)
+
+var (
+	default_branch_cache = cache.New("default_branch", time.Duration(30)*time.Minute, 5000)
+	// if a repository has one of these branches, it is the default (in this order)
+	preferred_branches = []string{"master", "main"}
+)
+
+type default_branch_cache_entry struct {
+	branch string
+}
+
+func (e *artefactServer) ListBranches(ctx context.Context, req *pb.ID) (*pb.BranchList, error) {
+	af, err := idstore.ByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = requestAccess(ctx, af.Name, af.Domain)
+	if err != nil {
+		return nil, err
+	}
+	lbr, err := brepo.ListBranches(ctx, af.Domain, &br.ListBranchesRequest{Repository: af.Name})
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.BranchList{
+		Branches:      lbr.Branches,
+		DefaultBranch: defaultBranch(ctx, af.Domain, af.Name),
+	}
+	sort.Strings(res.Branches)
+	return res, nil
+}
+
+// returns branch, or the default branch of the repository if branch is "". branch names are a single path element
+func resolveBranch(ctx context.Context, domain string, repo string, branch string) (string, error) {
+	if branch == "" {
+		return defaultBranch(ctx, domain, repo), nil
+	}
+	if strings.ContainsAny(branch, "/\\") || strings.Contains(branch, "..") {
+		return "", errors.InvalidArgs(ctx, "invalid branch", "invalid branch \"%s\"", branch)
+	}
+	return branch, nil
+}
+
+// the default branch of a repository. that is one of the preferred branches, if the repository has one,
+// otherwise the branch with the most recent build.
+func defaultBranch(ctx context.Context, domain string, repo string) string {
+	key := domain + "/" + repo
+	o := default_branch_cache.Get(key)
+	if o != nil {
+		return (o.(*default_branch_cache_entry)).branch
+	}
+	lbr, err := brepo.ListBranches(ctx, domain, &br.ListBranchesRequest{Repository: repo})
+	if err != nil {
+		// do not cache, might be a temporary failure
+		fmt.Printf("failed to list branches of %s in domain %s (using \"%s\"): %s\n", repo, domain, FALLBACK_BRANCH, utils.ErrorString(err))
+		return FALLBACK_BRANCH
+	}
+	res := pickDefaultBranch(ctx, domain, repo, lbr.Branches)
+	debugf("default branch for %s in domain %s is \"%s\"\n", repo, domain, res)
+	default_branch_cache.Put(key, &default_branch_cache_entry{branch: res})
+	return res
+}
+
+func pickDefaultBranch(ctx context.Context, domain string, repo string, branches []string) string {
+	for _, pref := range preferred_branches {
+		for _, b := range branches {
+			if b == pref {
+				return b
+			}
+		}
+	}
+	res := FALLBACK_BRANCH
+	ts := uint32(0)
+	for _, b := range branches {
+		glv, err := brepo.GetLatestVersion(ctx, domain, &br.GetLatestVersionRequest{Repository: repo, Branch: b})
+		if err != nil || glv.BuildMeta == nil {
+			continue
+		}
+		if glv.BuildMeta.Timestamp > ts {
+			ts = glv.BuildMeta.Timestamp
+			res = b
+		}
+	}
+	return res
+}
@@ -2,12 +2,29 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	pb "golang.conradwood.net/apis/artefact"
 	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/go-easyops/cache"
+	"golang.conradwood.net/go-easyops/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
 	"strconv"
+	"time"
 )
 
+var (
+	buildlist_cache  = cache.New("buildlist", time.Duration(30)*time.Minute, 1000)
+	buildlist_probes = flag.Int("buildlist_probes", 20, "ListBuilds probes at most this many missing build numbers for the newest N builds before it lists all versions")
+)
+
+type buildlist_cache_entry struct {
+	latest uint64
+	builds []uint64 // newest first
+}
+
 func (e *artefactServer) GetArtefactByID(ctx context.Context, req *pb.ID) (*pb.ArtefactID, error) {
 	af, err := idstore.ByID(ctx, req.ID)
 	if err != nil {
@@ -26,15 +43,142 @@ func (e *artefactServer) GetArtefactBuilds(ctx context.Context, req *pb.Artefact
 		return nil, xerr
 	}
 	debugf("Getting builds for %s in domain %s\n", af.Name, af.Domain)
+	branch := defaultBranch(ctx, af.Domain, af.Name)
+	builds, err := artefactBuilds(ctx, af, branch)
+	if err != nil {
+		return nil, err
+	}
+	records := knownBuildRecords(ctx, af.ID)
+	bl := &pb.BuildList{}
+	for i := len(builds) - 1; i >= 0; i-- {
+		bl.Builds = append(bl.Builds, builds[i])
+		bl.Infos = append(bl.Infos, buildInfo(ctx, af.ID, branch, builds[i], records))
+	}
+	debugf("Returning %d builds for %s in domain %s\n", len(bl.Builds), af.Name, af.Domain)
+	return bl, nil
+}
 
+func (e *artefactServer) ListBuilds(ctx context.Context, req *pb.BuildListRequest) (*pb.BuildList, error) {
+	af, err := idstore.ByID(ctx, req.ArtefactID)
+	if err != nil {
+		return nil, err
+	}
+	_, xerr := requestAccess(ctx, af.Name, af.Domain)
+	if xerr != nil {
+		return nil, xerr
+	}
+	before := uint64(0)
+	if req.PageToken != "" {
+		tok, err := parsePageToken(ctx, req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		before, err = strconv.ParseUint(tok, 10, 64)
+		if err != nil {
+			return nil, errors.InvalidArgs(ctx, "invalid page token", "invalid page token \"%s\"", req.PageToken)
+		}
+	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return nil, err
+	}
+	records := knownBuildRecords(ctx, af.ID)
+	bl := &pb.BuildList{}
+	if before == 0 && req.PageSize != 0 {
+		// just the newest ones, no need to ask the buildrepo for all versions
+		builds, err := newestBuilds(ctx, af, branch, int(req.PageSize))
+		if err != nil {
+			return nil, err
+		}
+		if builds != nil {
+			for _, b := range builds {
+				bl.Builds = append(bl.Builds, b)
+				bl.Infos = append(bl.Infos, buildInfo(ctx, af.ID, branch, b, records))
+			}
+			if len(builds) == int(req.PageSize) {
+				bl.NextPageToken = newPageToken(fmt.Sprintf("%d", builds[len(builds)-1]))
+			}
+			return bl, nil
+		}
+	}
+	builds, err := artefactBuilds(ctx, af, branch)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range builds {
+		if before != 0 && b >= before {
+			continue
+		}
+		if req.PageSize != 0 && uint32(len(bl.Builds)) == req.PageSize {
+			bl.NextPageToken = newPageToken(fmt.Sprintf("%d", bl.Builds[len(bl.Builds)-1]))
+			break
+		}
+		bl.Builds = append(bl.Builds, b)
+		bl.Infos = append(bl.Infos, buildInfo(ctx, af.ID, branch, b, records))
+	}
+	return bl, nil
+}
+
+// the newest n builds of an artefact on a branch (newest first), found by walking back from the latest build.
+// returns nil (but no error) if more than -buildlist_probes build numbers are missing, and the cached
+// version list if it is up to date
+func newestBuilds(ctx context.Context, af *pb.ArtefactID, branch string, n int) ([]uint64, error) {
+	glv, err := brepo.GetLatestVersion(ctx, af.Domain, &br.GetLatestVersionRequest{Repository: af.Name, Branch: branch})
+	if err != nil {
+		return nil, err
+	}
+	recordBuild(ctx, af.ID, branch, glv.BuildID, glv.BuildMeta)
+	o := buildlist_cache.Get(fmt.Sprintf("%d/%s", af.ID, branch))
+	if o != nil && o.(*buildlist_cache_entry).latest == glv.BuildID {
+		builds := o.(*buildlist_cache_entry).builds
+		if len(builds) > n {
+			builds = builds[:n]
+		}
+		return builds, nil
+	}
+	res := []uint64{glv.BuildID}
+	missing := 0
+	for b := glv.BuildID - 1; b > 0 && len(res) < n; b-- {
+		lfr, _, err := brepo.ListFiles(ctx, af.Domain, &br.ListFilesRequest{Repository: af.Name, Branch: branch, BuildID: b})
+		if err != nil && status.Code(err) == codes.Unavailable {
+			return nil, err
+		}
+		if err == nil && len(lfr.Entries) > 0 {
+			res = append(res, b)
+			continue
+		}
+		missing++
+		if missing > *buildlist_probes {
+			return nil, nil
+		}
+	}
+	return res, nil
+}
+
+// builds of an artefact on a branch, newest first. the versions are only
+// fetched from the buildrepo if the latest build changed since the last call
+func artefactBuilds(ctx context.Context, af *pb.ArtefactID, branch string) ([]uint64, error) {
+	glv, err := brepo.GetLatestVersion(ctx, af.Domain, &br.GetLatestVersionRequest{Repository: af.Name, Branch: branch})
+	if err != nil {
+		return nil, err
+	}
+	recordBuild(ctx, af.ID, branch, glv.BuildID, glv.BuildMeta)
+	key := fmt.Sprintf("%d/%s", af.ID, branch)
+	o := buildlist_cache.Get(key)
+	if o != nil {
+		ble := o.(*buildlist_cache_entry)
+		if ble.latest == glv.BuildID {
+			return ble.builds, nil
+		}
+	}
 	lvr, err := brepo.ListVersions(ctx, af.Domain, &br.ListVersionsRequest{
 		Repository: af.Name,
-		Branch:     "master",
+		Branch:     branch,
 	})
 	if err != nil {
 		return nil, err
 	}
-	bl := &pb.BuildList{}
+	var builds []uint64
 	for _, v := range lvr.Entries {
 		if v.Name == "latest" {
 			continue
@@ -43,10 +187,13 @@ func (e *artefactServer) GetArtefactBuilds(ctx context.Context, req *pb.Artefact
 		if err != nil {
 			return nil, err
 		}
-		bl.Builds = append(bl.Builds, b)
+		builds = append(builds, b)
 	}
-	debugf("Returning %d builds for %s in domain %s\n", len(bl.Builds), af.Name, af.Domain)
-	return bl, nil
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i] > builds[j]
+	})
+	buildlist_cache.Put(key, &buildlist_cache_entry{latest: glv.BuildID, builds: builds})
+	return builds, nil
 }
 func (e *artefactServer) GetDirListing(ctx context.Context, req *pb.DirListRequest) (*pb.DirListing, error) {
 	af, err := idstore.ByID(ctx, req.ArtefactID)
@@ -59,9 +206,13 @@ func (e *artefactServer) GetDirListing(ctx context.Context, req *pb.DirListReque
 	if xerr != nil {
 		return nil, xerr
 	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return nil, err
+	}
 	lfr, _, err := brepo.ListFiles(ctx, af.Domain, &br.ListFilesRequest{
 		Repository: af.Name,
-		Branch:     "master",
+		Branch:     branch,
 		BuildID:    req.Build,
 		Recursive:  false,
 		Dir:        req.Dir,
@@ -78,6 +229,10 @@ func (e *artefactServer) GetDirListing(ctx context.Context, req *pb.DirListReque
 	}
 	debugf("Dir \"%s\" in artefact #%d (%s) got %d entries\n", res.Path, res.ArtefactInfo.ID, res.ArtefactInfo.Name, len(lfr.Entries))
 
+	known, err := knownChecksums(ctx, af, branch, req.Build)
+	if err != nil {
+		fmt.Printf("failed to get checksums for %s: %s\n", af.Name, err)
+	}
 	dir := req.Dir
 	for _, e := range lfr.Entries {
 		if e.Dir != dir {
@@ -87,7 +242,11 @@ func (e *artefactServer) GetDirListing(ctx context.Context, req *pb.DirListReque
 		if e.Type == 2 {
 			res.Dirs = append(res.Dirs, &pb.DirInfo{RelativeDir: e.Dir, Name: e.Name})
 		} else if e.Type == 1 {
-			res.Files = append(res.Files, &pb.FileInfo{RelativeDir: e.Dir, Name: e.Name})
+			fi := &pb.FileInfo{RelativeDir: e.Dir, Name: e.Name, SHA256: known[checksumPath(e.Dir+"/"+e.Name)]}
+			if fi.SHA256 == "" {
+				queueChecksum(newChecksumRequest(af, branch, req.Build, e.Dir+"/"+e.Name))
+			}
+			res.Files = append(res.Files, fi)
 		} else {
 			fmt.Printf("Weird Entry: %#v\n", e)
 		}
@@ -105,20 +264,57 @@ func (e *artefactServer) GetFileStream(req *pb.FileRequest, srv pb.ArtefactServi
 	if xerr != nil {
 		return xerr
 	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return err
+	}
 	blvr := &br.GetFileRequest{
 		File: &br.File{
 			Repository: af.Name,
-			Branch:     "master",
+			Branch:     branch,
 			BuildID:    req.Build,
 			Filename:   req.Filename,
 		},
 		Blocksize: 4096,
 	}
-	err = brepo.GetFile(ctx, af.Domain, blvr, &serverwriter{srv: srv})
+	cr := newChecksumRequest(af, blvr.File.Branch, req.Build, req.Filename)
+	known, err := knownChecksum(ctx, cr)
 	if err != nil {
 		return err
 	}
-	return nil
+	warning, err := checkDownload(ctx, af.ID, blvr.File.Branch, req.Build)
+	if err != nil {
+		return err
+	}
+	if req.VerifyChecksum && known == "" {
+		// nothing recorded yet, compute it first (like createChecksumFile) so that the stream can be verified
+		known, err = getChecksum(ctx, cr)
+		if err != nil {
+			return err
+		}
+	}
+	recordDownload(ctx, af.ID, blvr.File.Branch, req.Build)
+	if warning != "" {
+		err = srv.Send(&pb.FileStreamResponse{Warning: warning})
+		if err != nil {
+			return err
+		}
+	}
+	hw := newHashWriter(&serverwriter{srv: srv})
+	err = brepo.GetFile(ctx, af.Domain, blvr, hw)
+	if err != nil {
+		return err
+	}
+	sum := hw.Sum()
+	if known == "" {
+		err = saveChecksum(ctx, cr, sum, hw.size)
+		if err != nil {
+			fmt.Printf("failed to save sha256 of %s: %s\n", cr.key(), err)
+		}
+	} else if req.VerifyChecksum && known != sum {
+		return errors.FailedPrecondition(ctx, "checksum mismatch for %s (expected %s, got %s)", req.Filename, known, sum)
+	}
+	return srv.Send(&pb.FileStreamResponse{SHA256: sum})
 }
 func (e *artefactServer) DoesFileExist(ctx context.Context, req *pb.FileRequest) (*pb.FileExistsInfo, error) {
 	af, err := idstore.ByID(ctx, req.ArtefactID)
@@ -129,10 +325,14 @@ func (e *artefactServer) DoesFileExist(ctx context.Context, req *pb.FileRequest)
 	if xerr != nil {
 		return nil, xerr
 	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return nil, err
+	}
 	blvr := &br.GetFileRequest{
 		File: &br.File{
 			Repository: af.Name,
-			Branch:     "master",
+			Branch:     branch,
 			BuildID:    req.Build,
 			Filename:   req.Filename,
 		},
@@ -146,6 +346,17 @@ func (e *artefactServer) DoesFileExist(ctx context.Context, req *pb.FileRequest)
 		Exists: fei.Exists,
 		Size:   fei.Size,
 	}
+	if fei.Exists {
+		// computing the checksum means downloading the file, so it is done in the background
+		cr := newChecksumRequest(af, blvr.File.Branch, req.Build, req.Filename)
+		res.SHA256, err = knownChecksum(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+		if res.SHA256 == "" {
+			queueChecksum(cr)
+		}
+	}
 	fmt.Printf("file %s@%d exists? (%v)\n", req.Filename, req.Build, res.Exists)
 	return res, nil
 }
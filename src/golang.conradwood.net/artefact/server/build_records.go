@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/artefact/db"
+	"golang.conradwood.net/go-easyops/cache"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ the buildrepo only tells us the metadata (timestamp, commit, builder) of the latest build, and nothing about downloads.
+ build records keep both: each latest build which is seen is recorded, downloads update LastDownload
+ (at most once per hour per build).
+*/
+
+var (
+	buildrecord_cache = cache.New("buildrecords", time.Duration(60)*time.Minute, 5000)
+	buildrecord_lock  sync.Mutex // serialises updates, so that there is only one record per build
+)
+
+type buildrecord_cache_entry struct {
+}
+
+// remember when (and from which commit) a build was created. does nothing if it is recorded already.
+// bm is the metadata the buildrepo reported for the latest build, it may be nil
+func recordBuild(ctx context.Context, artefactid uint64, branch string, build uint64, bm *br.BuildMeta) {
+	if build == 0 {
+		return
+	}
+	key := "created/" + buildMarkKey(artefactid, branch, build)
+	if buildrecord_cache.Get(key) != nil {
+		return
+	}
+	if bm != nil && bm.BuildID != build {
+		bm = nil
+	}
+	err := updateBuildRecord(ctx, artefactid, branch, build, func(rec *pb.BuildRecord) bool {
+		changed := false
+		if rec.Created == 0 {
+			if bm != nil {
+				rec.Created = bm.Timestamp
+			}
+			if rec.Created == 0 {
+				rec.Created = uint32(time.Now().Unix())
+			}
+			changed = true
+		}
+		if bm != nil && rec.CommitID == "" && bm.CommitID != "" {
+			rec.CommitID = bm.CommitID
+			rec.CommitMessage = bm.CommitMessage
+			rec.BuildUserID = bm.BuildUserID
+			rec.BuildHost = bm.BuildHost
+			changed = true
+		}
+		return changed
+	})
+	if err != nil {
+		fmt.Printf("failed to record build %d of artefact #%d: %s\n", build, artefactid, utils.ErrorString(err))
+		return
+	}
+	buildrecord_cache.Put(key, &buildrecord_cache_entry{})
+}
+
+// remember that a build was downloaded
+func recordDownload(ctx context.Context, artefactid uint64, branch string, build uint64) {
+	if build == 0 {
+		return
+	}
+	key := "download/" + buildMarkKey(artefactid, branch, build)
+	if buildrecord_cache.Get(key) != nil {
+		return
+	}
+	now := uint32(time.Now().Unix())
+	err := updateBuildRecord(ctx, artefactid, branch, build, func(rec *pb.BuildRecord) bool {
+		rec.LastDownload = now
+		return true
+	})
+	if err != nil {
+		fmt.Printf("failed to record download of build %d of artefact #%d: %s\n", build, artefactid, utils.ErrorString(err))
+		return
+	}
+	buildrecord_cache.Put(key, &buildrecord_cache_entry{})
+}
+
+// creates the record if there is none. f returns true if it changed the record
+func updateBuildRecord(ctx context.Context, artefactid uint64, branch string, build uint64, f func(rec *pb.BuildRecord) bool) error {
+	buildrecord_lock.Lock()
+	defer buildrecord_lock.Unlock()
+	brs, err := buildRecords(ctx, artefactid)
+	if err != nil {
+		return err
+	}
+	rec := brs[buildMarkKey(artefactid, branch, build)]
+	if rec == nil {
+		rec = &pb.BuildRecord{ArtefactID: artefactid, Branch: branch, BuildID: build}
+	}
+	if !f(rec) {
+		return nil
+	}
+	if rec.ID == 0 {
+		_, err = db.DefaultDBBuildRecord().Save(ctx, rec)
+	} else {
+		err = db.DefaultDBBuildRecord().Update(ctx, rec)
+	}
+	return err
+}
+
+// like buildRecords, errors are logged and result in no records
+func knownBuildRecords(ctx context.Context, artefactid uint64) map[string]*pb.BuildRecord {
+	res, err := buildRecords(ctx, artefactid)
+	if err != nil {
+		fmt.Printf("failed to get build records of artefact #%d: %s\n", artefactid, utils.ErrorString(err))
+		return nil
+	}
+	return res
+}
+
+// buildMarkKey() -> record
+func buildRecords(ctx context.Context, artefactid uint64) (map[string]*pb.BuildRecord, error) {
+	brs, err := db.DefaultDBBuildRecord().ByArtefactID(ctx, artefactid)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*pb.BuildRecord)
+	for _, rec := range brs {
+		res[buildMarkKey(rec.ArtefactID, rec.Branch, rec.BuildID)] = rec
+	}
+	return res, nil
+}
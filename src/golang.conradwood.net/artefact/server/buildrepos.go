@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/apis/common"
+	"golang.conradwood.net/artefact/buildrepo"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/errors"
+)
+
+// admin rpcs to change the set of buildrepos at runtime
+
+func (e *artefactServer) AddBuildRepo(ctx context.Context, req *pb.BuildRepoAddress) (*pb.BuildRepoServer, error) {
+	if !auth.IsRoot(ctx) {
+		return nil, errors.AccessDenied(ctx, "adding buildrepos requires root")
+	}
+	st, err := buildrepo.AddBuildRepo(req.Address)
+	if err != nil {
+		return nil, errors.InvalidArgs(ctx, "invalid buildrepo", "cannot add buildrepo \"%s\": %s", req.Address, err)
+	}
+	fmt.Printf("User %s added buildrepo %s\n", auth.Description(auth.GetUser(ctx)), st.Address)
+	return buildRepoServer(st), nil
+}
+
+func (e *artefactServer) RemoveBuildRepo(ctx context.Context, req *pb.BuildRepoAddress) (*common.Void, error) {
+	if !auth.IsRoot(ctx) {
+		return nil, errors.AccessDenied(ctx, "removing buildrepos requires root")
+	}
+	err := buildrepo.RemoveBuildRepo(req.Address)
+	if err != nil {
+		return nil, errors.NotFound(ctx, "%s", err)
+	}
+	fmt.Printf("User %s removed buildrepo %s\n", auth.Description(auth.GetUser(ctx)), req.Address)
+	return &common.Void{}, nil
+}
+
+func (e *artefactServer) ListBuildRepos(ctx context.Context, req *common.Void) (*pb.BuildRepoServerList, error) {
+	if !auth.IsRoot(ctx) {
+		return nil, errors.AccessDenied(ctx, "listing buildrepos requires root")
+	}
+	res := &pb.BuildRepoServerList{}
+	for _, st := range buildrepo.ListBuildRepos() {
+		res.Servers = append(res.Servers, buildRepoServer(st))
+	}
+	return res, nil
+}
+
+func buildRepoServer(st *buildrepo.BuildRepoStatus) *pb.BuildRepoServer {
+	return &pb.BuildRepoServer{
+		Address:   st.Address,
+		Domain:    st.Domain,
+		Connected: st.Connected,
+		Healthy:   st.Healthy,
+		Failures:  st.Failures,
+		LastError: st.LastError,
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/apis/common"
+	"golang.conradwood.net/artefact/db"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/cache"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ builds may be deprecated or yanked (e.g. because they are broken or have a security issue).
+ yanked builds are skipped when "latest" or a channel is resolved. downloads of yanked builds are
+ refused or warned about (-yanked_downloads), downloads of deprecated builds are always warned about.
+*/
+
+const (
+	BUILDMARK_CACHE_KEY = "all"
+	YANKED_REFUSE       = "refuse"
+	YANKED_WARN         = "warn"
+)
+
+var (
+	yanked_downloads = flag.String("yanked_downloads", YANKED_REFUSE, "downloads of yanked builds: \""+YANKED_REFUSE+"\" or \""+YANKED_WARN+"\"")
+	buildmark_cache  = cache.New("buildmarks", time.Duration(1)*time.Minute, 10)
+)
+
+type buildmark_cache_entry struct {
+	marks map[string]*pb.BuildMark // buildMarkKey() -> mark
+}
+
+func buildMarkKey(artefactid uint64, branch string, build uint64) string {
+	return fmt.Sprintf("%d/%s/%d", artefactid, branch, build)
+}
+
+func (e *artefactServer) MarkBuild(ctx context.Context, req *pb.MarkBuildRequest) (*common.Void, error) {
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return nil, errors.Unauthenticated(ctx, "need user account to mark builds")
+	}
+	if req.State != pb.BuildState_Available && req.Reason == "" {
+		return nil, errors.InvalidArgs(ctx, "missing reason", "a reason is required to deprecate or yank a build")
+	}
+	af, err := idstore.ByID(ctx, req.ArtefactID)
+	if err != nil {
+		return nil, err
+	}
+	err = requestAdminAccess(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return nil, err
+	}
+	bms, err := db.DefaultDBBuildMark().ByArtefactID(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	var bm *pb.BuildMark
+	for _, m := range bms {
+		if m.Branch == branch && m.BuildID == req.BuildID {
+			bm = m
+			break
+		}
+	}
+	if req.State == pb.BuildState_Available {
+		if bm != nil {
+			err = db.DefaultDBBuildMark().DeleteByID(ctx, bm.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if bm == nil {
+			builds, err := artefactBuilds(ctx, af, branch)
+			if err != nil {
+				return nil, err
+			}
+			if !containsBuild(builds, req.BuildID) {
+				return nil, errors.NotFound(ctx, "no build %d of artefact #%d on branch \"%s\"", req.BuildID, af.ID, branch)
+			}
+			bm = &pb.BuildMark{ArtefactID: af.ID, Branch: branch, BuildID: req.BuildID}
+		}
+		bm.Yanked = req.State == pb.BuildState_Yanked
+		bm.Reason = req.Reason
+		bm.UserID = u.ID
+		bm.Timestamp = uint32(time.Now().Unix())
+		if bm.ID == 0 {
+			_, err = db.DefaultDBBuildMark().Save(ctx, bm)
+		} else {
+			err = db.DefaultDBBuildMark().Update(ctx, bm)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	buildmark_cache.Evict(BUILDMARK_CACHE_KEY)
+	fmt.Printf("User %s marked build %d (branch %s) of artefact #%d (%s) as %s: %s\n", auth.Description(u), req.BuildID, branch, af.ID, af.Name, req.State, req.Reason)
+	return &common.Void{}, nil
+}
+
+func allBuildMarks(ctx context.Context) (map[string]*pb.BuildMark, error) {
+	o := buildmark_cache.Get(BUILDMARK_CACHE_KEY)
+	if o != nil {
+		return (o.(*buildmark_cache_entry)).marks, nil
+	}
+	bms, err := db.DefaultDBBuildMark().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*pb.BuildMark)
+	for _, bm := range bms {
+		res[buildMarkKey(bm.ArtefactID, bm.Branch, bm.BuildID)] = bm
+	}
+	buildmark_cache.Put(BUILDMARK_CACHE_KEY, &buildmark_cache_entry{marks: res})
+	return res, nil
+}
+
+// nil if the build is available
+func buildMark(ctx context.Context, artefactid uint64, branch string, build uint64) (*pb.BuildMark, error) {
+	bms, err := allBuildMarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return bms[buildMarkKey(artefactid, branch, build)], nil
+}
+
+func isYanked(ctx context.Context, artefactid uint64, branch string, build uint64) (bool, error) {
+	bm, err := buildMark(ctx, artefactid, branch, build)
+	if err != nil {
+		return false, err
+	}
+	return bm != nil && bm.Yanked, nil
+}
+
+// state and (from records, which may be nil) commit metadata of a build
+func buildInfo(ctx context.Context, artefactid uint64, branch string, build uint64, records map[string]*pb.BuildRecord) *pb.BuildInfo {
+	res := &pb.BuildInfo{BuildID: build, Branch: branch}
+	rec := records[buildMarkKey(artefactid, branch, build)]
+	if rec != nil {
+		res.CommitID = rec.CommitID
+		res.CommitMessage = rec.CommitMessage
+		res.Timestamp = rec.Created
+		res.BuildHost = rec.BuildHost
+		res.UserID = rec.BuildUserID
+	}
+	bm, err := buildMark(ctx, artefactid, branch, build)
+	if err != nil {
+		// informational only, downloads are checked separately
+		fmt.Printf("failed to get build marks: %s\n", utils.ErrorString(err))
+		return res
+	}
+	if bm == nil {
+		return res
+	}
+	res.State = pb.BuildState_Deprecated
+	if bm.Yanked {
+		res.State = pb.BuildState_Yanked
+	}
+	res.StateReason = bm.Reason
+	return res
+}
+
+// the newest build, up to and including latest, which is not yanked
+func latestUsableBuild(ctx context.Context, af *pb.ArtefactID, branch string, latest uint64) (uint64, error) {
+	yanked, err := isYanked(ctx, af.ID, branch, latest)
+	if err != nil {
+		return 0, err
+	}
+	if !yanked {
+		return latest, nil
+	}
+	builds, err := artefactBuilds(ctx, af, branch)
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range builds {
+		if b > latest {
+			continue
+		}
+		yanked, err = isYanked(ctx, af.ID, branch, b)
+		if err != nil {
+			return 0, err
+		}
+		if !yanked {
+			return b, nil
+		}
+	}
+	return 0, errors.NotFound(ctx, "all builds of artefact #%d on branch \"%s\" are yanked", af.ID, branch)
+}
+
+// checks if a build may be downloaded. returns a warning for deprecated builds (and yanked builds if they are not refused).
+// if the state of the build cannot be determined, the download is refused
+func checkDownload(ctx context.Context, artefactid uint64, branch string, build uint64) (string, error) {
+	bm, err := buildMark(ctx, artefactid, branch, build)
+	if err != nil {
+		fmt.Printf("failed to get build marks, refusing download of build %d of artefact #%d: %s\n", build, artefactid, utils.ErrorString(err))
+		return "", err
+	}
+	if bm == nil {
+		return "", nil
+	}
+	if !bm.Yanked {
+		return fmt.Sprintf("build %d is deprecated: %s", build, bm.Reason), nil
+	}
+	if *yanked_downloads == YANKED_WARN {
+		return fmt.Sprintf("build %d is yanked: %s", build, bm.Reason), nil
+	}
+	return "", errors.FailedPrecondition(ctx, "build %d of artefact #%d is yanked: %s", build, artefactid, bm.Reason)
+}
+
+// like checkDownload (and recordDownload), for (old style) references
+func checkReferenceDownload(ctx context.Context, ref *reference) (string, error) {
+	afid, err := artefactToID(ref.Repository(), ref.domain)
+	if err != nil {
+		return "", err
+	}
+	warning, err := checkDownload(ctx, afid, ref.Branch(), ref.Version())
+	if err != nil {
+		return "", err
+	}
+	recordDownload(ctx, afid, ref.Branch(), ref.Version())
+	return warning, nil
+}
+
+func containsBuild(builds []uint64, build uint64) bool {
+	for _, b := range builds {
+		if b == build {
+			return true
+		}
+	}
+	return false
+}
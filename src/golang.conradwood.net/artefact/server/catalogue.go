@@ -0,0 +1,331 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/authremote"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ an in-memory catalogue of all artefacts in all buildrepos, with their latest build.
+ it is refreshed in the background. List and Find answer from the catalogue, only access is checked per request.
+*/
+
+var (
+	catalogue_interval     = flag.Duration("catalogue_interval", time.Duration(2)*time.Minute, "how often to refresh the catalogue of artefacts from the buildrepos")
+	catalogue_workers      = flag.Int("catalogue_workers", 10, "number of concurrent buildrepo requests while refreshing the catalogue")
+	catalogue              *catalogue_state
+	catalogue_lock         sync.Mutex // protects catalogue
+	catalogue_refresh_lock sync.Mutex // only one refresh at a time
+)
+
+type catalogue_entry struct {
+	artefactid     uint64
+	name           string
+	domain         string
+	organisationid string
+	url            string
+	server         string
+	branch         string
+	buildid        uint64 // latest build on branch, 0 if not known
+	buildtime      uint32 // timestamp of latest build, 0 if not known
+	repositoryid   uint64
+}
+
+// a query against the catalogue
+type artefact_query struct {
+	score      func(ce *catalogue_entry) float64 // if not nil, only entries with a score > 0 match, ordered by score
+	filter     *pb.ArtefactFilter                // nil matches all
+	order      pb.ArtefactOrder                  // if there is no score
+	pagesize   uint32                            // 0 means all
+	pagetoken  string
+	maxresults uint32 // 0 means all
+}
+
+type scored_entry struct {
+	*catalogue_entry
+	score float64
+	key   string
+}
+
+// a catalogue is never modified once it is built
+type catalogue_state struct {
+	entries  []*catalogue_entry // sorted by name (and domain)
+	byname   map[string]*catalogue_entry
+	byrepo   map[uint64]*catalogue_entry
+	degraded []*pb.DegradedSource
+	updated  time.Time
+}
+
+func catalogueKey(domain, name string) string {
+	return domain + "/" + name
+}
+
+// the catalogue, nil if none was built yet
+func currentCatalogue() *catalogue_state {
+	catalogue_lock.Lock()
+	defer catalogue_lock.Unlock()
+	return catalogue
+}
+
+// the catalogue. builds it if there is none yet
+func getCatalogue() (*catalogue_state, error) {
+	c := currentCatalogue()
+	if c != nil {
+		return c, nil
+	}
+	catalogue_refresh_lock.Lock()
+	defer catalogue_refresh_lock.Unlock()
+	c = currentCatalogue()
+	if c != nil {
+		return c, nil
+	}
+	return buildCatalogue()
+}
+
+func catalogue_refresher() {
+	for {
+		catalogue_refresh_lock.Lock()
+		_, err := buildCatalogue()
+		catalogue_refresh_lock.Unlock()
+		if err != nil {
+			fmt.Printf("failed to refresh catalogue: %s\n", utils.ErrorString(err))
+		}
+		time.Sleep(*catalogue_interval)
+	}
+}
+
+// must be called with catalogue_refresh_lock held
+func buildCatalogue() (*catalogue_state, error) {
+	started := time.Now()
+	repos, err := brepo.ListRepos(authremote.Context())
+	if err != nil {
+		return nil, err
+	}
+	old := currentCatalogue()
+	details, err := artefactDetails()
+	if err != nil {
+		return nil, err
+	}
+	res := &catalogue_state{
+		byname:   make(map[string]*catalogue_entry),
+		byrepo:   make(map[uint64]*catalogue_entry),
+		degraded: degradedSources(repos),
+	}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan bool, *catalogue_workers)
+	for _, r := range repos.Entries {
+		wg.Add(1)
+		sem <- true
+		go func(name, domain, server string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			ce, err := newCatalogueEntry(name, domain, server, old)
+			if err != nil {
+				fmt.Printf("not cataloguing %s in domain %s: %s\n", name, domain, utils.ErrorString(err))
+				return
+			}
+			if d := details[ce.artefactid]; d != nil {
+				ce.organisationid = d.OrganisationID
+				ce.url = d.URL
+			}
+			lock.Lock()
+			res.entries = append(res.entries, ce)
+			lock.Unlock()
+		}(r.Name, r.Domain, r.Server)
+	}
+	wg.Wait()
+	sort.Slice(res.entries, func(i, j int) bool {
+		return res.entries[i].sortKey(pb.ArtefactOrder_ByName) < res.entries[j].sortKey(pb.ArtefactOrder_ByName)
+	})
+	for _, ce := range res.entries {
+		res.byname[catalogueKey(ce.domain, ce.name)] = ce
+		if ce.repositoryid != 0 {
+			res.byrepo[ce.repositoryid] = ce
+		}
+	}
+	res.updated = time.Now()
+	catalogue_lock.Lock()
+	catalogue = res
+	catalogue_lock.Unlock()
+	fmt.Printf("Catalogue refreshed: %d artefacts in %0.1fs\n", len(res.entries), time.Since(started).Seconds())
+	return res, nil
+}
+
+// if the latest version cannot be retrieved, the one from the previous catalogue (if any) is kept
+func newCatalogueEntry(name, domain, server string, old *catalogue_state) (*catalogue_entry, error) {
+	ctx := authremote.Context()
+	afid, err := artefactToID(name, domain)
+	if err != nil {
+		return nil, err
+	}
+	ce := &catalogue_entry{
+		artefactid: afid,
+		name:       name,
+		domain:     domain,
+		server:     server,
+		branch:     defaultBranch(ctx, domain, name),
+	}
+	glv, err := brepo.GetLatestVersion(ctx, domain, &br.GetLatestVersionRequest{Repository: name, Branch: ce.branch})
+	if err == nil {
+		ce.buildid = glv.BuildID
+		if glv.BuildMeta != nil {
+			ce.repositoryid = glv.BuildMeta.RepositoryID
+			ce.buildtime = glv.BuildMeta.Timestamp
+		}
+		recordBuild(ctx, afid, ce.branch, ce.buildid, glv.BuildMeta)
+		// downloads check the state of the build themselves, so this is only logged on failure
+		b, err := latestUsableBuild(ctx, &pb.ArtefactID{ID: afid, Domain: domain, Name: name}, ce.branch, ce.buildid)
+		if err != nil {
+			debugf("no usable build for %s in domain %s: %s\n", name, domain, utils.ErrorString(err))
+		} else {
+			ce.buildid = b
+		}
+		return ce, nil
+	}
+	debugf("no latest version for %s in domain %s: %s\n", name, domain, utils.ErrorString(err))
+	if old != nil {
+		oce := old.byname[catalogueKey(domain, name)]
+		if oce != nil && oce.branch == ce.branch {
+			ce.buildid = oce.buildid
+			ce.buildtime = oce.buildtime
+			ce.repositoryid = oce.repositoryid
+		}
+	}
+	return ce, nil
+}
+
+// artefactid -> artefact (from database)
+func artefactDetails() (map[uint64]*pb.ArtefactID, error) {
+	afs, err := idstore.All(authremote.Context())
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[uint64]*pb.ArtefactID)
+	for _, af := range afs {
+		res[af.ID] = af
+	}
+	return res, nil
+}
+
+func (ce *catalogue_entry) matches(f *pb.ArtefactFilter) bool {
+	if f == nil {
+		return true
+	}
+	if f.Domain != "" && f.Domain != ce.domain {
+		return false
+	}
+	if f.OrganisationID != "" && f.OrganisationID != ce.organisationid {
+		return false
+	}
+	if f.NamePrefix != "" && !strings.HasPrefix(ce.name, f.NamePrefix) {
+		return false
+	}
+	if f.BuiltSince != 0 && ce.buildtime <= f.BuiltSince {
+		return false
+	}
+	return true
+}
+
+// entries sort by this key, also used as page token
+func (ce *catalogue_entry) sortKey(order pb.ArtefactOrder) string {
+	if order == pb.ArtefactOrder_ByLatestBuild {
+		// newest first
+		return fmt.Sprintf("%010d\x00%s\x00%s", math.MaxUint32-ce.buildtime, ce.name, ce.domain)
+	}
+	return ce.name + "\x00" + ce.domain
+}
+
+// best score first
+func (ce *catalogue_entry) scoreKey(score float64) string {
+	return fmt.Sprintf("%08d\x00%s", int64((1000-score)*1000), ce.sortKey(pb.ArtefactOrder_ByName))
+}
+
+// the artefacts in the catalogue which match the query and this user may read. links are not set
+func (c *catalogue_state) artefactList(ctx context.Context, q *artefact_query) (*pb.ArtefactList, error) {
+	after, err := parsePageToken(ctx, q.pagetoken)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := readableArtefacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*scored_entry
+	for _, ce := range c.entries {
+		if !ce.matches(q.filter) {
+			continue
+		}
+		se := &scored_entry{catalogue_entry: ce}
+		if q.score != nil {
+			se.score = q.score(ce)
+			if se.score <= 0 {
+				continue
+			}
+			se.key = ce.scoreKey(se.score)
+		} else {
+			se.key = ce.sortKey(q.order)
+		}
+		if after != "" && se.key <= after {
+			continue
+		}
+		if !rs.allowed(ce.artefactid) {
+			continue
+		}
+		entries = append(entries, se)
+	}
+	if q.score != nil || q.order != pb.ArtefactOrder_ByName {
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].key < entries[j].key
+		})
+	}
+	res := &pb.ArtefactList{
+		Degraded:         c.degraded,
+		CatalogueUpdated: uint32(c.updated.Unix()),
+	}
+	if q.maxresults != 0 && uint32(len(entries)) > q.maxresults {
+		entries = entries[:q.maxresults]
+	}
+	if q.pagesize != 0 && uint32(len(entries)) > q.pagesize {
+		entries = entries[:q.pagesize]
+		res.NextPageToken = newPageToken(entries[len(entries)-1].key)
+	}
+	adminAccess := auth.IsRoot(ctx)
+	chs, err := allChannels(ctx)
+	if err != nil {
+		// not worth failing the list for
+		fmt.Printf("failed to get channels: %s\n", utils.ErrorString(err))
+	}
+	for _, ce := range entries {
+		af := &pb.Contents{
+			Name:           ce.name,
+			AdminAccess:    adminAccess,
+			Type:           pb.ContentType_Artefact,
+			Domain:         ce.domain,
+			ArtefactID:     &pb.ArtefactID{ID: ce.artefactid, Domain: ce.domain, Name: ce.name, URL: ce.url, OrganisationID: ce.organisationid},
+			BuildRepo:      ce.server,
+			Branch:         ce.branch,
+			Version:        ce.buildid,
+			RepositoryID:   ce.repositoryid,
+			BuildTimestamp: ce.buildtime,
+			Score:          ce.score,
+			Channels:       channelsOfBuild(chs[ce.artefactid], ce.branch, ce.buildid),
+		}
+		res.Artefacts = append(res.Artefacts, af)
+	}
+	return res, nil
+}
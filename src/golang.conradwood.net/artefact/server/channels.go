@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/artefact/db"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/cache"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ release channels pin a build of an artefact to a name, e.g. "stable". links may use a channel instead of
+ a version (artefactid/5/version/stable/dist). each change is recorded in the channelchange table.
+*/
+
+const (
+	CHANNEL_CACHE_KEY = "all"
+)
+
+var (
+	channel_cache      = cache.New("channels", time.Duration(1)*time.Minute, 10)
+	channel_name_regex = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	reserved_channels  = map[string]bool{"latest": true, "branch": true, "version": true}
+	promote_lock       sync.Mutex // one promotion at a time, so that the audit trail is consistent
+)
+
+type channel_cache_entry struct {
+	byartefact map[uint64][]*pb.Channel
+}
+
+func (e *artefactServer) PromoteBuild(ctx context.Context, req *pb.PromoteRequest) (*pb.Channel, error) {
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return nil, errors.Unauthenticated(ctx, "need user account to promote builds")
+	}
+	if !channel_name_regex.MatchString(req.Channel) || reserved_channels[req.Channel] {
+		return nil, errors.InvalidArgs(ctx, "invalid channel name", "invalid channel name \"%s\"", req.Channel)
+	}
+	af, err := idstore.ByID(ctx, req.ArtefactID)
+	if err != nil {
+		return nil, err
+	}
+	err = requestAdminAccess(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return nil, err
+	}
+	builds, err := artefactBuilds(ctx, af, branch)
+	if err != nil {
+		return nil, err
+	}
+	if !containsBuild(builds, req.BuildID) {
+		return nil, errors.NotFound(ctx, "no build %d of artefact #%d on branch \"%s\"", req.BuildID, af.ID, branch)
+	}
+	yanked, err := isYanked(ctx, af.ID, branch, req.BuildID)
+	if err != nil {
+		return nil, err
+	}
+	if yanked {
+		return nil, errors.FailedPrecondition(ctx, "build %d of artefact #%d is yanked", req.BuildID, af.ID)
+	}
+
+	promote_lock.Lock()
+	defer promote_lock.Unlock()
+	ch, err := loadChannel(ctx, af.ID, req.Channel)
+	if err != nil {
+		return nil, err
+	}
+	now := uint32(time.Now().Unix())
+	cc := &pb.ChannelChange{
+		ArtefactID: af.ID,
+		Channel:    req.Channel,
+		Branch:     branch,
+		NewBuildID: req.BuildID,
+		UserID:     u.ID,
+		Timestamp:  now,
+	}
+	if ch == nil {
+		ch = &pb.Channel{ArtefactID: af.ID, Name: req.Channel, Branch: branch, BuildID: req.BuildID, UpdatedBy: u.ID, Updated: now}
+		_, err = db.DefaultDBChannel().Save(ctx, ch)
+	} else {
+		if ch.Branch == branch && ch.BuildID == req.BuildID {
+			return ch, nil
+		}
+		cc.OldBuildID = ch.BuildID
+		ch.Branch = branch
+		ch.BuildID = req.BuildID
+		ch.UpdatedBy = u.ID
+		ch.Updated = now
+		err = db.DefaultDBChannel().Update(ctx, ch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	channel_cache.Evict(CHANNEL_CACHE_KEY)
+	_, err = db.DefaultDBChannelChange().Save(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("User %s promoted build %d (branch %s) of artefact #%d (%s) to channel %s (was build %d)\n", auth.Description(u), cc.NewBuildID, branch, af.ID, af.Name, cc.Channel, cc.OldBuildID)
+	return ch, nil
+}
+
+func (e *artefactServer) ListChannels(ctx context.Context, req *pb.ID) (*pb.ChannelList, error) {
+	af, err := idstore.ByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, xerr := requestAccess(ctx, af.Name, af.Domain)
+	if xerr != nil {
+		return nil, xerr
+	}
+	chs, err := allChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.ChannelList{Channels: chs[af.ID]}
+	return res, nil
+}
+
+func (e *artefactServer) ChannelHistory(ctx context.Context, req *pb.ID) (*pb.ChannelChangeList, error) {
+	af, err := idstore.ByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, xerr := requestAccess(ctx, af.Name, af.Domain)
+	if xerr != nil {
+		return nil, xerr
+	}
+	ccs, err := db.DefaultDBChannelChange().ByArtefactID(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ccs, func(i, j int) bool {
+		return ccs[i].ID > ccs[j].ID
+	})
+	return &pb.ChannelChangeList{Changes: ccs}, nil
+}
+
+// artefactid -> channels (sorted by name)
+func allChannels(ctx context.Context) (map[uint64][]*pb.Channel, error) {
+	o := channel_cache.Get(CHANNEL_CACHE_KEY)
+	if o != nil {
+		return (o.(*channel_cache_entry)).byartefact, nil
+	}
+	chs, err := db.DefaultDBChannel().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(chs, func(i, j int) bool {
+		return chs[i].Name < chs[j].Name
+	})
+	res := make(map[uint64][]*pb.Channel)
+	for _, ch := range chs {
+		res[ch.ArtefactID] = append(res[ch.ArtefactID], ch)
+	}
+	channel_cache.Put(CHANNEL_CACHE_KEY, &channel_cache_entry{byartefact: res})
+	return res, nil
+}
+
+// a channel of an artefact, nil if there is none by that name
+func artefactChannel(ctx context.Context, artefactid uint64, name string) (*pb.Channel, error) {
+	chs, err := allChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range chs[artefactid] {
+		if ch.Name == name {
+			return ch, nil
+		}
+	}
+	return nil, nil
+}
+
+// like artefactChannel, but from the database rather than the cache
+func loadChannel(ctx context.Context, artefactid uint64, name string) (*pb.Channel, error) {
+	chs, err := db.DefaultDBChannel().ByArtefactID(ctx, artefactid)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range chs {
+		if ch.Name == name {
+			return ch, nil
+		}
+	}
+	return nil, nil
+}
+
+// the build a channel resolves to. if its build is yanked, that is the build the channel
+// pinned before (from the channel's history) which is not yanked
+func channelBuild(ctx context.Context, ch *pb.Channel) (uint64, error) {
+	yanked, err := isYanked(ctx, ch.ArtefactID, ch.Branch, ch.BuildID)
+	if err != nil {
+		return 0, err
+	}
+	if !yanked {
+		return ch.BuildID, nil
+	}
+	ccs, err := db.DefaultDBChannelChange().ByArtefactID(ctx, ch.ArtefactID)
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(ccs, func(i, j int) bool {
+		return ccs[i].ID > ccs[j].ID
+	})
+	for _, cc := range ccs {
+		if cc.Channel != ch.Name || cc.Branch != ch.Branch {
+			continue
+		}
+		yanked, err = isYanked(ctx, ch.ArtefactID, cc.Branch, cc.NewBuildID)
+		if err != nil {
+			return 0, err
+		}
+		if !yanked {
+			return cc.NewBuildID, nil
+		}
+	}
+	return 0, errors.NotFound(ctx, "all builds of channel \"%s\" of artefact #%d are yanked", ch.Name, ch.ArtefactID)
+}
+
+// the names of the channels which pin this build
+func channelsOfBuild(chs []*pb.Channel, branch string, build uint64) []string {
+	var res []string
+	for _, ch := range chs {
+		if ch.Branch == branch && ch.BuildID == build {
+			res = append(res, ch.Name)
+		}
+	}
+	return res
+}
+
+// like channelsOfBuild, errors are logged and result in no channels
+func buildChannels(ctx context.Context, artefactid uint64, branch string, build uint64) []string {
+	chs, err := allChannels(ctx)
+	if err != nil {
+		fmt.Printf("failed to get channels: %s\n", utils.ErrorString(err))
+		return nil
+	}
+	return channelsOfBuild(chs[artefactid], branch, build)
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/artefact/db"
+	"golang.conradwood.net/go-easyops/authremote"
+	"golang.conradwood.net/go-easyops/cache"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ sha256 of files in builds. these are computed on first access (download or explicit request)
+ and stored in the database. files in directory listings which have no checksum yet are queued
+ and computed in the background.
+*/
+
+const (
+	CHECKSUM_FILENAME = "SHA256SUMS"
+)
+
+var (
+	checksum_workers = flag.Int("checksum_workers", 2, "number of background workers computing sha256 of files")
+	checksum_cache   = cache.New("checksums", time.Duration(60)*time.Minute, 10000)
+	checksum_queue   = make(chan *checksum_request, 1000)
+	checksum_queued  = make(map[string]bool)
+	checksum_lock    sync.Mutex
+	checksum_started sync.Once
+)
+
+type checksum_request struct {
+	artefactid uint64
+	domain     string
+	repository string
+	branch     string
+	build      uint64
+	path       string // relative to repository root, no leading '/'
+}
+
+type checksum_cache_entry struct {
+	sha256 string
+}
+
+func newChecksumRequest(af *pb.ArtefactID, branch string, build uint64, path string) *checksum_request {
+	return &checksum_request{
+		artefactid: af.ID,
+		domain:     af.Domain,
+		repository: af.Name,
+		branch:     branch,
+		build:      build,
+		path:       checksumPath(path),
+	}
+}
+func checksumRequestForFile(domain string, file *br.File) (*checksum_request, error) {
+	afid, err := artefactToID(file.Repository, domain)
+	if err != nil {
+		return nil, err
+	}
+	af := &pb.ArtefactID{ID: afid, Domain: domain, Name: file.Repository}
+	return newChecksumRequest(af, file.Branch, file.BuildID, file.Filename), nil
+}
+
+// e.g. "/dist//foo" -> "dist/foo"
+func checksumPath(path string) string {
+	return strings.TrimPrefix(filepath.Clean("/"+path), "/")
+}
+
+func (cr *checksum_request) key() string {
+	return fmt.Sprintf("%d/%s/%d/%s", cr.artefactid, cr.branch, cr.build, cr.path)
+}
+func (cr *checksum_request) file() *br.File {
+	return &br.File{
+		Repository: cr.repository,
+		Branch:     cr.branch,
+		BuildID:    cr.build,
+		Filename:   cr.path,
+	}
+}
+
+// the sha256 of a file, if known. returns "" (empty string) if it is not known
+func knownChecksum(ctx context.Context, cr *checksum_request) (string, error) {
+	o := checksum_cache.Get(cr.key())
+	if o != nil {
+		return (o.(*checksum_cache_entry)).sha256, nil
+	}
+	q := db.DefaultDBFileChecksum().NewQuery()
+	q.AddEqual("artefactid", cr.artefactid)
+	q.AddEqual("branch", cr.branch)
+	q.AddEqual("buildid", cr.build)
+	q.AddEqual("path", cr.path)
+	fcs, err := db.DefaultDBFileChecksum().ByDBQuery(ctx, q)
+	if err != nil {
+		return "", err
+	}
+	if len(fcs) == 0 {
+		return "", nil
+	}
+	checksum_cache.Put(cr.key(), &checksum_cache_entry{sha256: fcs[0].SHA256})
+	return fcs[0].SHA256, nil
+}
+
+// all known checksums of a build, path->sha256
+func knownChecksums(ctx context.Context, af *pb.ArtefactID, branch string, build uint64) (map[string]string, error) {
+	q := db.DefaultDBFileChecksum().NewQuery()
+	q.AddEqual("artefactid", af.ID)
+	q.AddEqual("branch", branch)
+	q.AddEqual("buildid", build)
+	fcs, err := db.DefaultDBFileChecksum().ByDBQuery(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]string)
+	for _, fc := range fcs {
+		res[fc.Path] = fc.SHA256
+	}
+	return res, nil
+}
+
+// the sha256 of a file. computed if not known yet
+func getChecksum(ctx context.Context, cr *checksum_request) (string, error) {
+	sum, err := knownChecksum(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	if sum != "" {
+		return sum, nil
+	}
+	hw := newHashWriter(io.Discard)
+	err = brepo.GetFile(ctx, cr.domain, &br.GetFileRequest{File: cr.file(), Blocksize: 8192}, hw)
+	if err != nil {
+		return "", err
+	}
+	sum = hw.Sum()
+	err = saveChecksum(ctx, cr, sum, hw.size)
+	if err != nil {
+		return "", err
+	}
+	return sum, nil
+}
+
+func saveChecksum(ctx context.Context, cr *checksum_request, sum string, size uint64) error {
+	known, err := knownChecksum(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if known != "" {
+		if known != sum {
+			fmt.Printf("WARNING: sha256 of %s changed (was %s, now %s)\n", cr.key(), known, sum)
+		}
+		return nil
+	}
+	fc := &pb.FileChecksum{
+		ArtefactID: cr.artefactid,
+		Branch:     cr.branch,
+		BuildID:    cr.build,
+		Path:       cr.path,
+		SHA256:     sum,
+		Size:       size,
+		Created:    uint32(time.Now().Unix()),
+	}
+	_, err = db.DefaultDBFileChecksum().Save(ctx, fc)
+	if err != nil {
+		return err
+	}
+	debugf("sha256 of %s is %s\n", cr.key(), sum)
+	checksum_cache.Put(cr.key(), &checksum_cache_entry{sha256: sum})
+	return nil
+}
+
+// store the checksum of a file which was just streamed through a hashwriter (errors are only logged)
+func rememberChecksum(ctx context.Context, domain string, file *br.File, hw *hashwriter) {
+	cr, err := checksumRequestForFile(domain, file)
+	if err == nil {
+		err = saveChecksum(ctx, cr, hw.Sum(), hw.size)
+	}
+	if err != nil {
+		fmt.Printf("failed to save sha256 of %s: %s\n", file.Filename, utils.ErrorString(err))
+	}
+}
+
+// compute the checksum in the background (if it is not already queued)
+func queueChecksum(cr *checksum_request) {
+	checksum_started.Do(func() {
+		for i := 0; i < *checksum_workers; i++ {
+			go checksum_worker()
+		}
+	})
+	checksum_lock.Lock()
+	defer checksum_lock.Unlock()
+	if checksum_queued[cr.key()] {
+		return
+	}
+	select {
+	case checksum_queue <- cr:
+		checksum_queued[cr.key()] = true
+	default:
+		// queue full, will be retried on next access
+	}
+}
+
+func checksum_worker() {
+	for cr := range checksum_queue {
+		ctx := authremote.Context()
+		_, err := getChecksum(ctx, cr)
+		if err != nil {
+			fmt.Printf("failed to compute sha256 of %s: %s\n", cr.key(), utils.ErrorString(err))
+		}
+		checksum_lock.Lock()
+		delete(checksum_queued, cr.key())
+		checksum_lock.Unlock()
+	}
+}
+
+// add known checksums to the files in a listing and queue the ones which are not known yet
+func fillChecksums(ctx context.Context, af *pb.ArtefactID, branch string, build uint64, files []*pb.Contents) {
+	known, err := knownChecksums(ctx, af, branch, build)
+	if err != nil {
+		fmt.Printf("failed to get checksums for %s: %s\n", af.Name, utils.ErrorString(err))
+		return
+	}
+	for _, f := range files {
+		if f.Type != pb.ContentType_File {
+			continue
+		}
+		path := checksumPath(f.Path + "/" + f.Name)
+		f.SHA256 = known[path]
+		if f.SHA256 == "" {
+			queueChecksum(newChecksumRequest(af, branch, build, path))
+		}
+	}
+}
+
+// create a SHA256SUMS file for all files in a directory (recursively)
+func createChecksumFile(ctx context.Context, af *pb.ArtefactID, branch string, build uint64, dir string) ([]byte, error) {
+	dir = checksumPath(dir)
+	lfr, _, err := brepo.ListFiles(ctx, af.Domain, &br.ListFilesRequest{
+		Repository: af.Name,
+		Branch:     branch,
+		BuildID:    build,
+		Recursive:  true,
+		Dir:        dir,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range lfr.Entries {
+		if entry.Type != 1 {
+			continue
+		}
+		fname := checksumPath(entry.Dir + "/" + entry.Name)
+		if dir != "" && !strings.HasPrefix(fname, dir+"/") {
+			continue
+		}
+		files = append(files, fname)
+	}
+	if len(files) == 0 {
+		return nil, errors.NotFound(ctx, "no files in \"%s\"", dir)
+	}
+	sort.Strings(files)
+	res := ""
+	for _, fname := range files {
+		sum, err := getChecksum(ctx, newChecksumRequest(af, branch, build, fname))
+		if err != nil {
+			return nil, err
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(fname, dir), "/")
+		res = res + sum + "  " + rel + "\n"
+	}
+	return []byte(res), nil
+}
+
+// an io.Writer which computes the sha256 of everything written to it
+type hashwriter struct {
+	target io.Writer
+	hash   hash.Hash
+	size   uint64
+}
+
+func newHashWriter(target io.Writer) *hashwriter {
+	return &hashwriter{target: target, hash: sha256.New()}
+}
+func (hw *hashwriter) Write(buf []byte) (int, error) {
+	n, err := hw.target.Write(buf)
+	hw.hash.Write(buf[:n])
+	hw.size = hw.size + uint64(n)
+	return n, err
+}
+
+// hex encoded sha256
+func (hw *hashwriter) Sum() string {
+	return hex.EncodeToString(hw.hash.Sum(nil))
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/apis/gitserver"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ resolves git commits to builds. the commit of a build is known if it was recorded (see build_records.go)
+ while the build was the latest build of its branch. otherwise the gitserver is asked: buildrepo build ids are
+ gitserver build ids. what it reports is recorded, so that each build is only asked about once.
+*/
+
+const (
+	MIN_COMMIT_LEN = 7 // shortest abbreviated commit hash GetBuildForCommit accepts
+)
+
+var (
+	commit_scan_builds = flag.Int("commit_scan_builds", 100, "GetBuildForCommit asks the gitserver about at most this many builds (per branch, newest first) with unknown commit")
+)
+
+func (e *artefactServer) GetBuildForCommit(ctx context.Context, req *pb.BuildForCommitRequest) (*pb.BuildInfo, error) {
+	commit := strings.ToLower(strings.TrimSpace(req.CommitID))
+	if len(commit) < MIN_COMMIT_LEN {
+		return nil, errors.InvalidArgs(ctx, "commit too short", "commit \"%s\" is too short, need at least %d characters", req.CommitID, MIN_COMMIT_LEN)
+	}
+	af, err := idstore.ByID(ctx, req.ArtefactID)
+	if err != nil {
+		return nil, err
+	}
+	_, xerr := requestAccess(ctx, af.Name, af.Domain)
+	if xerr != nil {
+		return nil, xerr
+	}
+	// make sure the latest builds are recorded
+	lbr, err := brepo.ListBranches(ctx, af.Domain, &br.ListBranchesRequest{Repository: af.Name})
+	if err != nil {
+		return nil, err
+	}
+	repositoryid := uint64(0)
+	for _, branch := range lbr.Branches {
+		glv, err := brepo.GetLatestVersion(ctx, af.Domain, &br.GetLatestVersionRequest{Repository: af.Name, Branch: branch})
+		if err != nil {
+			return nil, err
+		}
+		recordBuild(ctx, af.ID, branch, glv.BuildID, glv.BuildMeta)
+		if glv.BuildMeta != nil && glv.BuildMeta.RepositoryID != 0 {
+			repositoryid = glv.BuildMeta.RepositoryID
+		}
+	}
+	records, err := buildRecords(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	found, err := commitRecord(ctx, records, commit)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil && repositoryid != 0 {
+		err = recordGitserverCommits(ctx, af, lbr.Branches, repositoryid, records)
+		if err != nil {
+			return nil, err
+		}
+		found, err = commitRecord(ctx, records, commit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if found == nil {
+		return nil, errors.NotFound(ctx, "no known build of artefact #%d for commit \"%s\"", af.ID, req.CommitID)
+	}
+	return buildInfo(ctx, af.ID, found.Branch, found.BuildID, records), nil
+}
+
+// the newest record of a (possibly abbreviated, lowercase) commit, nil if there is none
+func commitRecord(ctx context.Context, records map[string]*pb.BuildRecord, commit string) (*pb.BuildRecord, error) {
+	var found *pb.BuildRecord
+	for _, rec := range records {
+		if rec.CommitID == "" || !strings.HasPrefix(strings.ToLower(rec.CommitID), commit) {
+			continue
+		}
+		if found != nil && !strings.EqualFold(found.CommitID, rec.CommitID) {
+			return nil, errors.InvalidArgs(ctx, "ambiguous commit", "commit \"%s\" is ambiguous (%s and %s)", commit, found.CommitID, rec.CommitID)
+		}
+		// the same commit may have been built more than once, prefer the newest build
+		if found == nil || rec.Created > found.Created || (rec.Created == found.Created && rec.BuildID > found.BuildID) {
+			found = rec
+		}
+	}
+	return found, nil
+}
+
+// asks the gitserver for the commits of builds whose commit is not known and records them (in the database and in records)
+func recordGitserverCommits(ctx context.Context, af *pb.ArtefactID, branches []string, repositoryid uint64, records map[string]*pb.BuildRecord) error {
+	for _, branch := range branches {
+		builds, err := artefactBuilds(ctx, af, branch) // newest first
+		if err != nil {
+			return err
+		}
+		asked := 0
+		for _, b := range builds {
+			key := buildMarkKey(af.ID, branch, b)
+			if records[key] != nil && records[key].CommitID != "" {
+				continue
+			}
+			if asked >= *commit_scan_builds {
+				break
+			}
+			asked++
+			gb, err := gitserver.GetGIT2Client().GetBuildByID(ctx, &gitserver.ByIDRequest{ID: b})
+			if err != nil {
+				debugf("gitserver has no build %d (artefact #%d): %s\n", b, af.ID, utils.ErrorString(err))
+				continue
+			}
+			if gb.RepositoryID != repositoryid || gb.CommitHash == "" {
+				continue
+			}
+			var rec *pb.BuildRecord
+			err = updateBuildRecord(ctx, af.ID, branch, b, func(r *pb.BuildRecord) bool {
+				r.CommitID = gb.CommitHash
+				r.BuildUserID = gb.UserID
+				if r.Created == 0 {
+					r.Created = gb.Timestamp
+				}
+				rec = r
+				return true
+			})
+			if err != nil {
+				return err
+			}
+			records[key] = rec
+			fmt.Printf("Recorded commit %s of build %d (branch %s) of artefact #%d from gitserver\n", gb.CommitHash, b, branch, af.ID)
+		}
+	}
+	return nil
+}
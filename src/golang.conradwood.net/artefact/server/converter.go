@@ -34,42 +34,28 @@ func (e *artefactServer) GetArtefactIDForRepo(ctx context.Context, id *pb.ID) (*
 
 // return the artefactid from a repoid
 func (e *artefactServer) GetArtefactForRepo(ctx context.Context, id *pb.ID) (*pb.ID, error) {
-	rafid, err := try_resolve_repoid_by_url(ctx, id)
-	if err == nil {
-		return rafid, nil
+	c := currentCatalogue()
+	if c != nil {
+		ce := c.byrepo[id.ID]
+		if ce != nil {
+			return &pb.ID{ID: ce.artefactid}, nil
+		}
 	}
-	debugf("getting artefact for repo %d\n", id.ID)
-	repos, err := brepo.ListRepos(ctx)
+	afid, err := artefactForRepoFromIndex(ctx, id.ID)
 	if err != nil {
-		debugf("error listing repos: %s", utils.ErrorString(err))
 		return nil, err
 	}
-	debugf("Found %d repos\n", len(repos.Entries))
-	afid := uint64(0)
-	for _, r := range repos.Entries {
-		if *debug {
-			fmt.Printf("Checking %s against %d\n", r.Name, id.ID)
-		}
-		glv, err := brepo.GetLatestVersion(ctx, r.Domain, &br.GetLatestVersionRequest{
-			Repository: r.Name,
-			Branch:     "master",
-		})
-		if err != nil {
-			return nil, err
-		}
-		if glv.BuildMeta != nil && glv.BuildMeta.RepositoryID == id.ID {
-			//			artefact_repo_cache.Put(fmt.Sprintf("%d",id),
-			fmt.Printf("Name: %s, Domain: %s\n", r.Name, r.Domain)
-			afid, err = artefactToID(r.Name, r.Domain)
-			if err != nil {
-				return nil, err
-			}
-		}
+	if afid != 0 {
+		return &pb.ID{ID: afid}, nil
 	}
-	if afid == 0 {
-		return nil, errors.NotFound(ctx, "no artefact for repo")
+	rafid, err := try_resolve_repoid_by_url(ctx, id)
+	if err == nil {
+		tryIndexRepoArtefact(ctx, id.ID, rafid.ID)
+		return rafid, nil
 	}
-	return &pb.ID{ID: afid}, nil
+	debugf("repo %d not (yet) indexed: %s\n", id.ID, utils.ErrorString(err))
+	triggerReindex()
+	return nil, errors.NotFound(ctx, "no artefact for repo")
 }
 func (e *artefactServer) GetRepoForArtefact(ctx context.Context, id *pb.ID) (*pb.ID, error) {
 	key := fmt.Sprintf("%d", id.ID)
@@ -90,11 +76,12 @@ func (e *artefactServer) GetRepoForArtefact(ctx context.Context, id *pb.ID) (*pb
 	if err == nil {
 		res := &pb.ID{ID: rmi.RepositoryID}
 		repo_artefact_cache.Put(key, &repo_artefact_cache_entry{response: res})
+		tryIndexRepoArtefact(ctx, res.ID, af.ID)
 		return res, nil
 	}
 	glv, err := brepo.GetLatestVersion(ctx, af.Domain, &br.GetLatestVersionRequest{
 		Repository: af.Name,
-		Branch:     "master",
+		Branch:     defaultBranch(ctx, af.Domain, af.Name),
 	})
 	if err != nil {
 		return nil, err
@@ -102,6 +89,7 @@ func (e *artefactServer) GetRepoForArtefact(ctx context.Context, id *pb.ID) (*pb
 	if glv.BuildMeta != nil {
 		res := &pb.ID{ID: glv.BuildMeta.RepositoryID}
 		repo_artefact_cache.Put(key, &repo_artefact_cache_entry{response: res})
+		tryIndexRepoArtefact(ctx, res.ID, af.ID)
 		return res, nil
 	}
 	return nil, errors.Unavailable(ctx, "buildrepo information unavailable")
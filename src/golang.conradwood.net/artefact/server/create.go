@@ -42,9 +42,14 @@ func (a *artefactServer) CreateArtefactIfRequired(ctx context.Context, req *pb.C
 
 	if myaf != nil {
 		fmt.Printf(prefix+" exists already (id=%d)\n", myaf.ID)
-		// if new create request has a new url, update it
-		if req.GitURL != "" && myaf.URL != req.GitURL {
-			myaf.URL = req.GitURL
+		// if new create request has a new url, update it. also record the organisation of older artefacts
+		if (req.GitURL != "" && myaf.URL != req.GitURL) || (myaf.OrganisationID == "" && req.OrganisationID != "") {
+			if req.GitURL != "" {
+				myaf.URL = req.GitURL
+			}
+			if myaf.OrganisationID == "" {
+				myaf.OrganisationID = req.OrganisationID
+			}
 			err = db.DefaultDBArtefactID().Update(ctx, myaf)
 			if err != nil {
 				return nil, err
@@ -61,10 +66,11 @@ func (a *artefactServer) CreateArtefactIfRequired(ctx context.Context, req *pb.C
 		return res, nil
 	}
 	myaf = &pb.ArtefactID{
-		Domain:  req.BuildRepoDomain,
-		Name:    req.ArtefactName,
-		URL:     req.GitURL,
-		Created: uint32(time.Now().Unix()),
+		Domain:         req.BuildRepoDomain,
+		Name:           req.ArtefactName,
+		URL:            req.GitURL,
+		Created:        uint32(time.Now().Unix()),
+		OrganisationID: req.OrganisationID,
 	}
 	_, err = db.DefaultDBArtefactID().Save(ctx, myaf)
 	if err != nil {
@@ -85,6 +91,7 @@ func create_artefact_meta(ctx context.Context, af *pb.ArtefactID) (*pb.ArtefactM
 	var lb *pb.LatestBuild
 	afs := &artefactServer{}
 	repoid := uint64(0)
+	// this also adds the artefact to the repository index
 	ridp, err := afs.GetRepoForArtefact(ctx, &pb.ID{ID: af.ID})
 	if err != nil {
 		fmt.Printf("Got no repo for artefact id #%d\n", af.ID)
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ compares the files of two builds of an artefact. files are compared by size and, if the sizes are equal, by sha256.
+ modified (or added/removed) small text files also get a unified diff.
+*/
+
+var (
+	diff_max_size   = flag.Uint64("diff_max_size", 64*1024, "text files up to this size (in bytes) get a unified diff in DiffBuilds")
+	diff_extensions = map[string]bool{
+		".yaml": true, ".yml": true, ".json": true, ".toml": true, ".ini": true, ".conf": true, ".cfg": true,
+		".properties": true, ".xml": true, ".txt": true, ".md": true, ".sh": true, ".env": true, ".proto": true,
+	}
+)
+
+func (e *artefactServer) DiffBuilds(ctx context.Context, req *pb.DiffRequest) (*pb.BuildDiff, error) {
+	if req.BuildA == 0 || req.BuildB == 0 {
+		return nil, errors.InvalidArgs(ctx, "missing build", "two builds are required to diff")
+	}
+	af, err := idstore.ByID(ctx, req.ArtefactID)
+	if err != nil {
+		return nil, err
+	}
+	_, xerr := requestAccess(ctx, af.Name, af.Domain)
+	if xerr != nil {
+		return nil, xerr
+	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return nil, err
+	}
+	dir := checksumPath(req.Dir)
+	filesA, err := diffFiles(ctx, af, branch, req.BuildA, dir)
+	if err != nil {
+		return nil, err
+	}
+	filesB, err := diffFiles(ctx, af, branch, req.BuildB, dir)
+	if err != nil {
+		return nil, err
+	}
+	sumsA, err := knownChecksums(ctx, af, branch, req.BuildA)
+	if err != nil {
+		return nil, err
+	}
+	sumsB, err := knownChecksums(ctx, af, branch, req.BuildB)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for p := range filesA {
+		paths = append(paths, p)
+	}
+	for p := range filesB {
+		if !filesA[p] {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	res := &pb.BuildDiff{ArtefactID: af.ID, Branch: branch, BuildA: req.BuildA, BuildB: req.BuildB}
+	for _, p := range paths {
+		fd := &pb.FileDiff{Path: p}
+		if filesA[p] {
+			fd.SizeA, err = fileSize(ctx, af, branch, req.BuildA, p)
+			if err != nil {
+				return nil, err
+			}
+			fd.SHA256A = sumsA[p]
+		}
+		if filesB[p] {
+			fd.SizeB, err = fileSize(ctx, af, branch, req.BuildB, p)
+			if err != nil {
+				return nil, err
+			}
+			fd.SHA256B = sumsB[p]
+		}
+		if !filesA[p] {
+			fd.Change = pb.FileChange_FileAdded
+		} else if !filesB[p] {
+			fd.Change = pb.FileChange_FileRemoved
+		} else {
+			fd.Change = pb.FileChange_FileModified
+			if fd.SizeA == fd.SizeB {
+				if fd.SHA256A == "" {
+					fd.SHA256A, err = getChecksum(ctx, newChecksumRequest(af, branch, req.BuildA, p))
+					if err != nil {
+						return nil, err
+					}
+				}
+				if fd.SHA256B == "" {
+					fd.SHA256B, err = getChecksum(ctx, newChecksumRequest(af, branch, req.BuildB, p))
+					if err != nil {
+						return nil, err
+					}
+				}
+				if fd.SHA256A == fd.SHA256B {
+					res.Unchanged++
+					continue
+				}
+			}
+		}
+		fd.SizeDelta = int64(fd.SizeB) - int64(fd.SizeA)
+		res.SizeDelta = res.SizeDelta + fd.SizeDelta
+		fd.UnifiedDiff = textDiff(ctx, af, branch, req.BuildA, req.BuildB, fd)
+		res.Files = append(res.Files, fd)
+	}
+	return res, nil
+}
+
+// the files of a build in dir (all files if dir is ""), as a set
+func diffFiles(ctx context.Context, af *pb.ArtefactID, branch string, build uint64, dir string) (map[string]bool, error) {
+	files, err := buildFiles(ctx, af, branch, build)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]bool)
+	for _, f := range files {
+		if dir == "" || strings.HasPrefix(f, dir+"/") {
+			res[f] = true
+		}
+	}
+	return res, nil
+}
+
+func fileSize(ctx context.Context, af *pb.ArtefactID, branch string, build uint64, path string) (uint64, error) {
+	gmr, err := brepo.GetFileMetaData(ctx, af.Domain, &br.GetMetaRequest{File: &br.File{
+		Repository: af.Name,
+		Branch:     branch,
+		BuildID:    build,
+		Filename:   path,
+	}})
+	if err != nil {
+		return 0, err
+	}
+	return gmr.Size, nil
+}
+
+// unified diff of a small text file, "" if it is not one. errors are logged and result in no diff
+func textDiff(ctx context.Context, af *pb.ArtefactID, branch string, buildA, buildB uint64, fd *pb.FileDiff) string {
+	if !diff_extensions[strings.ToLower(filepath.Ext(fd.Path))] || fd.SizeA > *diff_max_size || fd.SizeB > *diff_max_size {
+		return ""
+	}
+	labelA := "a/" + fd.Path
+	labelB := "b/" + fd.Path
+	var a, b string
+	var err error
+	if fd.Change == pb.FileChange_FileAdded {
+		labelA = "/dev/null"
+	} else {
+		a, err = fileContent(ctx, af, branch, buildA, fd.Path)
+	}
+	if err == nil && fd.Change == pb.FileChange_FileRemoved {
+		labelB = "/dev/null"
+	} else if err == nil {
+		b, err = fileContent(ctx, af, branch, buildB, fd.Path)
+	}
+	if err != nil {
+		fmt.Printf("failed to diff %s of artefact #%d: %s\n", fd.Path, af.ID, utils.ErrorString(err))
+		return ""
+	}
+	if !isText(a) || !isText(b) {
+		return ""
+	}
+	return unifiedDiff(labelA, labelB, a, b)
+}
+
+func fileContent(ctx context.Context, af *pb.ArtefactID, branch string, build uint64, path string) (string, error) {
+	buf := &bytes.Buffer{}
+	err := brepo.GetFile(ctx, af.Domain, &br.GetFileRequest{File: newChecksumRequest(af, branch, build, path).file(), Blocksize: 8192}, buf)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func isText(s string) bool {
+	return utf8.ValidString(s) && !strings.Contains(s, "\x00")
+}
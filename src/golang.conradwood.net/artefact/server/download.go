@@ -12,7 +12,6 @@ import (
 	"golang.conradwood.net/go-easyops/errors"
 	"golang.conradwood.net/go-easyops/rpc"
 	"golang.conradwood.net/go-easyops/utils"
-	"io"
 )
 
 func (e *artefactServer) StreamHTTP(req *h2g.StreamRequest, srv pb.ArtefactService_StreamHTTPServer) error {
@@ -37,6 +36,12 @@ func (e *artefactServer) StreamHTTP(req *h2g.StreamRequest, srv pb.ArtefactServi
 		}
 	}
 	if r == "" {
+		if isGoProxyPath(ctx, req.Path) {
+			return e.goproxy(req, srv)
+		}
+		if isMavenPath(ctx, req.Path) {
+			return e.maven(req, srv)
+		}
 		return e.download_v2(req, srv)
 	}
 	fmt.Printf("Downloading. Parsing reference \"%s\"...\n", r)
@@ -55,50 +60,19 @@ func (e *artefactServer) StreamHTTP(req *h2g.StreamRequest, srv pb.ArtefactServi
 
 	ctx = authremote.Context() // stream stuff still doesn't work quite right
 
+	warning, err := checkReferenceDownload(ctx, ref)
+	if err != nil {
+		return err
+	}
 	fname := fmt.Sprintf("%s/%s", ref.path, ref.name)
 	fmt.Printf("Downloading (%s:%s) from \"%s\"...\n", ref.Repository(), fname, ref.buildrepo)
-	b := brepo.GetBuildRepoManagerClient(ref.Repository(), ref.domain)
 	file := &br.File{
 		Repository: ref.Repository(),
 		Branch:     ref.Branch(),
 		BuildID:    ref.Version(),
 		Filename:   fname,
 	}
-
-	glv, err := b.GetFileMetaData(ctx, &br.GetMetaRequest{File: file})
-	if err != nil {
-		fmt.Printf("Unable to get size of file: %s\n", utils.ErrorString(err))
-		return err
-	}
-	fsize := glv.Size
-	fmt.Printf("Filesize: %d\n", fsize)
-
-	err = srv.Send(&h2g.StreamDataResponse{Response: &h2g.StreamResponse{
-		Filename: fname,
-		Size:     fsize,
-		MimeType: getmimetype(fname),
-	}})
-	if err != nil {
-		return err
-	}
-	bc, err := b.GetFileAsStream(ctx, &br.GetFileRequest{File: file, Blocksize: 8192})
-	if err != nil {
-		return err
-	}
-	for {
-		fb, err := bc.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return err
-		}
-		err = srv.Send(&h2g.StreamDataResponse{Data: fb.Data[:fb.Size]})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return sendBuildFile(ctx, srv, req, ref.domain, file, fname, warning)
 }
 
 func (e *artefactServer) GetFile(req *pb.Reference, srv pb.ArtefactService_GetFileServer) error {
@@ -126,12 +100,12 @@ func (e *artefactServer) GetFile(req *pb.Reference, srv pb.ArtefactService_GetFi
 
 	ctx = authremote.Context() // stream stuff still doesn't work quite right
 
+	warning, err := checkReferenceDownload(ctx, ref)
+	if err != nil {
+		return err
+	}
 	fname := fmt.Sprintf("%s/%s", ref.path, ref.name)
 	fmt.Printf("Downloading (%s:%s)...\n", ref.Repository(), fname)
-	b := brepo.GetBuildRepoManagerClient(ref.Repository(), ref.domain)
-	if b == nil {
-		return errors.NotFound(ctx, "no buildrepo for domain \"%s\"", ref.domain)
-	}
 	file := &br.File{
 		Repository: ref.Repository(),
 		Branch:     ref.Branch(),
@@ -139,39 +113,26 @@ func (e *artefactServer) GetFile(req *pb.Reference, srv pb.ArtefactService_GetFi
 		Filename:   fname,
 	}
 
-	glv, err := b.GetFileMetaData(ctx, &br.GetMetaRequest{File: file})
+	glv, err := brepo.GetFileMetaData(ctx, ref.domain, &br.GetMetaRequest{File: file})
 	if err != nil {
 		fmt.Printf("Unable to get size of file: %s\n", utils.ErrorString(err))
 		return err
 	}
 	fsize := glv.Size
 	fmt.Printf("Filesize: %d\n", fsize)
-	err = srv.Send(&h2g.StreamDataResponse{Response: &h2g.StreamResponse{
+	sr := &h2g.StreamResponse{
 		Filename: fname,
 		Size:     fsize,
 		MimeType: "binary/octet-stream",
-	}})
-	if err != nil {
-		return err
 	}
-	bc, err := b.GetFileAsStream(ctx, &br.GetFileRequest{File: file, Blocksize: 8192})
+	if warning != "" {
+		sr.ExtraHeaders = map[string]string{"Warning": fmt.Sprintf("299 - %q", warning)}
+	}
+	err = srv.Send(&h2g.StreamDataResponse{Response: sr})
 	if err != nil {
 		return err
 	}
-	for {
-		fb, err := bc.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return err
-		}
-		err = srv.Send(&h2g.StreamDataResponse{Data: fb.Data[:fb.Size]})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return brepo.GetFile(ctx, ref.domain, &br.GetFileRequest{File: file, Blocksize: 8192}, &httpwriter{srv: srv})
 }
 func getmimetype(filename string) string {
 	if strings.HasSuffix(filename, ".html") {
@@ -186,6 +147,16 @@ func getmimetype(filename string) string {
 	return "binary/octet-stream"
 }
 
-
-
-
+// the part of path after prefix, if path starts with prefix. the prefix may also follow
+// the h2gproxy mount point (the first element of the path), e.g. /artefact/goproxy/...
+func routedPath(path string, prefix string) (string, bool) {
+	if strings.HasPrefix(path, prefix) {
+		return path[len(prefix):], true
+	}
+	p := strings.TrimPrefix(path, "/")
+	idx := strings.Index(p, "/")
+	if idx == -1 || !strings.HasPrefix(p[idx:], prefix) {
+		return "", false
+	}
+	return p[idx+len(prefix):], true
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	pb "golang.conradwood.net/apis/artefact"
 	br "golang.conradwood.net/apis/buildrepo"
@@ -10,7 +11,7 @@ import (
 	"golang.conradwood.net/go-easyops/rpc"
 	//	"golang.conradwood.net/go-easyops/tokens"
 	"golang.conradwood.net/go-easyops/utils"
-	"io"
+	"path/filepath"
 )
 
 func (e *artefactServer) download_v2(req *h2g.StreamRequest, srv pb.ArtefactService_StreamHTTPServer) error {
@@ -38,55 +39,149 @@ func (e *artefactServer) download_v2(req *h2g.StreamRequest, srv pb.ArtefactServ
 		fmt.Printf("User #%s (%s) does not have access to artefact %s\n", user.ID, user.Email, lr.String())
 		return err
 	}
+	warning, err := checkDownload(ctx, lr.GetArtefact().ID, lr.Branch(), lr.ResolvedVersion(ctx))
+	if err != nil {
+		return err
+	}
+	recordDownload(ctx, lr.GetArtefact().ID, lr.Branch(), lr.ResolvedVersion(ctx))
 	fmt.Printf("Downloading: %s\n", lr.String())
+	if filepath.Base(lr.Path()) == CHECKSUM_FILENAME {
+		fei, err := brepo.DoesFileExist(ctx, lr.Domain(), &br.GetFileRequest{File: &br.File{
+			Repository: lr.ArtefactName(),
+			Branch:     lr.Branch(),
+			BuildID:    lr.ResolvedVersion(ctx),
+			Filename:   lr.Path(),
+		}})
+		if err == nil && !fei.Exists {
+			// not part of the build, so we generate it
+			b, err := createChecksumFile(ctx, lr.GetArtefact(), lr.Branch(), lr.ResolvedVersion(ctx), filepath.Dir(lr.Path()))
+			if err != nil {
+				return err
+			}
+			return sendHTTPBytes(srv, CHECKSUM_FILENAME, "text/plain", b)
+		}
+	}
+	format, isarchive := archiveFormatFromHTTP(req)
+	if isarchive {
+		ar := &archive_request{
+			artefact: lr.GetArtefact(),
+			branch:   lr.Branch(),
+			build:    lr.ResolvedVersion(ctx),
+			dir:      lr.Path(),
+			format:   format,
+		}
+		return sendHTTPArchive(ctx, srv, ar)
+	}
 	fname := fmt.Sprintf("%s", lr.Path())
 	fmt.Printf("Downloading (%s:%s) from \"%s\"...\n", lr.ArtefactName(), fname, lr.Domain())
-	b := brepo.GetBuildRepoManagerClient(lr.ArtefactName(), lr.Domain())
 	file := &br.File{
 		Repository: lr.ArtefactName(),
 		Branch:     lr.Branch(),
 		BuildID:    lr.ResolvedVersion(ctx),
 		Filename:   fname,
 	}
+	return sendBuildFile(ctx, srv, req, lr.Domain(), file, fname, warning)
+}
 
-	glv, err := b.GetFileMetaData(ctx, &br.GetMetaRequest{File: file})
+// send a file from a build via http. if req is not nil, its Range/If-Range headers are honoured.
+// warning, if not empty, is sent as http "Warning" header
+func sendBuildFile(ctx context.Context, srv pb.ArtefactService_StreamHTTPServer, req *h2g.StreamRequest, domain string, file *br.File, servedname string, warning string) error {
+	glv, err := brepo.GetFileMetaData(ctx, domain, &br.GetMetaRequest{File: file})
 	if err != nil {
 		fmt.Printf("Unable to get size of file: %s\n", utils.ErrorString(err))
 		return err
 	}
 	fsize := glv.Size
 	fmt.Printf("Filesize: %d\n", fsize)
-
-	err = srv.Send(&h2g.StreamDataResponse{Response: &h2g.StreamResponse{
-		Filename: fname,
+	etag := etagForFile(file)
+	sr := &h2g.StreamResponse{
+		Filename: servedname,
 		Size:     fsize,
-		MimeType: getmimetype(fname),
-	}})
-	if err != nil {
-		return err
+		MimeType: getmimetype(servedname),
+		ExtraHeaders: map[string]string{
+			"Accept-Ranges": "bytes",
+			"ETag":          etag,
+		},
 	}
-	bc, err := b.GetFileAsStream(ctx, &br.GetFileRequest{File: file, Blocksize: 8192})
+	if warning != "" {
+		sr.ExtraHeaders["Warning"] = fmt.Sprintf("299 - %q", warning)
+	}
+	rng, err := parseRange(req, etag, fsize)
 	if err != nil {
-		fmt.Printf("no file as stream: %s\n", err)
-		return err
+		fmt.Printf("%s: %s\n", servedname, err)
+		sr.StatusCode = 416
+		sr.Size = 0
+		sr.ExtraHeaders["Content-Range"] = fmt.Sprintf("bytes */%d", fsize)
+		return srv.Send(&h2g.StreamDataResponse{Response: sr})
 	}
-	for {
-		fb, err := bc.Recv()
-		if err == io.EOF {
-			break
-		}
+	if rng == nil {
+		err = srv.Send(&h2g.StreamDataResponse{Response: sr})
 		if err != nil {
 			return err
 		}
-		err = srv.Send(&h2g.StreamDataResponse{Data: fb.Data[:fb.Size]})
+		hw := newHashWriter(&httpwriter{srv: srv})
+		err = brepo.GetFile(ctx, domain, &br.GetFileRequest{File: file, Blocksize: 8192}, hw)
 		if err != nil {
 			return err
 		}
+		rememberChecksum(ctx, domain, file, hw)
+		return nil
 	}
 
-	return nil
+	debugf("%s: serving range %s\n", servedname, rng.ContentRange())
+	sr.StatusCode = 206
+	sr.Size = rng.Length()
+	sr.ExtraHeaders["Content-Range"] = rng.ContentRange()
+	err = srv.Send(&h2g.StreamDataResponse{Response: sr})
+	if err != nil {
+		return err
+	}
+	// the buildrepo cannot start at an offset, so we read from the start and stop the stream once the range is sent
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	err = brepo.GetFile(cctx, domain, &br.GetFileRequest{File: file, Blocksize: 8192}, newRangeWriter(&httpwriter{srv: srv}, rng))
+	if err == errRangeDone {
+		return nil
+	}
+	return err
 }
 
+// send a small, in-memory file via http
+func sendHTTPBytes(srv pb.ArtefactService_StreamHTTPServer, fname string, mimetype string, data []byte) error {
+	err := srv.Send(&h2g.StreamDataResponse{Response: &h2g.StreamResponse{
+		Filename: fname,
+		Size:     uint64(len(data)),
+		MimeType: mimetype,
+	}})
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		n := 8192
+		if n > len(data) {
+			n = len(data)
+		}
+		err = srv.Send(&h2g.StreamDataResponse{Data: data[:n]})
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
 
+// an io.Writer which sends data via http
+type httpwriter struct {
+	srv pb.ArtefactService_StreamHTTPServer
+}
 
-
+func (hw *httpwriter) Write(buf []byte) (int, error) {
+	// the buffer may be reused by the caller once we return
+	b := make([]byte, len(buf))
+	copy(b, buf)
+	err := hw.srv.Send(&h2g.StreamDataResponse{Data: b})
+	if err != nil {
+		return 0, err
+	}
+	return len(buf), nil
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/go-easyops/authremote"
+	"golang.conradwood.net/go-easyops/cache"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ an in-memory index of the files in the latest build of each artefact in the catalogue.
+ it is refreshed in the background, only builds which changed since the last run are listed again.
+ searches in older builds list the files on demand. builds do not change, so file lists are cached.
+*/
+
+var (
+	file_index_interval    = flag.Duration("file_index_interval", time.Duration(10)*time.Minute, "how often to index the files in the latest builds. 0 to disable")
+	file_search_max        = flag.Uint("file_search_max_results", 500, "maximum number of files FindFiles returns")
+	file_search_max_builds = flag.Int("file_search_max_builds", 50, "maximum number of builds FindFiles searches in a single artefact")
+	filelist_cache         = cache.New("filelist", time.Duration(24)*time.Hour, 1000)
+	file_index             = make(map[uint64]*indexed_build) // artefactid -> latest build
+	file_index_updated     time.Time
+	file_index_lock        sync.Mutex // protects file_index and file_index_updated
+)
+
+type indexed_build struct {
+	branch string
+	build  uint64
+	files  []string // sorted
+}
+
+type filelist_cache_entry struct {
+	files []string
+}
+
+func file_indexer() {
+	if *file_index_interval == 0 {
+		return
+	}
+	for {
+		err := indexFiles()
+		if err != nil {
+			fmt.Printf("failed to index files: %s\n", utils.ErrorString(err))
+		}
+		time.Sleep(*file_index_interval)
+	}
+}
+
+func indexFiles() error {
+	c, err := getCatalogue()
+	if err != nil {
+		return err
+	}
+	started := time.Now()
+	indexed := 0
+	seen := make(map[uint64]bool)
+	for _, ce := range c.entries {
+		seen[ce.artefactid] = true
+		if ce.buildid == 0 {
+			continue
+		}
+		file_index_lock.Lock()
+		ib := file_index[ce.artefactid]
+		file_index_lock.Unlock()
+		if ib != nil && ib.branch == ce.branch && ib.build == ce.buildid {
+			continue
+		}
+		ctx := authremote.Context()
+		files, err := buildFiles(ctx, &pb.ArtefactID{ID: ce.artefactid, Domain: ce.domain, Name: ce.name}, ce.branch, ce.buildid)
+		if err != nil {
+			fmt.Printf("not indexing files of %s in domain %s: %s\n", ce.name, ce.domain, utils.ErrorString(err))
+			continue
+		}
+		file_index_lock.Lock()
+		file_index[ce.artefactid] = &indexed_build{branch: ce.branch, build: ce.buildid, files: files}
+		file_index_lock.Unlock()
+		indexed++
+	}
+	file_index_lock.Lock()
+	for afid := range file_index {
+		if !seen[afid] {
+			delete(file_index, afid)
+		}
+	}
+	file_index_updated = time.Now()
+	file_index_lock.Unlock()
+	debugf("Indexed files of %d builds in %0.1fs\n", indexed, time.Since(started).Seconds())
+	return nil
+}
+
+// all files (not directories) in a build, sorted
+func buildFiles(ctx context.Context, af *pb.ArtefactID, branch string, build uint64) ([]string, error) {
+	key := fmt.Sprintf("%d/%s/%d", af.ID, branch, build)
+	o := filelist_cache.Get(key)
+	if o != nil {
+		return (o.(*filelist_cache_entry)).files, nil
+	}
+	lfr, _, err := brepo.ListFiles(ctx, af.Domain, &br.ListFilesRequest{
+		Repository: af.Name,
+		Branch:     branch,
+		BuildID:    build,
+		Recursive:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range lfr.Entries {
+		if entry.Type != 1 {
+			continue
+		}
+		files = append(files, checksumPath(entry.Dir+"/"+entry.Name))
+	}
+	sort.Strings(files)
+	filelist_cache.Put(key, &filelist_cache_entry{files: files})
+	return files, nil
+}
+
+func (e *artefactServer) FindFiles(ctx context.Context, req *pb.FindFilesRequest) (*pb.FileMatchList, error) {
+	match, err := fileMatcher(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	max := uint32(*file_search_max)
+	if req.MaxResults != 0 && req.MaxResults < max {
+		max = req.MaxResults
+	}
+	if req.ArtefactID != 0 {
+		return findFilesInArtefact(ctx, req, match, max)
+	}
+	c, err := getCatalogue()
+	if err != nil {
+		return nil, err
+	}
+	rs, err := readableArtefacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	file_index_lock.Lock()
+	index := make(map[uint64]*indexed_build, len(file_index))
+	for afid, ib := range file_index {
+		index[afid] = ib
+	}
+	res := &pb.FileMatchList{IndexUpdated: uint32(file_index_updated.Unix())}
+	if file_index_updated.IsZero() {
+		res.IndexUpdated = 0
+	}
+	file_index_lock.Unlock()
+
+	// catalogue entries are sorted by name
+	for _, ce := range c.entries {
+		if !rs.allowed(ce.artefactid) {
+			continue
+		}
+		ib := index[ce.artefactid]
+		if ib == nil {
+			if ce.buildid != 0 {
+				res.Pending++
+			}
+			continue
+		}
+		af := &pb.ArtefactID{ID: ce.artefactid, Domain: ce.domain, Name: ce.name}
+		if !addFileMatches(res, af, ib.branch, ib.build, ib.files, match, max) {
+			break
+		}
+	}
+	return res, nil
+}
+
+// all builds of one artefact, newest first
+func findFilesInArtefact(ctx context.Context, req *pb.FindFilesRequest, match func(string) bool, max uint32) (*pb.FileMatchList, error) {
+	af, err := idstore.ByID(ctx, req.ArtefactID)
+	if err != nil {
+		return nil, err
+	}
+	_, xerr := requestAccess(ctx, af.Name, af.Domain)
+	if xerr != nil {
+		return nil, xerr
+	}
+	branch, err := resolveBranch(ctx, af.Domain, af.Name, req.Branch)
+	if err != nil {
+		return nil, err
+	}
+	builds, err := artefactBuilds(ctx, af, branch)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.FileMatchList{}
+	if len(builds) > *file_search_max_builds {
+		builds = builds[:*file_search_max_builds]
+		res.Truncated = true
+	}
+	for _, b := range builds {
+		files, err := buildFiles(ctx, af, branch, b)
+		if err != nil {
+			return nil, err
+		}
+		if !addFileMatches(res, af, branch, b, files, match, max) {
+			break
+		}
+	}
+	return res, nil
+}
+
+// adds the matching files to res. returns false (and sets Truncated) once res holds max matches
+func addFileMatches(res *pb.FileMatchList, af *pb.ArtefactID, branch string, build uint64, files []string, match func(string) bool, max uint32) bool {
+	for _, f := range files {
+		if !match(f) {
+			continue
+		}
+		if uint32(len(res.Matches)) >= max {
+			res.Truncated = true
+			return false
+		}
+		res.Matches = append(res.Matches, &pb.FileMatch{
+			ArtefactID:    af.ID,
+			Name:          af.Name,
+			Domain:        af.Domain,
+			Branch:        branch,
+			Build:         build,
+			Path:          f,
+			LinkToVersion: DL_PREFIX + linkVersion(af, "branch/"+branch+"/", fmt.Sprintf("%d", build)) + "/" + f,
+		})
+	}
+	return true
+}
+
+func fileMatcher(ctx context.Context, req *pb.FindFilesRequest) (func(string) bool, error) {
+	pattern := strings.TrimSpace(req.Pattern)
+	if pattern == "" {
+		return nil, errors.InvalidArgs(ctx, "missing pattern", "missing pattern")
+	}
+	if req.Regex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.InvalidArgs(ctx, "invalid regular expression", "invalid regular expression \"%s\": %s", pattern, err)
+		}
+		return re.MatchString, nil
+	}
+	_, err := path.Match(pattern, "")
+	if err != nil {
+		return nil, errors.InvalidArgs(ctx, "invalid pattern", "invalid pattern \"%s\": %s", pattern, err)
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		return func(f string) bool {
+			m, _ := path.Match(pattern, path.Base(f))
+			return m
+		}, nil
+	}
+	return func(f string) bool {
+		m, _ := path.Match(pattern, f)
+		return m
+	}, nil
+}
@@ -25,11 +25,14 @@ func (e *artefactServer) GetContents2(ctx context.Context, req *pb.Reference) (*
 		Name:    lr.GetArtefact().Name,
 		Version: lr.ResolvedVersion(ctx),
 		Path:    lr.Path(),
+		Branch:  lr.Branch(),
 	}
+	res.Channels = buildChannels(ctx, lr.GetArtefact().ID, res.Branch, res.Version)
+	res.Build = buildInfo(ctx, lr.GetArtefact().ID, res.Branch, res.Version, knownBuildRecords(ctx, lr.GetArtefact().ID))
 
 	lfr, t, err := brepo.ListFiles(ctx, lr.Domain(), &br.ListFilesRequest{
 		Repository: lr.ArtefactName(),
-		Branch:     "master",
+		Branch:     lr.Branch(),
 		BuildID:    res.Version,
 		Recursive:  false,
 		Dir:        lr.Path(),
@@ -43,6 +46,7 @@ func (e *artefactServer) GetContents2(ctx context.Context, req *pb.Reference) (*
 	if err != nil {
 		return nil, err
 	}
+	fillChecksums(ctx, lr.GetArtefact(), lr.Branch(), res.Version, res.Entries)
 	sortEntries(res)
 	return res, nil
 }
@@ -70,6 +74,7 @@ func filesToEntries(ctx context.Context, lr *LinkReference, files []*br.RepoEntr
 			BuildRepo:        lr.Domain(),
 			RepositoryID:     lr.RepositoryID(),
 			ArtefactID:       lr.GetArtefact(),
+			Branch:           lr.Branch(),
 		}
 		if entry.Type == 1 {
 			c.Type = pb.ContentType_File
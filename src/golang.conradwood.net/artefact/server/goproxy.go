@@ -0,0 +1,310 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	h2g "golang.conradwood.net/apis/h2gproxy"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/errors"
+)
+
+/*
+ serves the go module proxy protocol (GOPROXY) from artefact builds.
+ a module path is mapped to an artefact like so:
+   <host>/<artefactname>[/<subdir>]
+ the artefact is looked up by name, and the domain must be a suffix of the host
+ (e.g. golang.conradwood.net/go-easyops -> artefact "go-easyops" in domain "conradwood.net")
+ the module sources are expected in the build under <goproxy_dir>/<subdir>
+ each build is a version: build N is served as <goproxy_version_prefix>N (e.g. v0.1.N)
+*/
+
+const (
+	GOPROXY_PREFIX = "/goproxy/"
+)
+
+var (
+	goproxy_dir     = flag.String("goproxy_dir", "gomodule", "directory within a build which contains the go module sources")
+	goproxy_version = flag.String("goproxy_version_prefix", "v0.1.", "go module versions are this prefix followed by the buildid")
+)
+
+type goproxy_request struct {
+	module   string // the module path, e.g. golang.conradwood.net/go-easyops
+	query    string // e.g. "list", "latest", "v0.1.5.info"
+	artefact *pb.ArtefactID
+	dir      string // the directory within the build
+	branch   string
+}
+
+func isGoProxyPath(ctx context.Context, path string) bool {
+	_, ok := routedPath(path, GOPROXY_PREFIX)
+	return ok && !isLinkReference(ctx, path)
+}
+
+func (e *artefactServer) goproxy(req *h2g.StreamRequest, srv pb.ArtefactService_StreamHTTPServer) error {
+	ctx := srv.Context()
+	user := auth.GetUser(ctx)
+	gr, err := parseGoProxyPath(ctx, req.Path)
+	if err != nil {
+		fmt.Printf("invalid goproxy request \"%s\": %s\n", req.Path, err)
+		return err
+	}
+	_, err = requestAccess(ctx, gr.artefact.Name, gr.artefact.Domain)
+	if err != nil {
+		fmt.Printf("User #%s (%s) does not have access to module %s\n", user.ID, user.Email, gr.module)
+		return err
+	}
+	debugf("goproxy: module \"%s\", query \"%s\" (artefact #%d)\n", gr.module, gr.query, gr.artefact.ID)
+	if gr.query == "@latest" {
+		glv, err := brepo.GetLatestVersion(ctx, gr.artefact.Domain, &br.GetLatestVersionRequest{Repository: gr.artefact.Name, Branch: gr.branch})
+		if err != nil {
+			return err
+		}
+		build, err := latestUsableBuild(ctx, gr.artefact, gr.branch, glv.BuildID)
+		if err != nil {
+			return err
+		}
+		return sendGoProxyInfo(srv, "latest.info", build)
+	}
+	if gr.query == "list" {
+		builds, err := listBuilds(ctx, gr.artefact)
+		if err != nil {
+			return err
+		}
+		s := ""
+		for _, b := range builds {
+			s = s + goVersion(b) + "\n"
+		}
+		return sendHTTPBytes(srv, "list", "text/plain", []byte(s))
+	}
+	ext := filepath.Ext(gr.query)
+	build, err := goBuildID(ctx, strings.TrimSuffix(gr.query, ext))
+	if err != nil {
+		return err
+	}
+	if ext == ".info" {
+		return sendGoProxyInfo(srv, gr.query, build)
+	}
+	warning, err := checkDownload(ctx, gr.artefact.ID, gr.branch, build)
+	if err != nil {
+		return err
+	}
+	recordDownload(ctx, gr.artefact.ID, gr.branch, build)
+	if ext == ".mod" {
+		fname := gr.dir + "/go.mod"
+		if gr.dir == "" {
+			fname = "go.mod"
+		}
+		buf := &bytes.Buffer{}
+		err = brepo.GetFile(ctx, gr.artefact.Domain, &br.GetFileRequest{
+			File: &br.File{
+				Repository: gr.artefact.Name,
+				Branch:     gr.branch,
+				BuildID:    build,
+				Filename:   fname,
+			},
+			Blocksize: 8192,
+		}, buf)
+		if err != nil {
+			return err
+		}
+		return sendHTTPBytes(srv, "go.mod", "text/plain", buf.Bytes())
+	} else if ext == ".zip" {
+		return sendGoProxyZip(ctx, srv, gr, build, warning)
+	}
+	return errors.NotFound(ctx, "invalid goproxy query \"%s\"", gr.query)
+}
+
+// parse something like /goproxy/golang.conradwood.net/go-easyops/@v/list
+func parseGoProxyPath(ctx context.Context, path string) (*goproxy_request, error) {
+	ref, ok := routedPath(path, GOPROXY_PREFIX)
+	if !ok {
+		return nil, errors.InvalidArgs(ctx, "invalid path in goproxy request", "invalid path in goproxy request: '%s'", path)
+	}
+	res := &goproxy_request{}
+	if strings.HasSuffix(ref, "/@latest") {
+		res.module = strings.TrimSuffix(ref, "/@latest")
+		res.query = "@latest"
+	} else {
+		idx := strings.LastIndex(ref, "/@v/")
+		if idx == -1 {
+			return nil, errors.InvalidArgs(ctx, "invalid path in goproxy request", "no /@v/ in goproxy request: '%s'", path)
+		}
+		res.module = ref[:idx]
+		res.query = ref[idx+4:]
+	}
+	var err error
+	res.module, err = unescapeModulePath(res.module)
+	if err != nil {
+		return nil, errors.InvalidArgs(ctx, "invalid module path", "invalid module path \"%s\": %s", res.module, err)
+	}
+	parts := strings.Split(res.module, "/")
+	if len(parts) < 2 {
+		return nil, errors.NotFound(ctx, "no artefact for module \"%s\"", res.module)
+	}
+	host := parts[0]
+	if len(parts) > 2 {
+		res.dir = strings.Join(parts[2:], "/")
+	}
+	if *goproxy_dir != "" {
+		res.dir = strings.TrimSuffix(*goproxy_dir+"/"+res.dir, "/")
+	}
+	afs, err := idstore.ByName(ctx, parts[1])
+	if err != nil {
+		return nil, err
+	}
+	for _, af := range afs {
+		if host == af.Domain || strings.HasSuffix(host, "."+af.Domain) {
+			res.artefact = af
+			break
+		}
+	}
+	if res.artefact == nil {
+		return nil, errors.NotFound(ctx, "no artefact for module \"%s\"", res.module)
+	}
+	res.branch = defaultBranch(ctx, res.artefact.Domain, res.artefact.Name)
+	return res, nil
+}
+
+// the go proxy protocol encodes uppercase letters as '!' followed by the lowercase letter
+func unescapeModulePath(path string) (string, error) {
+	res := ""
+	bang := false
+	for _, c := range path {
+		if bang {
+			if c < 'a' || c > 'z' {
+				return "", fmt.Errorf("invalid escape sequence")
+			}
+			res = res + strings.ToUpper(string(c))
+			bang = false
+			continue
+		}
+		if c == '!' {
+			bang = true
+			continue
+		}
+		res = res + string(c)
+	}
+	if bang {
+		return "", fmt.Errorf("incomplete escape sequence")
+	}
+	return res, nil
+}
+
+func goVersion(build uint64) string {
+	return fmt.Sprintf("%s%d", *goproxy_version, build)
+}
+
+// convert a go version (e.g. v0.1.5) into a buildid
+func goBuildID(ctx context.Context, version string) (uint64, error) {
+	if !strings.HasPrefix(version, *goproxy_version) {
+		return 0, errors.NotFound(ctx, "version \"%s\" not served by this proxy", version)
+	}
+	b, err := strconv.ParseUint(strings.TrimPrefix(version, *goproxy_version), 10, 64)
+	if err != nil {
+		return 0, errors.NotFound(ctx, "version \"%s\" not served by this proxy", version)
+	}
+	return b, nil
+}
+
+// get all builds of an artefact (in its default branch), sorted in ascending order
+func listBuilds(ctx context.Context, af *pb.ArtefactID) ([]uint64, error) {
+	lvr, err := brepo.ListVersions(ctx, af.Domain, &br.ListVersionsRequest{
+		Repository: af.Name,
+		Branch:     defaultBranch(ctx, af.Domain, af.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res []uint64
+	for _, v := range lvr.Entries {
+		if v.Name == "latest" {
+			continue
+		}
+		b, err := strconv.ParseUint(v.Name, 10, 64)
+		if err != nil {
+			continue
+		}
+		res = append(res, b)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+	return res, nil
+}
+
+func sendGoProxyInfo(srv pb.ArtefactService_StreamHTTPServer, fname string, build uint64) error {
+	info := struct {
+		Version string
+	}{
+		Version: goVersion(build),
+	}
+	b, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return sendHTTPBytes(srv, fname, "application/json", b)
+}
+
+// a zip file, with all files prefixed with module@version/
+// warning, if not empty, is sent as http "Warning" header
+func sendGoProxyZip(ctx context.Context, srv pb.ArtefactService_StreamHTTPServer, gr *goproxy_request, build uint64, warning string) error {
+	lfr, _, err := brepo.ListFiles(ctx, gr.artefact.Domain, &br.ListFilesRequest{
+		Repository: gr.artefact.Name,
+		Branch:     gr.branch,
+		BuildID:    build,
+		Recursive:  true,
+		Dir:        gr.dir,
+	})
+	if err != nil {
+		return err
+	}
+	version := goVersion(build)
+	sr := &h2g.StreamResponse{
+		Filename: version + ".zip",
+		MimeType: "application/zip",
+	}
+	if warning != "" {
+		sr.ExtraHeaders = map[string]string{"Warning": fmt.Sprintf("299 - %q", warning)}
+	}
+	err = srv.Send(&h2g.StreamDataResponse{Response: sr})
+	if err != nil {
+		return err
+	}
+	prefix := gr.module + "@" + version + "/"
+	zw := zip.NewWriter(&httpwriter{srv: srv})
+	for _, entry := range lfr.Entries {
+		if entry.Type != 1 {
+			continue
+		}
+		fname := strings.TrimPrefix(entry.Dir+"/"+entry.Name, "/")
+		rel := strings.TrimPrefix(strings.TrimPrefix(fname, gr.dir), "/")
+		w, err := zw.Create(prefix + rel)
+		if err != nil {
+			return err
+		}
+		err = brepo.GetFile(ctx, gr.artefact.Domain, &br.GetFileRequest{
+			File: &br.File{
+				Repository: gr.artefact.Name,
+				Branch:     gr.branch,
+				BuildID:    build,
+				Filename:   fname,
+			},
+			Blocksize: 8192,
+		}, w)
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
@@ -19,6 +19,12 @@ func get_latest_build(ctx context.Context, repoid uint64) (*pb.LatestBuild, erro
 	if err != nil {
 		return nil, err
 	}
-	res := &pb.LatestBuild{BuildID: lb.ID, UnixTimestamp: lb.Timestamp}
+	res := &pb.LatestBuild{
+		BuildID:       lb.ID,
+		UnixTimestamp: lb.Timestamp,
+		CommitID:      lb.CommitHash,
+		Branch:        lb.Branch,
+		UserID:        lb.UserID,
+	}
 	return res, nil
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	pb "golang.conradwood.net/apis/artefact"
 	br "golang.conradwood.net/apis/buildrepo"
@@ -17,25 +18,25 @@ const (
 	DL_PREFIX  = "/builds/downloads/"
 )
 
+var (
+	name_links = flag.Bool("name_links", false, "if true create links by domain and name (e.g. /artefacts/example.com/foo/latest/dist) instead of by artefactid")
+)
+
 // the struct to refer to a reference (file/repo/or so)
 type LinkReference struct {
 	artefactid uint64
 	version    uint64 // 0->latest
+	branch     string // "" -> default branch of the repository
 	path       string
+	domain     string // set (with name) instead of artefactid by name-based links
+	name       string
+	channel    string // e.g. "stable", resolved to branch and version
 	artefact   *pb.ArtefactID
 	glv        *br.GetLatestVersionResponse
 }
 
 func ParseLinkReference(ctx context.Context, link string) (*LinkReference, error) {
-	strips := []string{URL_PREFIX, DL_PREFIX}
-	ref := ""
-	for _, s := range strips {
-		idx := strings.Index(link, s)
-		if idx != -1 {
-			ref = link[idx+len(s):]
-			break
-		}
-	}
+	ref := linkPath(link)
 	if ref == "" {
 		return nil, errors.InvalidArgs(ctx, "invalid path in linkreference", "invalid path in linkreference: '%s'", link)
 
@@ -46,15 +47,41 @@ func ParseLinkReference(ctx context.Context, link string) (*LinkReference, error
 	if err != nil {
 		return nil, err
 	}
-	if lr.artefactid == 0 {
-		return nil, errors.InvalidArgs(ctx, "invalid path in linkreference", "no repositoryid in path  in linkreference: '%s'", ref)
+	var af *pb.ArtefactID
+	if lr.name != "" {
+		af, err = artefactByName(ctx, lr.domain, lr.name)
+		if err != nil {
+			return nil, err
+		}
+		lr.artefactid = af.ID
+	} else {
+		if lr.artefactid == 0 {
+			return nil, errors.InvalidArgs(ctx, "invalid path in linkreference", "no repositoryid in path  in linkreference: '%s'", ref)
+		}
+		af, err = idstore.ByID(ctx, lr.artefactid)
+		if err != nil {
+			return nil, err
+		}
 	}
-
-	af, err := idstore.ByID(ctx, lr.artefactid)
+	lr.artefact = af
+	if lr.channel != "" {
+		ch, err := artefactChannel(ctx, af.ID, lr.channel)
+		if err != nil {
+			return nil, err
+		}
+		if ch == nil || (lr.branch != "" && lr.branch != ch.Branch) {
+			return nil, errors.NotFound(ctx, "no channel \"%s\" for artefact #%d", lr.channel, af.ID)
+		}
+		lr.branch = ch.Branch
+		lr.version, err = channelBuild(ctx, ch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	lr.branch, err = resolveBranch(ctx, af.Domain, af.Name, lr.branch)
 	if err != nil {
 		return nil, err
 	}
-	lr.artefact = af
 
 	glv, err := brepo.GetLatestVersion(ctx, lr.artefact.Domain, &br.GetLatestVersionRequest{Repository: lr.artefact.Name, Branch: lr.Branch()})
 	if err != nil {
@@ -67,38 +94,87 @@ func ParseLinkReference(ctx context.Context, link string) (*LinkReference, error
 	}
 
 	lr.glv = glv
+	if lr.version == 0 {
+		// "latest" skips yanked builds
+		v, err := latestUsableBuild(ctx, af, lr.branch, glv.BuildID)
+		if err != nil {
+			return nil, err
+		}
+		if v != glv.BuildID {
+			lr.version = v
+		}
+	}
 
 	return lr, nil
 }
 
+// the part of a link after URL_PREFIX or DL_PREFIX, "" if it has neither
+func linkPath(link string) string {
+	strips := []string{URL_PREFIX, DL_PREFIX}
+	for _, s := range strips {
+		idx := strings.Index(link, s)
+		if idx != -1 {
+			return link[idx+len(s):]
+		}
+	}
+	return ""
+}
+
+// true if the link can be parsed as a LinkReference (without resolving it)
+func isLinkReference(ctx context.Context, link string) bool {
+	ref := linkPath(link)
+	if ref == "" {
+		return false
+	}
+	return parseURL(ctx, &LinkReference{}, ref) == nil
+}
+
+// the branch is optional, e.g. artefactid/5/branch/main/version/latest/dist
 func parseURL(ctx context.Context, lr *LinkReference, path string) error {
 	var err error
+	if !strings.HasPrefix(path, "artefactid/") {
+		return parseNameURL(ctx, lr, path)
+	}
 
-	regex := regexp.MustCompile(`artefactid/(\d+)/version/latest/(.*)`)
+	regex := regexp.MustCompile(`^artefactid/(\d+)(/branch/([^/]+))?/version/latest/(.*)`)
 	matches := regex.FindStringSubmatch(path)
 	if len(matches) > 1 {
 		lr.artefactid, _ = strconv.ParseUint(matches[1], 10, 64)
-		lr.path = matches[2]
+		lr.branch = matches[3]
+		lr.path = matches[4]
 		return nil
 	}
 
-	regex = regexp.MustCompile(`artefactid/(\d+)/version/latest`)
+	regex = regexp.MustCompile(`^artefactid/(\d+)(/branch/([^/]+))?/version/latest$`)
 	matches = regex.FindStringSubmatch(path)
 	if len(matches) > 0 {
 		lr.artefactid, _ = strconv.ParseUint(matches[1], 10, 64)
+		lr.branch = matches[3]
 		lr.path = ""
 		return nil
 	}
 
-	regex = regexp.MustCompile(`artefactid/(\d+)/version/(\d+)/(.*)`)
+	regex = regexp.MustCompile(`^artefactid/(\d+)(/branch/([^/]+))?/version/(\d+)/(.*)`)
 	matches = regex.FindStringSubmatch(path)
 	if len(matches) > 2 {
 		lr.artefactid, _ = strconv.ParseUint(matches[1], 10, 64)
-		lr.version, err = strconv.ParseUint(matches[2], 10, 64)
+		lr.branch = matches[3]
+		lr.version, err = strconv.ParseUint(matches[4], 10, 64)
 		if err != nil {
 			return err
 		}
-		lr.path = matches[3]
+		lr.path = matches[5]
+		return nil
+	}
+
+	// a release channel, e.g. artefactid/5/version/stable/dist
+	regex = regexp.MustCompile(`^artefactid/(\d+)(/branch/([^/]+))?/version/([a-z][a-z0-9_-]*)(/(.*))?$`)
+	matches = regex.FindStringSubmatch(path)
+	if len(matches) > 0 {
+		lr.artefactid, _ = strconv.ParseUint(matches[1], 10, 64)
+		lr.branch = matches[3]
+		lr.channel = matches[4]
+		lr.path = matches[6]
 		return nil
 	}
 
@@ -106,14 +182,53 @@ func parseURL(ctx context.Context, lr *LinkReference, path string) error {
 
 }
 
+// domain and name, the branch is optional, e.g. example.com/foo/branch/main/latest/dist, example.com/foo/123/dist
+// or (a release channel) example.com/foo/stable/dist
+func parseNameURL(ctx context.Context, lr *LinkReference, path string) error {
+	regex := regexp.MustCompile(`^([^/]+)/([^/]+)(/branch/([^/]+))?/(latest|\d+|[a-z][a-z0-9_-]*)(/(.*))?$`)
+	matches := regex.FindStringSubmatch(path)
+	if len(matches) == 0 {
+		return errors.InvalidArgs(ctx, "invalid path", "no matches for path: '%s'", path)
+	}
+	lr.domain = matches[1]
+	lr.name = matches[2]
+	lr.branch = matches[4]
+	if channel_name_regex.MatchString(matches[5]) && matches[5] != "latest" {
+		lr.channel = matches[5]
+	} else if matches[5] != "latest" {
+		v, err := strconv.ParseUint(matches[5], 10, 64)
+		if err != nil {
+			return errors.InvalidArgs(ctx, "invalid version", "invalid version \"%s\" in path: '%s'", matches[5], path)
+		}
+		lr.version = v
+	}
+	lr.path = matches[7]
+	return nil
+}
+
+// an existing artefact by domain and name. unlike artefactToID it does not create it
+func artefactByName(ctx context.Context, domain, name string) (*pb.ArtefactID, error) {
+	afs, err := idstore.ByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, af := range afs {
+		if af.Domain == domain {
+			return af, nil
+		}
+	}
+	return nil, errors.NotFound(ctx, "no artefact \"%s\" in domain \"%s\"", name, domain)
+}
+
 // create link to dir
 func createDirLink(af *pb.Contents) {
 	if af.ArtefactID == nil {
 		panic("no artefact id!")
 	}
 	p := af.Path + "/" + af.Name
-	af.LinkToVersion = fmt.Sprintf(URL_PREFIX+"artefactid/%d/version/%d/%s", af.ArtefactID.ID, af.Version, p)
-	af.LinkToLatest = fmt.Sprintf(URL_PREFIX+"artefactid/%d/version/latest/%s", af.ArtefactID.ID, p)
+	af.LinkToVersion = fmt.Sprintf(URL_PREFIX+"%s/%s", linkVersion(af.ArtefactID, linkBranch(af), fmt.Sprintf("%d", af.Version)), p)
+	af.LinkToLatest = fmt.Sprintf(URL_PREFIX+"%s/%s", linkVersion(af.ArtefactID, linkBranch(af), "latest"), p)
+	af.LinkToArchive = fmt.Sprintf(DL_PREFIX+"%s/%s?format=zip", linkVersion(af.ArtefactID, linkBranch(af), fmt.Sprintf("%d", af.Version)), p)
 }
 
 // create link to download a file
@@ -122,8 +237,8 @@ func createFileLink(af *pb.Contents) {
 		panic("no artefact id!")
 	}
 	p := af.Path + "/" + af.Name
-	af.LinkToVersion = fmt.Sprintf(DL_PREFIX+"artefactid/%d/version/%d/%s", af.ArtefactID.ID, af.Version, p)
-	af.LinkToLatest = fmt.Sprintf(DL_PREFIX+"artefactid/%d/version/latest/%s", af.ArtefactID.ID, p)
+	af.LinkToVersion = fmt.Sprintf(DL_PREFIX+"%s/%s", linkVersion(af.ArtefactID, linkBranch(af), fmt.Sprintf("%d", af.Version)), p)
+	af.LinkToLatest = fmt.Sprintf(DL_PREFIX+"%s/%s", linkVersion(af.ArtefactID, linkBranch(af), "latest"), p)
 }
 
 // create link to artefact
@@ -131,18 +246,35 @@ func createArtefactLink(af *pb.Contents) {
 	if af.ArtefactID == nil {
 		panic("no artefact id!")
 	}
-	af.LinkToVersion = fmt.Sprintf(URL_PREFIX+"artefactid/%d/version/%d/%s", af.ArtefactID.ID, af.Version, af.Path)
-	af.LinkToLatest = fmt.Sprintf(URL_PREFIX+"artefactid/%d/version/latest/%s", af.ArtefactID.ID, af.Path)
+	af.LinkToVersion = fmt.Sprintf(URL_PREFIX+"%s/%s", linkVersion(af.ArtefactID, linkBranch(af), fmt.Sprintf("%d", af.Version)), af.Path)
+	af.LinkToLatest = fmt.Sprintf(URL_PREFIX+"%s/%s", linkVersion(af.ArtefactID, linkBranch(af), "latest"), af.Path)
+}
+
+// the part of a link which refers to a version of an artefact, e.g. "artefactid/5/branch/main/version/12"
+// or, with -name_links, "example.com/foo/branch/main/12". branch is the output of linkBranch()
+func linkVersion(afid *pb.ArtefactID, branch string, version string) string {
+	if *name_links && afid.Domain != "" && afid.Name != "" {
+		return fmt.Sprintf("%s/%s/%s%s", afid.Domain, afid.Name, branch, version)
+	}
+	return fmt.Sprintf("artefactid/%d/%sversion/%s", afid.ID, branch, version)
+}
+
+// the branch part of a link, "" if the contents do not specify a branch
+func linkBranch(af *pb.Contents) string {
+	if af.Branch == "" {
+		return ""
+	}
+	return "branch/" + af.Branch + "/"
 }
 
 func (lr *LinkReference) String() string {
-	return fmt.Sprintf("artefactid:%d,name:'%s',domain:'%s',path:'%s'", lr.artefactid, lr.artefact.Name, lr.artefact.Domain, lr.path)
+	return fmt.Sprintf("artefactid:%d,name:'%s',domain:'%s',branch:'%s',path:'%s'", lr.artefactid, lr.artefact.Name, lr.artefact.Domain, lr.branch, lr.path)
 }
 func (lr *LinkReference) GetArtefact() *pb.ArtefactID {
 	return lr.artefact
 }
 func (lr *LinkReference) Branch() string {
-	return "master"
+	return lr.branch
 }
 
 // rather than 0 as "latest" will return the actual latest version
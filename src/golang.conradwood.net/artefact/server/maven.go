@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	h2g "golang.conradwood.net/apis/h2gproxy"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/errors"
+)
+
+/*
+ serves artefacts in a maven2 repository layout:
+   /maven/<groupId as dirs>/<artifactId>/maven-metadata.xml
+   /maven/<groupId as dirs>/<artifactId>/<version>/<artifactId>-<version>[-<classifier>].<ext>
+ plus .sha1 and .md5 sidecars for each of those.
+ the artifactId is the artefact name, the groupId is the reversed domain (optionally followed by more elements),
+ e.g. net.conradwood/foo -> artefact "foo" in domain "conradwood.net"
+ the version is the buildid.
+ within a build the files are expected in <maven_dir>/<artifactId>[-<classifier>].<ext>
+ if a build does not contain a pom, a minimal one is generated.
+*/
+
+const (
+	MAVEN_PREFIX = "/maven/"
+)
+
+var (
+	maven_dir = flag.String("maven_dir", "dist/maven", "directory within a build which contains the maven artefacts")
+)
+
+type maven_request struct {
+	groupid    string
+	artifactid string
+	version    string // empty for maven-metadata.xml
+	filename   string // the requested filename, e.g. foo-123.jar or maven-metadata.xml.sha1
+	artefact   *pb.ArtefactID
+}
+
+type maven_metadata struct {
+	XMLName    xml.Name `xml:"metadata"`
+	GroupID    string   `xml:"groupId"`
+	ArtifactID string   `xml:"artifactId"`
+	Versioning struct {
+		Latest      string   `xml:"latest"`
+		Release     string   `xml:"release"`
+		Versions    []string `xml:"versions>version"`
+		LastUpdated string   `xml:"lastUpdated"`
+	} `xml:"versioning"`
+}
+
+func isMavenPath(ctx context.Context, path string) bool {
+	_, ok := routedPath(path, MAVEN_PREFIX)
+	return ok && !isLinkReference(ctx, path)
+}
+
+func (e *artefactServer) maven(req *h2g.StreamRequest, srv pb.ArtefactService_StreamHTTPServer) error {
+	ctx := srv.Context()
+	user := auth.GetUser(ctx)
+	mr, err := parseMavenPath(ctx, req.Path)
+	if err != nil {
+		fmt.Printf("invalid maven request \"%s\": %s\n", req.Path, err)
+		return err
+	}
+	_, err = requestAccess(ctx, mr.artefact.Name, mr.artefact.Domain)
+	if err != nil {
+		fmt.Printf("User #%s (%s) does not have access to maven artefact %s:%s\n", user.ID, user.Email, mr.groupid, mr.artifactid)
+		return err
+	}
+	debugf("maven: group \"%s\", artifact \"%s\", version \"%s\", file \"%s\"\n", mr.groupid, mr.artifactid, mr.version, mr.filename)
+
+	fname := mr.filename
+	var sum hash.Hash
+	if strings.HasSuffix(fname, ".sha1") {
+		sum = sha1.New()
+		fname = strings.TrimSuffix(fname, ".sha1")
+	} else if strings.HasSuffix(fname, ".md5") {
+		sum = md5.New()
+		fname = strings.TrimSuffix(fname, ".md5")
+	}
+
+	buf := &bytes.Buffer{} // generated content (metadata or pom). files from builds are streamed
+	if mr.version == "" {
+		if fname != "maven-metadata.xml" {
+			return errors.NotFound(ctx, "no such file \"%s\"", mr.filename)
+		}
+		b, err := mavenMetadata(ctx, mr)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	} else {
+		build, err := strconv.ParseUint(mr.version, 10, 64)
+		if err != nil {
+			return errors.NotFound(ctx, "no such version \"%s\"", mr.version)
+		}
+		prefix := mr.artifactid + "-" + mr.version
+		if !strings.HasPrefix(fname, prefix) {
+			return errors.NotFound(ctx, "no such file \"%s\"", mr.filename)
+		}
+		// e.g. "foo.jar" or "foo-sources.jar"
+		file := &br.File{
+			Repository: mr.artefact.Name,
+			Branch:     defaultBranch(ctx, mr.artefact.Domain, mr.artefact.Name),
+			BuildID:    build,
+			Filename:   *maven_dir + "/" + mr.artifactid + strings.TrimPrefix(fname, prefix),
+		}
+		warning, err := checkDownload(ctx, mr.artefact.ID, file.Branch, build)
+		if err != nil {
+			return err
+		}
+		if sum == nil {
+			recordDownload(ctx, mr.artefact.ID, file.Branch, build)
+			err = sendBuildFile(ctx, srv, req, mr.artefact.Domain, file, fname, warning)
+			if err == nil || !strings.HasSuffix(fname, ".pom") {
+				return err
+			}
+			// no pom in the build, generate one
+			return sendHTTPBytes(srv, fname, "text/xml", mavenPom(mr))
+		}
+		// stream the file into the hash, artefacts may be large
+		err = brepo.GetFile(ctx, mr.artefact.Domain, &br.GetFileRequest{File: file, Blocksize: 8192}, sum)
+		if err != nil {
+			if !strings.HasSuffix(fname, ".pom") {
+				return err
+			}
+			sum.Reset()
+			buf.Write(mavenPom(mr))
+		}
+	}
+	if sum == nil {
+		return sendHTTPBytes(srv, fname, "text/xml", buf.Bytes())
+	}
+	sum.Write(buf.Bytes())
+	return sendHTTPBytes(srv, mr.filename, "text/plain", []byte(hex.EncodeToString(sum.Sum(nil))))
+}
+
+// parse something like /maven/net/conradwood/foo/123/foo-123.jar
+func parseMavenPath(ctx context.Context, path string) (*maven_request, error) {
+	ref, ok := routedPath(path, MAVEN_PREFIX)
+	if !ok {
+		return nil, errors.InvalidArgs(ctx, "invalid path in maven request", "invalid path in maven request: '%s'", path)
+	}
+	parts := strings.Split(strings.Trim(ref, "/"), "/")
+	if len(parts) < 3 {
+		return nil, errors.NotFound(ctx, "invalid maven path \"%s\"", path)
+	}
+	res := &maven_request{filename: parts[len(parts)-1]}
+	if strings.HasPrefix(res.filename, "maven-metadata.xml") {
+		res.artifactid = parts[len(parts)-2]
+		res.groupid = strings.Join(parts[:len(parts)-2], ".")
+	} else {
+		if len(parts) < 4 {
+			return nil, errors.NotFound(ctx, "invalid maven path \"%s\"", path)
+		}
+		res.version = parts[len(parts)-2]
+		res.artifactid = parts[len(parts)-3]
+		res.groupid = strings.Join(parts[:len(parts)-3], ".")
+	}
+	afs, err := idstore.ByName(ctx, res.artifactid)
+	if err != nil {
+		return nil, err
+	}
+	for _, af := range afs {
+		rd := reverseDomain(af.Domain)
+		if res.groupid == rd || strings.HasPrefix(res.groupid, rd+".") {
+			res.artefact = af
+			break
+		}
+	}
+	if res.artefact == nil {
+		return nil, errors.NotFound(ctx, "no artefact for %s:%s", res.groupid, res.artifactid)
+	}
+	return res, nil
+}
+
+// conradwood.net -> net.conradwood
+func reverseDomain(domain string) string {
+	parts := strings.Split(domain, ".")
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, ".")
+}
+
+func mavenMetadata(ctx context.Context, mr *maven_request) ([]byte, error) {
+	builds, err := listBuilds(ctx, mr.artefact)
+	if err != nil {
+		return nil, err
+	}
+	md := &maven_metadata{GroupID: mr.groupid, ArtifactID: mr.artifactid}
+	branch := defaultBranch(ctx, mr.artefact.Domain, mr.artefact.Name)
+	for _, b := range builds {
+		md.Versioning.Versions = append(md.Versioning.Versions, fmt.Sprintf("%d", b))
+		// latest skips yanked builds
+		yanked, err := isYanked(ctx, mr.artefact.ID, branch, b)
+		if err != nil {
+			return nil, err
+		}
+		if !yanked {
+			md.Versioning.Latest = fmt.Sprintf("%d", b)
+		}
+	}
+	md.Versioning.Release = md.Versioning.Latest
+	md.Versioning.LastUpdated = time.Now().UTC().Format("20060102150405")
+	b, err := xml.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+func mavenPom(mr *maven_request) []byte {
+	s := xml.Header + `<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>%s</groupId>
+  <artifactId>%s</artifactId>
+  <version>%s</version>
+</project>
+`
+	return []byte(fmt.Sprintf(s, mr.groupid, mr.artifactid, mr.version))
+}
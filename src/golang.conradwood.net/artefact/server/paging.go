@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+
+	"golang.conradwood.net/go-easyops/errors"
+)
+
+// page tokens are the (opaque) sort key of the last item of the previous page
+
+func newPageToken(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// returns "" for the first page
+func parsePageToken(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) == 0 {
+		return "", errors.InvalidArgs(ctx, "invalid page token", "invalid page token \"%s\"", token)
+	}
+	return string(b), nil
+}
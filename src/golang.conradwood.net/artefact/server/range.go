@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strconv"
+	"strings"
+
+	br "golang.conradwood.net/apis/buildrepo"
+	h2g "golang.conradwood.net/apis/h2gproxy"
+)
+
+/*
+ support for http range requests (resumable downloads).
+ only a single range is supported. requests for multiple ranges get the whole file.
+ the buildrepo always streams from the start of the file, so the bytes before the range are skipped here.
+*/
+
+var (
+	errRangeDone = fmt.Errorf("range complete")
+)
+
+type byte_range struct {
+	start uint64
+	end   uint64 // inclusive
+	size  uint64 // the total filesize
+}
+
+// the header value of a request, "" if none
+func getHeader(req *h2g.StreamRequest, name string) string {
+	if req == nil {
+		return ""
+	}
+	for _, h := range req.Headers {
+		if !strings.EqualFold(h.Name, name) {
+			continue
+		}
+		if len(h.Values) == 0 {
+			return ""
+		}
+		return h.Values[0]
+	}
+	return ""
+}
+
+// files in a build never change, so the etag only depends on which file it is
+func etagForFile(file *br.File) string {
+	h := fnv.New64a()
+	h.Write([]byte(fmt.Sprintf("%s/%s/%d/%s", file.Repository, file.Branch, file.BuildID, file.Filename)))
+	return fmt.Sprintf("\"%d-%x\"", file.BuildID, h.Sum64())
+}
+
+// returns nil if the whole file is to be served, or an error if the range is not satisfiable
+func parseRange(req *h2g.StreamRequest, etag string, size uint64) (*byte_range, error) {
+	rh := getHeader(req, "Range")
+	if rh == "" {
+		return nil, nil
+	}
+	// if-range only applies if the file is unchanged. we do not serve last-modified, so dates never match
+	ir := getHeader(req, "If-Range")
+	if ir != "" && ir != etag {
+		return nil, nil
+	}
+	if !strings.HasPrefix(rh, "bytes=") {
+		return nil, nil
+	}
+	spec := strings.TrimSpace(strings.TrimPrefix(rh, "bytes="))
+	if strings.Contains(spec, ",") {
+		return nil, nil
+	}
+	idx := strings.Index(spec, "-")
+	if idx == -1 {
+		return nil, nil
+	}
+	res := &byte_range{size: size}
+	from := strings.TrimSpace(spec[:idx])
+	to := strings.TrimSpace(spec[idx+1:])
+	if from == "" {
+		// suffix, e.g. "-500" (the last 500 bytes)
+		n, err := strconv.ParseUint(to, 10, 64)
+		if err != nil {
+			return nil, nil
+		}
+		if n == 0 || size == 0 {
+			return nil, fmt.Errorf("range \"%s\" not satisfiable", rh)
+		}
+		if n > size {
+			n = size
+		}
+		res.start = size - n
+		res.end = size - 1
+		return res, nil
+	}
+	var err error
+	res.start, err = strconv.ParseUint(from, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+	if res.start >= size {
+		return nil, fmt.Errorf("range \"%s\" not satisfiable (size=%d)", rh, size)
+	}
+	res.end = size - 1
+	if to != "" {
+		res.end, err = strconv.ParseUint(to, 10, 64)
+		if err != nil {
+			return nil, nil
+		}
+		if res.end < res.start {
+			return nil, nil
+		}
+		if res.end >= size {
+			res.end = size - 1
+		}
+	}
+	return res, nil
+}
+
+func (r *byte_range) Length() uint64 {
+	return r.end - r.start + 1
+}
+func (r *byte_range) ContentRange() string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.start, r.end, r.size)
+}
+
+// passes on only the bytes within a range
+type rangewriter struct {
+	target    io.Writer
+	skip      uint64
+	remaining uint64
+}
+
+func newRangeWriter(target io.Writer, r *byte_range) *rangewriter {
+	return &rangewriter{target: target, skip: r.start, remaining: r.Length()}
+}
+
+// returns errRangeDone once the end of the range has been written
+func (rw *rangewriter) Write(buf []byte) (int, error) {
+	n := len(buf)
+	if rw.skip >= uint64(len(buf)) {
+		rw.skip = rw.skip - uint64(len(buf))
+		return n, nil
+	}
+	buf = buf[rw.skip:]
+	rw.skip = 0
+	if uint64(len(buf)) > rw.remaining {
+		buf = buf[:rw.remaining]
+	}
+	if len(buf) > 0 {
+		_, err := rw.target.Write(buf)
+		if err != nil {
+			return 0, err
+		}
+		rw.remaining = rw.remaining - uint64(len(buf))
+	}
+	if rw.remaining == 0 {
+		return n, errRangeDone
+	}
+	return n, nil
+}
@@ -47,7 +47,7 @@ func createArtefactReference(a *pb.Contents) {
 		panic(s)
 	}
 	var err error
-	res := &pb.SerialReference{BuildRepo: a.BuildRepo, RefType: uint32(a.Type), Version: a.Version, Domain: a.Domain}
+	res := &pb.SerialReference{BuildRepo: a.BuildRepo, RefType: uint32(a.Type), Version: a.Version, Domain: a.Domain, Branch: a.Branch}
 	if a.Type == pb.ContentType_Artefact {
 		res.Texts = []string{a.Name}
 		a.ReferenceVersion, a.ReferenceLatest, err = toReference(res)
@@ -78,7 +78,7 @@ func parseReference(ctx context.Context, ref string) (*reference, error) {
 		return nil, err
 	}
 	etype := pb.ContentType(sr.RefType)
-	res := &reference{branch: "master", Type: etype, domain: sr.Domain, buildrepo: sr.BuildRepo}
+	res := &reference{branch: sr.Branch, Type: etype, domain: sr.Domain, buildrepo: sr.BuildRepo}
 	res.version = sr.Version
 	if etype == pb.ContentType_Artefact {
 		if len(sr.Texts) != 1 {
@@ -99,15 +99,18 @@ func parseReference(ctx context.Context, ref string) (*reference, error) {
 	if res.domain == "" {
 		return nil, errors.InvalidArgs(ctx, "reference has no domain", "reference for %s is missing a domain", res.repository)
 	}
+	res.branch, err = resolveBranch(ctx, res.domain, res.repository, res.branch)
+	if err != nil {
+		return nil, err
+	}
 
 	// get latest
 	if res.version != 0 {
 		res.resolvedVersion = res.version
 	} else {
-		b := brepo.GetBuildRepoManagerClient(res.repository, res.domain)
-		glv, err := b.GetLatestVersion(ctx, &br.GetLatestVersionRequest{
+		glv, err := brepo.GetLatestVersion(ctx, res.domain, &br.GetLatestVersionRequest{
 			Repository: res.Repository(),
-			Branch:     "master",
+			Branch:     res.Branch(),
 		})
 		if err != nil {
 			return nil, errors.InvalidArgs(ctx, "invalid reference (4)", "reference, rejected by buildrepo (%s) (ref: %s)", err, res.String())
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/artefact/db"
+	"golang.conradwood.net/go-easyops/authremote"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ an index repositoryid -> artefactid, stored in the database.
+ it is updated whenever we learn about a mapping and periodically by a background indexer.
+ lookups of repositories which are not indexed yet trigger a background re-index (at most once per -repo_reindex_min_interval)
+*/
+
+var (
+	repo_index_interval       = flag.Duration("repo_index_interval", time.Duration(60)*time.Minute, "how often to (re-)index repositories to artefacts. 0 to disable")
+	repo_reindex_min_interval = flag.Duration("repo_reindex_min_interval", time.Duration(5)*time.Minute, "minimum time between re-indexes triggered by lookups of unknown repositories")
+	reindex_lock              sync.Mutex
+	reindex_running           bool
+	reindex_last              time.Time
+)
+
+type artefact_repo_cache_entry struct {
+	artefactid uint64
+}
+
+// look up the artefact for a repository in the index. returns 0 if none is indexed
+func artefactForRepoFromIndex(ctx context.Context, repoid uint64) (uint64, error) {
+	key := fmt.Sprintf("%d", repoid)
+	o := artefact_repo_cache.Get(key)
+	if o != nil {
+		return (o.(*artefact_repo_cache_entry)).artefactid, nil
+	}
+	ras, err := db.DefaultDBRepoArtefact().ByRepositoryID(ctx, repoid)
+	if err != nil {
+		return 0, err
+	}
+	if len(ras) == 0 {
+		return 0, nil
+	}
+	artefact_repo_cache.Put(key, &artefact_repo_cache_entry{artefactid: ras[0].ArtefactID})
+	return ras[0].ArtefactID, nil
+}
+
+// record that a repository feeds an artefact
+func indexRepoArtefact(ctx context.Context, repoid uint64, artefactid uint64) error {
+	if repoid == 0 || artefactid == 0 {
+		return nil
+	}
+	key := fmt.Sprintf("%d", repoid)
+	o := artefact_repo_cache.Get(key)
+	if o != nil && (o.(*artefact_repo_cache_entry)).artefactid == artefactid {
+		return nil
+	}
+	now := uint32(time.Now().Unix())
+	ras, err := db.DefaultDBRepoArtefact().ByRepositoryID(ctx, repoid)
+	if err != nil {
+		return err
+	}
+	if len(ras) == 0 {
+		_, err = db.DefaultDBRepoArtefact().Save(ctx, &pb.RepoArtefact{RepositoryID: repoid, ArtefactID: artefactid, Updated: now})
+		if err != nil {
+			return err
+		}
+		debugf("indexed repository #%d -> artefact #%d\n", repoid, artefactid)
+	} else {
+		ra := ras[0]
+		if ra.ArtefactID != artefactid {
+			fmt.Printf("Repository #%d moved from artefact #%d to artefact #%d\n", repoid, ra.ArtefactID, artefactid)
+		}
+		ra.ArtefactID = artefactid
+		ra.Updated = now
+		err = db.DefaultDBRepoArtefact().Update(ctx, ra)
+		if err != nil {
+			return err
+		}
+	}
+	artefact_repo_cache.Put(key, &artefact_repo_cache_entry{artefactid: artefactid})
+	return nil
+}
+
+// same as indexRepoArtefact, but only logs errors
+func tryIndexRepoArtefact(ctx context.Context, repoid uint64, artefactid uint64) {
+	err := indexRepoArtefact(ctx, repoid, artefactid)
+	if err != nil {
+		fmt.Printf("failed to index repository #%d -> artefact #%d: %s\n", repoid, artefactid, utils.ErrorString(err))
+	}
+}
+
+// a repository was not found in the index. starts a (background) refresh of the catalogue and the index,
+// unless one is running or was started within -repo_reindex_min_interval
+func triggerReindex() {
+	reindex_lock.Lock()
+	if reindex_running || time.Since(reindex_last) < *repo_reindex_min_interval {
+		reindex_lock.Unlock()
+		return
+	}
+	reindex_running = true
+	reindex_last = time.Now()
+	reindex_lock.Unlock()
+	go func() {
+		defer func() {
+			reindex_lock.Lock()
+			reindex_running = false
+			reindex_lock.Unlock()
+		}()
+		catalogue_refresh_lock.Lock()
+		_, err := buildCatalogue()
+		catalogue_refresh_lock.Unlock()
+		if err == nil {
+			err = index_all_repos()
+		}
+		if err != nil {
+			fmt.Printf("failed to re-index repositories: %s\n", utils.ErrorString(err))
+		}
+	}()
+}
+
+func repo_indexer() {
+	if *repo_index_interval == 0 {
+		return
+	}
+	for {
+		err := index_all_repos()
+		if err != nil {
+			fmt.Printf("failed to index repositories: %s\n", utils.ErrorString(err))
+		}
+		time.Sleep(*repo_index_interval)
+	}
+}
+
+// index the repositories in the catalogue
+func index_all_repos() error {
+	started := time.Now()
+	c, err := getCatalogue()
+	if err != nil {
+		return err
+	}
+	indexed := 0
+	for _, ce := range c.entries {
+		if ce.repositoryid == 0 {
+			continue
+		}
+		err = indexRepoArtefact(authremote.Context(), ce.repositoryid, ce.artefactid)
+		if err != nil {
+			return err
+		}
+		indexed++
+	}
+	fmt.Printf("Indexed %d of %d repositories in %0.1fs\n", indexed, len(c.entries), time.Since(started).Seconds())
+	return nil
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/artefact/db"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/authremote"
+	"golang.conradwood.net/go-easyops/cache"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+ retention rules decide which old builds of an artefact may be deleted. PlanRetention reports what a rule would delete.
+ the executor is off by default (-retention_interval) and only logs what it would delete unless -retention_dry_run=false.
+ builds which were not seen by the catalogue have no creation time. they are assumed to be as young as the
+ next newer build with a known creation time (builds are numbered in order), so that they are not deleted too early.
+ the builds "latest" and the channels resolve to (which differ from the newest and the pinned build if those are yanked)
+ are always kept. domains whose buildrepos cannot delete builds are skipped by the executor.
+*/
+
+var (
+	retention_interval = flag.Duration("retention_interval", 0, "how often to delete builds according to the retention rules. 0 (default) disables it")
+	retention_dry_run  = flag.Bool("retention_dry_run", true, "if true, the retention executor only logs which builds it would delete")
+	buildsize_cache    = cache.New("buildsize", time.Duration(24)*time.Hour, 5000)
+)
+
+type buildsize_cache_entry struct {
+	size uint64
+}
+
+func (e *artefactServer) SetRetentionRule(ctx context.Context, req *pb.RetentionRule) (*pb.RetentionRule, error) {
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return nil, errors.Unauthenticated(ctx, "need user account to set retention rules")
+	}
+	af, err := idstore.ByID(ctx, req.ArtefactID)
+	if err != nil {
+		return nil, err
+	}
+	err = requestAdminAccess(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := retentionRule(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	if req.KeepLast == 0 && req.KeepDays == 0 && req.KeepDownloadedDays == 0 {
+		if rule != nil {
+			err = db.DefaultDBRetentionRule().DeleteByID(ctx, rule.ID)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Printf("User %s removed the retention rule of artefact #%d (%s)\n", auth.Description(u), af.ID, af.Name)
+		}
+		return &pb.RetentionRule{ArtefactID: af.ID}, nil
+	}
+	if rule == nil {
+		rule = &pb.RetentionRule{ArtefactID: af.ID}
+	}
+	rule.KeepLast = req.KeepLast
+	rule.KeepDays = req.KeepDays
+	rule.KeepDownloadedDays = req.KeepDownloadedDays
+	rule.UpdatedBy = u.ID
+	rule.Updated = uint32(time.Now().Unix())
+	if rule.ID == 0 {
+		_, err = db.DefaultDBRetentionRule().Save(ctx, rule)
+	} else {
+		err = db.DefaultDBRetentionRule().Update(ctx, rule)
+	}
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("User %s set the retention rule of artefact #%d (%s): keep last %d, %d days, downloaded within %d days\n", auth.Description(u), af.ID, af.Name, rule.KeepLast, rule.KeepDays, rule.KeepDownloadedDays)
+	return rule, nil
+}
+
+func (e *artefactServer) PlanRetention(ctx context.Context, req *pb.ID) (*pb.RetentionPlan, error) {
+	af, err := idstore.ByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	_, xerr := requestAccess(ctx, af.Name, af.Domain)
+	if xerr != nil {
+		return nil, xerr
+	}
+	rule, err := retentionRule(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	return planRetention(ctx, af, rule)
+}
+
+// nil if the artefact has none
+func retentionRule(ctx context.Context, artefactid uint64) (*pb.RetentionRule, error) {
+	rules, err := db.DefaultDBRetentionRule().ByArtefactID(ctx, artefactid)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return rules[0], nil
+}
+
+func planRetention(ctx context.Context, af *pb.ArtefactID, rule *pb.RetentionRule) (*pb.RetentionPlan, error) {
+	res := &pb.RetentionPlan{ArtefactID: af.ID, Rule: rule, CannotDelete: !brepo.CanDeleteBuilds(af.Domain)}
+	lbr, err := brepo.ListBranches(ctx, af.Domain, &br.ListBranchesRequest{Repository: af.Name})
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		// keep everything
+		for _, branch := range lbr.Branches {
+			builds, err := artefactBuilds(ctx, af, branch)
+			if err != nil {
+				return nil, err
+			}
+			res.KeptBuilds = res.KeptBuilds + uint32(len(builds))
+		}
+		return res, nil
+	}
+	chs, err := allChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := buildRecords(ctx, af.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := uint32(time.Now().Unix())
+	keepLast := rule.KeepLast
+	if keepLast == 0 {
+		keepLast = 1 // never delete the latest build
+	}
+	for _, branch := range lbr.Branches {
+		builds, err := artefactBuilds(ctx, af, branch) // newest first
+		if err != nil {
+			return nil, err
+		}
+		pinned, err := resolvedBuilds(ctx, af, branch, builds, chs[af.ID])
+		if err != nil {
+			return nil, err
+		}
+		created := now // creation time of the next newer build with a known creation time
+		for i, b := range builds {
+			rec := records[buildMarkKey(af.ID, branch, b)]
+			if rec != nil && rec.Created != 0 && rec.Created < created {
+				created = rec.Created
+			}
+			keep := uint32(i) < keepLast || pinned[b]
+			if rule.KeepDays != 0 && now-created < rule.KeepDays*24*60*60 {
+				keep = true
+			}
+			if rule.KeepDownloadedDays != 0 && rec != nil && rec.LastDownload != 0 && now-rec.LastDownload < rule.KeepDownloadedDays*24*60*60 {
+				keep = true
+			}
+			if keep {
+				res.KeptBuilds++
+				continue
+			}
+			pd := &pb.PlannedDeletion{Branch: branch, BuildID: b}
+			if rec != nil {
+				pd.Created = rec.Created
+				pd.LastDownload = rec.LastDownload
+			}
+			pd.Bytes, err = buildSize(ctx, af, branch, b)
+			if err != nil {
+				fmt.Printf("failed to get size of build %d of artefact #%d: %s\n", b, af.ID, utils.ErrorString(err))
+			}
+			if !res.CannotDelete {
+				res.BytesSaved = res.BytesSaved + pd.Bytes
+			}
+			res.Deletions = append(res.Deletions, pd)
+		}
+	}
+	return res, nil
+}
+
+// the builds which "latest" and the channels of a branch pin and resolve to
+func resolvedBuilds(ctx context.Context, af *pb.ArtefactID, branch string, builds []uint64, chs []*pb.Channel) (map[uint64]bool, error) {
+	res := make(map[uint64]bool)
+	if len(builds) > 0 {
+		b, err := latestUsableBuild(ctx, af, branch, builds[0])
+		if err == nil {
+			res[b] = true
+		} else if status.Code(err) != codes.NotFound { // all yanked
+			return nil, err
+		}
+	}
+	for _, ch := range chs {
+		if ch.Branch != branch {
+			continue
+		}
+		res[ch.BuildID] = true
+		b, err := channelBuild(ctx, ch)
+		if err == nil {
+			res[b] = true
+		} else if status.Code(err) != codes.NotFound { // all yanked
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// total size of all files in a build
+func buildSize(ctx context.Context, af *pb.ArtefactID, branch string, build uint64) (uint64, error) {
+	key := buildMarkKey(af.ID, branch, build)
+	o := buildsize_cache.Get(key)
+	if o != nil {
+		return (o.(*buildsize_cache_entry)).size, nil
+	}
+	files, err := buildFiles(ctx, af, branch, build)
+	if err != nil {
+		return 0, err
+	}
+	size := uint64(0)
+	for _, f := range files {
+		gmr, err := brepo.GetFileMetaData(ctx, af.Domain, &br.GetMetaRequest{File: &br.File{
+			Repository: af.Name,
+			Branch:     branch,
+			BuildID:    build,
+			Filename:   f,
+		}})
+		if err != nil {
+			return 0, err
+		}
+		size = size + gmr.Size
+	}
+	buildsize_cache.Put(key, &buildsize_cache_entry{size: size})
+	return size, nil
+}
+
+func retention_executor() {
+	if *retention_interval == 0 {
+		return
+	}
+	for {
+		err := applyRetentionRules()
+		if err != nil {
+			fmt.Printf("failed to apply retention rules: %s\n", utils.ErrorString(err))
+		}
+		time.Sleep(*retention_interval)
+	}
+}
+
+func applyRetentionRules() error {
+	ctx := authremote.Context()
+	rules, err := db.DefaultDBRetentionRule().All(ctx)
+	if err != nil {
+		return err
+	}
+	skipped := make(map[string]bool) // domains which cannot delete builds, logged once per run
+	for _, rule := range rules {
+		ctx := authremote.Context()
+		af, err := idstore.ByID(ctx, rule.ArtefactID)
+		if err != nil {
+			fmt.Printf("retention rule #%d: %s\n", rule.ID, utils.ErrorString(err))
+			continue
+		}
+		plan, err := planRetention(ctx, af, rule)
+		if err != nil {
+			fmt.Printf("failed to plan retention of artefact #%d (%s): %s\n", af.ID, af.Name, utils.ErrorString(err))
+			continue
+		}
+		if plan.CannotDelete && !*retention_dry_run {
+			if !skipped[af.Domain] {
+				fmt.Printf("buildrepos of domain \"%s\" cannot delete builds, skipping its retention rules\n", af.Domain)
+				skipped[af.Domain] = true
+			}
+			continue
+		}
+		for _, pd := range plan.Deletions {
+			if *retention_dry_run {
+				fmt.Printf("[dry-run] would delete build %d (branch %s, %d bytes) of artefact #%d (%s)\n", pd.BuildID, pd.Branch, pd.Bytes, af.ID, af.Name)
+				continue
+			}
+			err = deleteBuild(ctx, af, pd.Branch, pd.BuildID)
+			if err != nil {
+				fmt.Printf("failed to delete build %d (branch %s) of artefact #%d (%s): %s\n", pd.BuildID, pd.Branch, af.ID, af.Name, utils.ErrorString(err))
+				continue
+			}
+			fmt.Printf("Deleted build %d (branch %s, %d bytes) of artefact #%d (%s)\n", pd.BuildID, pd.Branch, pd.Bytes, af.ID, af.Name)
+		}
+	}
+	return nil
+}
+
+func deleteBuild(ctx context.Context, af *pb.ArtefactID, branch string, build uint64) error {
+	err := brepo.DeleteBuild(ctx, af.Domain, af.Name, branch, build)
+	if err != nil {
+		return err
+	}
+	buildlist_cache.Evict(fmt.Sprintf("%d/%s", af.ID, branch))
+	records, err := buildRecords(ctx, af.ID)
+	if err != nil {
+		return err
+	}
+	rec := records[buildMarkKey(af.ID, branch, build)]
+	if rec != nil {
+		err = db.DefaultDBBuildRecord().DeleteByID(ctx, rec.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+/*
+ relevance of an artefact for a search term. higher is better, 0 means "no match".
+ exact name matches rank first, then prefix matches, then matches of a token in the name
+ (e.g. "server" in "artefact-server"), then substring matches, then matches in url or domain
+ and finally similar names (by trigrams or edit distance).
+*/
+
+const (
+	SCORE_EXACT     = 100
+	SCORE_PREFIX    = 80
+	SCORE_TOKEN     = 70
+	SCORE_CONTAINS  = 50
+	SCORE_URL       = 40
+	SCORE_DOMAIN    = 30
+	SCORE_SIMILAR   = 25 // the maximum for similar names
+	MIN_SIMILARITY  = 0.4
+	MAX_TERM_LENGTH = 100
+)
+
+func searchScore(term string, ce *catalogue_entry) float64 {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if len(term) > MAX_TERM_LENGTH {
+		term = term[:MAX_TERM_LENGTH]
+	}
+	name := strings.ToLower(ce.name)
+	if name == term {
+		return SCORE_EXACT
+	}
+	if strings.HasPrefix(name, term) {
+		// shorter names are closer to an exact match
+		return SCORE_PREFIX + 10*float64(len(term))/float64(len(name))
+	}
+	for _, t := range nameTokens(name) {
+		if t == term {
+			return SCORE_TOKEN + 5
+		}
+		if strings.HasPrefix(t, term) {
+			return SCORE_TOKEN
+		}
+	}
+	cterm := strings.ToLower(charsOnly(term))
+	if cterm == "" {
+		return 0
+	}
+	if strings.Contains(strings.ToLower(charsOnly(name)), cterm) {
+		return SCORE_CONTAINS
+	}
+	if ce.url != "" && strings.Contains(strings.ToLower(ce.url), term) {
+		return SCORE_URL
+	}
+	if strings.Contains(strings.ToLower(ce.domain), term) {
+		return SCORE_DOMAIN
+	}
+	sim := similarity(cterm, strings.ToLower(charsOnly(name)))
+	if sim < MIN_SIMILARITY {
+		return 0
+	}
+	return SCORE_SIMILAR * sim
+}
+
+// "go-easyops_server" -> [go easyops server]
+func nameTokens(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// 0..1, the better of trigram similarity and edit distance
+func similarity(a, b string) float64 {
+	ts := trigramSimilarity(a, b)
+	maxlen := len(a)
+	if len(b) > maxlen {
+		maxlen = len(b)
+	}
+	if maxlen == 0 {
+		return 0
+	}
+	ls := 1 - float64(levenshtein(a, b))/float64(maxlen)
+	if ls > ts {
+		return ls
+	}
+	return ts
+}
+
+// jaccard index of the trigrams of a and b
+func trigramSimilarity(a, b string) float64 {
+	ta := trigrams(a)
+	tb := trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range ta {
+		if tb[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+func trigrams(s string) map[string]bool {
+	s = "  " + s + " "
+	res := make(map[string]bool)
+	for i := 0; i+3 <= len(s); i++ {
+		res[s[i:i+3]] = true
+	}
+	return res
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -2,14 +2,60 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	pb "golang.conradwood.net/apis/artefact"
 	"golang.conradwood.net/apis/common"
+	"golang.conradwood.net/apis/objectauth"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/errors"
 )
 
 func (e *artefactServer) SetAccess(ctx context.Context, req *pb.SetAccessRequest) (*common.Void, error) {
+	if req.Target == nil || req.Target.Reference == "" {
+		return nil, errors.InvalidArgs(ctx, "missing target", "no target to set access on")
+	}
+	if req.UserID == "" {
+		return nil, errors.InvalidArgs(ctx, "missing userid", "no user to set access for")
+	}
+	afid, err := referenceToArtefactID(ctx, req.Target.Reference)
+	if err != nil {
+		return nil, err
+	}
+	err = requestAdminAccess(ctx, afid)
+	if err != nil {
+		return nil, err
+	}
+	u := auth.GetUser(ctx)
+	fmt.Printf("User %s sets access for user #%s on artefact #%d to %v\n", auth.Description(u), req.UserID, afid, req.Grant)
+	gur := &objectauth.GrantUserRequest{
+		ObjectType: objectauth.OBJECTTYPE_Artefact,
+		ObjectID:   afid,
+		UserID:     req.UserID,
+		Read:       req.Grant,
+		View:       req.Grant,
+	}
+	_, err = objectauth.GetObjectAuthServiceClient().GrantToUser(ctx, gur)
+	if err != nil {
+		return nil, err
+	}
+	invalidate_perm_cache(req.UserID, afid)
 	return &common.Void{}, nil
 }
 
-
-
-
+// resolve a reference (either a serialised reference or a link reference) to an artefactid
+func referenceToArtefactID(ctx context.Context, r string) (uint64, error) {
+	if strings.HasPrefix(r, "/") {
+		lr, err := ParseLinkReference(ctx, r)
+		if err != nil {
+			return 0, err
+		}
+		return lr.GetArtefact().ID, nil
+	}
+	ref, err := parseReference(ctx, r)
+	if err != nil {
+		return 0, err
+	}
+	return artefactToID(ref.Repository(), ref.domain)
+}
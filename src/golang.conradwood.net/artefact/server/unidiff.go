@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+ unified diffs (like diff -u) of small text files. lines are compared with a longest common subsequence
+ after common leading and trailing lines are removed.
+*/
+
+const (
+	DIFF_CONTEXT   = 3
+	DIFF_MAX_CELLS = 4000000 // maximum size of the lcs table, larger changes are not diffed
+	// appended to the last line of a text without a trailing newline. the line then differs from the same line with a newline
+	DIFF_NO_EOL = "\n\\ No newline at end of file"
+)
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// unified diff of two texts. "" if they are equal or too different to diff.
+// labels are the file names in the header, e.g. "a/foo.yaml" and "b/foo.yaml"
+func unifiedDiff(labelA, labelB string, a, b string) string {
+	ops, ok := diffLines(splitLines(a), splitLines(b))
+	if !ok {
+		return ""
+	}
+	hunks := unifiedHunks(ops)
+	if hunks == "" {
+		return ""
+	}
+	return "--- " + labelA + "\n+++ " + labelB + "\n" + hunks
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	if strings.HasSuffix(s, "\n") {
+		return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	}
+	res := strings.Split(s, "\n")
+	res[len(res)-1] = res[len(res)-1] + DIFF_NO_EOL
+	return res
+}
+
+// the edit script turning a into b. false if the changed part is too large
+func diffLines(a, b []string) ([]diffOp, bool) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma := a[prefix : len(a)-suffix]
+	mb := b[prefix : len(b)-suffix]
+	if (len(ma)+1)*(len(mb)+1) > DIFF_MAX_CELLS {
+		return nil, false
+	}
+	var res []diffOp
+	for _, l := range a[:prefix] {
+		res = append(res, diffOp{kind: ' ', text: l})
+	}
+	// lcs[i][j] is the length of the longest common subsequence of ma[i:] and mb[j:]
+	w := len(mb) + 1
+	lcs := make([]int32, (len(ma)+1)*w)
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			} else if lcs[(i+1)*w+j] >= lcs[i*w+j+1] {
+				lcs[i*w+j] = lcs[(i+1)*w+j]
+			} else {
+				lcs[i*w+j] = lcs[i*w+j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		if i < len(ma) && j < len(mb) && ma[i] == mb[j] {
+			res = append(res, diffOp{kind: ' ', text: ma[i]})
+			i++
+			j++
+		} else if j == len(mb) || (i < len(ma) && lcs[(i+1)*w+j] >= lcs[i*w+j+1]) {
+			res = append(res, diffOp{kind: '-', text: ma[i]})
+			i++
+		} else {
+			res = append(res, diffOp{kind: '+', text: mb[j]})
+			j++
+		}
+	}
+	for _, l := range a[len(a)-suffix:] {
+		res = append(res, diffOp{kind: ' ', text: l})
+	}
+	return res, true
+}
+
+// the hunks ("@@ -1,4 +1,5 @@" and lines) of an edit script, "" if there are no changes
+func unifiedHunks(ops []diffOp) string {
+	// line numbers in a and b before each op
+	apos := make([]int, len(ops)+1)
+	bpos := make([]int, len(ops)+1)
+	for k, op := range ops {
+		apos[k+1] = apos[k]
+		bpos[k+1] = bpos[k]
+		if op.kind != '+' {
+			apos[k+1]++
+		}
+		if op.kind != '-' {
+			bpos[k+1]++
+		}
+	}
+	var sb strings.Builder
+	i := 0
+	for i < len(ops) {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := i - DIFF_CONTEXT
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			r := end
+			for r < len(ops) && ops[r].kind == ' ' {
+				r++
+			}
+			if r == len(ops) || r-end > 2*DIFF_CONTEXT {
+				end = end + DIFF_CONTEXT
+				if end > len(ops) {
+					end = len(ops)
+				}
+				break
+			}
+			end = r
+		}
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(apos[start], apos[end]), hunkRange(bpos[start], bpos[end])))
+		for _, op := range ops[start:end] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.text)
+			sb.WriteByte('\n')
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// "start,length" with 1-based start. an empty range starts at the line before it
+func hunkRange(from, to int) string {
+	if from == to {
+		return fmt.Sprintf("%d,0", from)
+	}
+	return fmt.Sprintf("%d,%d", from+1, to-from)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// lines 1..n, with the replacements
+func numberedLines(n int, replace map[int]string) string {
+	var sb strings.Builder
+	for i := 1; i <= n; i++ {
+		l := fmt.Sprintf("%d", i)
+		if r, ok := replace[i]; ok {
+			l = r
+		}
+		sb.WriteString(l + "\n")
+	}
+	return sb.String()
+}
+
+// expected results were produced with diff -u
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		labelA string
+		a, b   string
+		diff   string
+	}{
+		{"equal", "a/f", "1\n2\n", "1\n2\n", ""},
+		{"first line", "a/f", numberedLines(5, nil), numberedLines(5, map[int]string{1: "x"}),
+			"@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n"},
+		{"last line", "a/f", numberedLines(5, nil), numberedLines(5, map[int]string{5: "x"}),
+			"@@ -2,4 +2,4 @@\n 2\n 3\n 4\n-5\n+x\n"},
+		{"merged hunks", "a/f", numberedLines(20, nil), numberedLines(20, map[int]string{3: "x", 10: "y"}),
+			"@@ -1,13 +1,13 @@\n 1\n 2\n-3\n+x\n 4\n 5\n 6\n 7\n 8\n 9\n-10\n+y\n 11\n 12\n 13\n"},
+		{"split hunks", "a/f", numberedLines(20, nil), numberedLines(20, map[int]string{3: "x", 11: "y"}),
+			"@@ -1,6 +1,6 @@\n 1\n 2\n-3\n+x\n 4\n 5\n 6\n@@ -8,7 +8,7 @@\n 8\n 9\n 10\n-11\n+y\n 12\n 13\n 14\n"},
+		{"insertion", "a/f", "1\n2\n3\n", "1\n2\nx\n3\n",
+			"@@ -1,3 +1,4 @@\n 1\n 2\n+x\n 3\n"},
+		{"added file", "/dev/null", "", "a\nb\n",
+			"@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"removed file", "a/f", "a\nb\n", "",
+			"@@ -1,2 +0,0 @@\n-a\n-b\n"},
+		{"newline added", "a/f", "1\n2\n3", "1\n2\n3\n",
+			"@@ -1,3 +1,3 @@\n 1\n 2\n-3\n\\ No newline at end of file\n+3\n"},
+		{"newline removed", "a/f", "1\n2\n3\n", "1\n2\n3",
+			"@@ -1,3 +1,3 @@\n 1\n 2\n-3\n+3\n\\ No newline at end of file\n"},
+		{"added file without newline", "/dev/null", "", "a\nb",
+			"@@ -0,0 +1,2 @@\n+a\n+b\n\\ No newline at end of file\n"},
+	}
+	for _, tt := range tests {
+		labelB := "b/f"
+		if tt.b == "" {
+			labelB = "/dev/null"
+		}
+		expected := ""
+		if tt.diff != "" {
+			expected = "--- " + tt.labelA + "\n+++ " + labelB + "\n" + tt.diff
+		}
+		got := unifiedDiff(tt.labelA, labelB, tt.a, tt.b)
+		if got != expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tt.name, expected, got)
+		}
+	}
+}
+
+func TestDiffMaxCells(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 2001; i++ {
+		a = append(a, fmt.Sprintf("a%d", i))
+		b = append(b, fmt.Sprintf("b%d", i))
+	}
+	if _, ok := diffLines(a, b); ok {
+		t.Errorf("diffed %d cells, more than %d", (len(a)+1)*(len(b)+1), DIFF_MAX_CELLS)
+	}
+	if d := unifiedDiff("a/f", "b/f", strings.Join(a, "\n"), strings.Join(b, "\n")); d != "" {
+		t.Errorf("expected no diff, got %d bytes", len(d))
+	}
+	// common leading and trailing lines do not count
+	a = append([]string{"x"}, a...)
+	c := append([]string{"y"}, a[1:]...)
+	if _, ok := diffLines(a, c); !ok {
+		t.Errorf("a single changed line was not diffed")
+	}
+}
+
+func TestHunkRange(t *testing.T) {
+	for _, tt := range []struct {
+		from, to int
+		res      string
+	}{{0, 0, "0,0"}, {0, 2, "1,2"}, {4, 4, "4,0"}, {7, 14, "8,7"}} {
+		if r := hunkRange(tt.from, tt.to); r != tt.res {
+			t.Errorf("hunkRange(%d,%d)=%s, expected %s", tt.from, tt.to, r, tt.res)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	br "golang.conradwood.net/apis/buildrepo"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/authremote"
+	"golang.conradwood.net/go-easyops/errors"
+	"golang.conradwood.net/go-easyops/utils"
+)
+
+/*
+ watchers get an event whenever the latest build of an artefact changes.
+ a single poller checks the latest builds of all artefacts that are watched by at least one watcher
+ or have a webhook.
+*/
+
+var (
+	watch_poll_interval = flag.Duration("watch_poll_interval", time.Duration(30)*time.Second, "how often to check for new builds of watched artefacts")
+	watchers            = make(map[*watcher]bool)
+	watchlock           sync.Mutex
+	watch_poller_once   sync.Once
+)
+
+type watcher struct {
+	ctx    context.Context
+	all    bool // watch all artefacts (with access)
+	ids    map[uint64]bool
+	domain string
+	events chan *pb.BuildEvent
+}
+
+// state of the poller, only used by the poller goroutine
+type watch_poller struct {
+	latest    map[uint64]uint64 // artefactid -> buildid
+	artefacts map[uint64]*pb.ArtefactID
+}
+
+func (e *artefactServer) WatchArtefacts(req *pb.WatchRequest, srv pb.ArtefactService_WatchArtefactsServer) error {
+	ctx := srv.Context()
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return errors.Unauthenticated(ctx, "need user account to watch artefacts")
+	}
+	w := &watcher{
+		ctx:    ctx,
+		all:    len(req.ArtefactIDs) == 0,
+		ids:    make(map[uint64]bool),
+		domain: req.Domain,
+		events: make(chan *pb.BuildEvent, 100),
+	}
+	for _, id := range req.ArtefactIDs {
+		af, err := idstore.ByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		_, err = requestAccess(ctx, af.Name, af.Domain)
+		if err != nil {
+			return err
+		}
+		w.ids[id] = true
+	}
+	startBuildPoller()
+	watchlock.Lock()
+	watchers[w] = true
+	fmt.Printf("User %s watching %d artefacts (all=%v), %d watchers\n", auth.Description(u), len(w.ids), w.all, len(watchers))
+	watchlock.Unlock()
+	defer func() {
+		watchlock.Lock()
+		delete(watchers, w)
+		watchlock.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-w.events:
+			err := srv.Send(ev)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// true if this watcher is interested in the artefact
+func (w *watcher) matches(af *pb.ArtefactID) bool {
+	if w.domain != "" && w.domain != af.Domain {
+		return false
+	}
+	if w.all {
+		_, err := requestAccess(w.ctx, af.Name, af.Domain)
+		return err == nil
+	}
+	return w.ids[af.ID]
+}
+
+func (w *watcher) send(ev *pb.BuildEvent) {
+	select {
+	case w.events <- ev:
+	default:
+		fmt.Printf("watcher too slow, dropped event for artefact #%d\n", ev.ArtefactID)
+	}
+}
+
+func startBuildPoller() {
+	watch_poller_once.Do(func() {
+		go (&watch_poller{latest: make(map[uint64]uint64), artefacts: make(map[uint64]*pb.ArtefactID)}).loop()
+	})
+}
+
+func (wp *watch_poller) loop() {
+	for {
+		time.Sleep(*watch_poll_interval)
+		err := wp.poll()
+		if err != nil {
+			fmt.Printf("failed to poll for new builds: %s\n", utils.ErrorString(err))
+		}
+	}
+}
+
+func (wp *watch_poller) poll() error {
+	watchlock.Lock()
+	var ws []*watcher
+	all := false
+	ids := make(map[uint64]bool)
+	for w := range watchers {
+		ws = append(ws, w)
+		if w.all {
+			all = true
+		}
+		for id := range w.ids {
+			ids[id] = true
+		}
+	}
+	watchlock.Unlock()
+
+	ctx := authremote.Context()
+	hooks, err := getWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+	hookdomains := make(map[string]bool)
+	for _, h := range hooks {
+		if h.ArtefactID == 0 {
+			hookdomains[h.Domain] = true
+		} else {
+			ids[h.ArtefactID] = true
+		}
+	}
+	if len(ws) == 0 && len(hooks) == 0 {
+		// nobody is watching, start afresh next time
+		wp.latest = make(map[uint64]uint64)
+		return nil
+	}
+
+	var afs []*pb.ArtefactID
+	if all || len(hookdomains) > 0 {
+		repos, err := brepo.ListRepos(ctx)
+		if err != nil {
+			return err
+		}
+		for _, r := range repos.Entries {
+			if !all && !hookdomains[r.Domain] {
+				continue
+			}
+			afid, err := artefactToID(r.Name, r.Domain)
+			if err != nil {
+				continue
+			}
+			afs = append(afs, &pb.ArtefactID{ID: afid, Name: r.Name, Domain: r.Domain})
+			delete(ids, afid)
+		}
+	}
+	for id := range ids {
+		af := wp.artefacts[id]
+		if af == nil {
+			af, err = idstore.ByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			wp.artefacts[id] = af
+		}
+		afs = append(afs, af)
+	}
+
+	for _, af := range afs {
+		branch := defaultBranch(ctx, af.Domain, af.Name)
+		glv, err := brepo.GetLatestVersion(ctx, af.Domain, &br.GetLatestVersionRequest{Repository: af.Name, Branch: branch})
+		if err != nil {
+			debugf("no latest version for %s: %s\n", af.Name, utils.ErrorString(err))
+			continue
+		}
+		old, known := wp.latest[af.ID]
+		wp.latest[af.ID] = glv.BuildID
+		if !known || old == glv.BuildID {
+			continue
+		}
+		ev := &pb.BuildEvent{
+			ArtefactID: af.ID,
+			Name:       af.Name,
+			Domain:     af.Domain,
+			Branch:     branch,
+			OldBuildID: old,
+			NewBuildID: glv.BuildID,
+			Timestamp:  uint32(time.Now().Unix()),
+		}
+		fmt.Printf("New build of %s: %d -> %d\n", af.Name, old, glv.BuildID)
+		for _, w := range ws {
+			if w.matches(af) {
+				w.send(ev)
+			}
+		}
+		for _, h := range hooks {
+			if h.ArtefactID == af.ID || (h.ArtefactID == 0 && h.Domain == af.Domain) {
+				go deliverWebhook(h, ev)
+			}
+		}
+	}
+	return nil
+}
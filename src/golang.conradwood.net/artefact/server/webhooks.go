@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+	"golang.conradwood.net/apis/common"
+	"golang.conradwood.net/artefact/db"
+	"golang.conradwood.net/go-easyops/auth"
+	"golang.conradwood.net/go-easyops/errors"
+)
+
+/*
+ webhooks are POSTed a json payload whenever a new build of an artefact appears.
+ the payload is signed with the webhook's secret (hmac-sha256, hex encoded, in header SIGNATURE_HEADER).
+ failed deliveries are retried with exponential backoff.
+*/
+
+const (
+	SIGNATURE_HEADER = "X-Artefact-Signature"
+	EVENT_HEADER     = "X-Artefact-Event"
+)
+
+var (
+	webhook_retries = flag.Int("webhook_retries", 8, "how often to retry a failed webhook delivery")
+	webhook_backoff = flag.Duration("webhook_backoff", time.Duration(10)*time.Second, "delay before the first retry of a failed webhook delivery. doubles with each retry")
+	webhook_timeout = flag.Duration("webhook_timeout", time.Duration(15)*time.Second, "timeout for a webhook http request")
+)
+
+// the json sent to webhooks
+type webhook_payload struct {
+	ArtefactID      uint64
+	Name            string
+	Domain          string
+	Branch          string
+	BuildID         uint64
+	PreviousBuildID uint64
+	LinkToVersion   string
+	Timestamp       uint32
+}
+
+func (e *artefactServer) AddWebhook(ctx context.Context, req *pb.Webhook) (*pb.Webhook, error) {
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return nil, errors.Unauthenticated(ctx, "need user account to add webhooks")
+	}
+	pu, err := url.Parse(req.URL)
+	if err != nil || (pu.Scheme != "http" && pu.Scheme != "https") || pu.Host == "" {
+		return nil, errors.InvalidArgs(ctx, "invalid url", "invalid webhook url \"%s\"", req.URL)
+	}
+	wh := &pb.Webhook{
+		ArtefactID: req.ArtefactID,
+		Domain:     req.Domain,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		CreatedBy:  u.ID,
+		Created:    uint32(time.Now().Unix()),
+	}
+	if wh.ArtefactID != 0 {
+		af, err := idstore.ByID(ctx, wh.ArtefactID)
+		if err != nil {
+			return nil, err
+		}
+		err = requestAdminAccess(ctx, af.ID)
+		if err != nil {
+			return nil, err
+		}
+		wh.Domain = af.Domain
+	} else {
+		if wh.Domain == "" {
+			return nil, errors.InvalidArgs(ctx, "artefactid or domain required", "webhook needs artefactid or domain")
+		}
+		if !auth.IsRoot(ctx) {
+			return nil, errors.AccessDenied(ctx, "webhooks for domain \"%s\" denied", wh.Domain)
+		}
+	}
+	if wh.Secret == "" {
+		b := make([]byte, 32)
+		_, err = rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+		wh.Secret = hex.EncodeToString(b)
+	}
+	_, err = db.DefaultDBWebhook().Save(ctx, wh)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("User %s added webhook #%d (artefact #%d, domain \"%s\") to %s\n", auth.Description(u), wh.ID, wh.ArtefactID, wh.Domain, wh.URL)
+	startBuildPoller()
+	return wh, nil
+}
+
+func (e *artefactServer) ListWebhooks(ctx context.Context, req *common.Void) (*pb.WebhookList, error) {
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return nil, errors.Unauthenticated(ctx, "need user account to list webhooks")
+	}
+	whs, err := getWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root := auth.IsRoot(ctx)
+	res := &pb.WebhookList{}
+	for _, wh := range whs {
+		if !root && wh.CreatedBy != u.ID {
+			continue
+		}
+		wh.Secret = ""
+		res.Webhooks = append(res.Webhooks, wh)
+	}
+	return res, nil
+}
+
+func (e *artefactServer) DeleteWebhook(ctx context.Context, req *pb.ID) (*common.Void, error) {
+	u := auth.GetUser(ctx)
+	if u == nil {
+		return nil, errors.Unauthenticated(ctx, "need user account to delete webhooks")
+	}
+	wh, err := db.DefaultDBWebhook().ByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if wh.CreatedBy != u.ID && !auth.IsRoot(ctx) {
+		return nil, errors.AccessDenied(ctx, "webhook #%d not owned by user", wh.ID)
+	}
+	err = db.DefaultDBWebhook().DeleteByID(ctx, wh.ID)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("User %s deleted webhook #%d (%s)\n", auth.Description(u), wh.ID, wh.URL)
+	return &common.Void{}, nil
+}
+
+func getWebhooks(ctx context.Context) ([]*pb.Webhook, error) {
+	return db.DefaultDBWebhook().All(ctx)
+}
+
+// deliver an event to a webhook, retrying if necessary
+func deliverWebhook(wh *pb.Webhook, ev *pb.BuildEvent) {
+	c := &pb.Contents{
+		ArtefactID: &pb.ArtefactID{ID: ev.ArtefactID, Name: ev.Name, Domain: ev.Domain},
+		Version:    ev.NewBuildID,
+		Branch:     ev.Branch,
+	}
+	createArtefactLink(c)
+	payload := &webhook_payload{
+		ArtefactID:      ev.ArtefactID,
+		Name:            ev.Name,
+		Domain:          ev.Domain,
+		Branch:          ev.Branch,
+		BuildID:         ev.NewBuildID,
+		PreviousBuildID: ev.OldBuildID,
+		LinkToVersion:   c.LinkToVersion,
+		Timestamp:       ev.Timestamp,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		fmt.Printf("webhook #%d: failed to marshal payload: %s\n", wh.ID, err)
+		return
+	}
+	backoff := *webhook_backoff
+	for i := 0; ; i++ {
+		err = postWebhook(wh, body)
+		if err == nil {
+			debugf("webhook #%d: delivered build %d of %s\n", wh.ID, ev.NewBuildID, ev.Name)
+			return
+		}
+		if i >= *webhook_retries {
+			fmt.Printf("webhook #%d: giving up after %d attempts: %s\n", wh.ID, i+1, err)
+			return
+		}
+		fmt.Printf("webhook #%d: attempt %d failed, retrying in %0.0fs: %s\n", wh.ID, i+1, backoff.Seconds(), err)
+		time.Sleep(backoff)
+		backoff = backoff * 2
+	}
+}
+
+func postWebhook(wh *pb.Webhook, body []byte) error {
+	req, err := http.NewRequest("POST", wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EVENT_HEADER, "build")
+	req.Header.Set(SIGNATURE_HEADER, "sha256="+signPayload(wh.Secret, body))
+	hc := &http.Client{Timeout: *webhook_timeout}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("http status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// hex encoded hmac-sha256
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
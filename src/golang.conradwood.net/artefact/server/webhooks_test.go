@@ -0,0 +1,130 @@
+package main
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	pb "golang.conradwood.net/apis/artefact"
+)
+
+// records the webhook requests it receives. the first 'failures' requests fail with http 500
+type webhook_listener struct {
+	sync.Mutex
+	failures int
+	secret   string
+	times    []time.Time
+	payloads []*webhook_payload
+	errors   []string
+}
+
+func (wl *webhook_listener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wl.Lock()
+	defer wl.Unlock()
+	wl.times = append(wl.times, time.Now())
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		wl.errors = append(wl.errors, err.Error())
+	}
+	sig := r.Header.Get(SIGNATURE_HEADER)
+	if !hmac.Equal([]byte(sig), []byte("sha256="+signPayload(wl.secret, body))) {
+		wl.errors = append(wl.errors, "invalid signature \""+sig+"\"")
+	}
+	if r.Header.Get(EVENT_HEADER) != "build" {
+		wl.errors = append(wl.errors, "invalid event \""+r.Header.Get(EVENT_HEADER)+"\"")
+	}
+	p := &webhook_payload{}
+	err = json.Unmarshal(body, p)
+	if err != nil {
+		wl.errors = append(wl.errors, err.Error())
+	}
+	wl.payloads = append(wl.payloads, p)
+	if len(wl.times) <= wl.failures {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func setWebhookFlags(t *testing.T, retries int, backoff time.Duration) {
+	oldRetries := *webhook_retries
+	oldBackoff := *webhook_backoff
+	*webhook_retries = retries
+	*webhook_backoff = backoff
+	t.Cleanup(func() {
+		*webhook_retries = oldRetries
+		*webhook_backoff = oldBackoff
+	})
+}
+
+func testBuildEvent() *pb.BuildEvent {
+	return &pb.BuildEvent{ArtefactID: 5, Name: "foo", Domain: "example.com", Branch: "main", OldBuildID: 11, NewBuildID: 12, Timestamp: 1000}
+}
+
+func TestWebhookSignedAndRetried(t *testing.T) {
+	backoff := time.Duration(20) * time.Millisecond
+	setWebhookFlags(t, 5, backoff)
+	wl := &webhook_listener{failures: 2, secret: "s3cret"}
+	srv := httptest.NewServer(wl)
+	defer srv.Close()
+
+	deliverWebhook(&pb.Webhook{ID: 1, URL: srv.URL, Secret: wl.secret}, testBuildEvent())
+
+	wl.Lock()
+	defer wl.Unlock()
+	for _, e := range wl.errors {
+		t.Errorf("webhook request: %s", e)
+	}
+	if len(wl.times) != 3 {
+		t.Fatalf("expected 3 attempts (2 failures, 1 success), got %d", len(wl.times))
+	}
+	// exponential backoff: the second retry waits (at least) twice as long as the first
+	if d := wl.times[1].Sub(wl.times[0]); d < backoff {
+		t.Errorf("first retry after %s, expected at least %s", d, backoff)
+	}
+	if d := wl.times[2].Sub(wl.times[1]); d < 2*backoff {
+		t.Errorf("second retry after %s, expected at least %s", d, 2*backoff)
+	}
+	p := wl.payloads[2]
+	if p.ArtefactID != 5 || p.BuildID != 12 || p.PreviousBuildID != 11 || p.Branch != "main" {
+		t.Errorf("unexpected payload %#v", p)
+	}
+	if p.LinkToVersion == "" {
+		t.Errorf("payload without link")
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	setWebhookFlags(t, 2, time.Millisecond)
+	wl := &webhook_listener{failures: 100, secret: "s3cret"}
+	srv := httptest.NewServer(wl)
+	defer srv.Close()
+
+	deliverWebhook(&pb.Webhook{ID: 1, URL: srv.URL, Secret: wl.secret}, testBuildEvent())
+
+	wl.Lock()
+	defer wl.Unlock()
+	if len(wl.times) != 3 {
+		t.Fatalf("expected 3 attempts (1 + 2 retries), got %d", len(wl.times))
+	}
+}
+
+func TestWebhookWrongSecret(t *testing.T) {
+	setWebhookFlags(t, 0, time.Millisecond)
+	wl := &webhook_listener{secret: "s3cret"}
+	srv := httptest.NewServer(wl)
+	defer srv.Close()
+
+	deliverWebhook(&pb.Webhook{ID: 1, URL: srv.URL, Secret: "other"}, testBuildEvent())
+
+	wl.Lock()
+	defer wl.Unlock()
+	if len(wl.errors) == 0 {
+		t.Errorf("payload signed with a different secret was accepted")
+	}
+}